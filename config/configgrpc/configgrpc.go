@@ -17,12 +17,15 @@ import (
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -34,9 +37,13 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/internal/memorylimiterhelper"
 )
 
-var errMetadataNotFound = errors.New("no request metadata found")
+var (
+	errMetadataNotFound = errors.New("no request metadata found")
+	errMemoryLimited    = status.Error(codes.ResourceExhausted, "data refused due to high memory usage")
+)
 
 // KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter.
 // Refer to the original data-structure for the meaning of each parameter:
@@ -187,6 +194,10 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
+	// MemoryLimiter is the ID of a memory_limiter extension. While the extension refuses data,
+	// the incoming RPCs are rejected with RESOURCE_EXHAUSTED before their messages are read.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
+
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
@@ -319,16 +330,64 @@ func validateBalancerName(balancerName string) bool {
 	return balancer.Get(balancerName) != nil
 }
 
-// ToServer returns a grpc.Server for the configuration
+// ToServer returns a grpc.Server for the configuration.
+// A tap handle must be passed with InTapHandle rather than grpc.InTapHandle, as the server
+// accepts a single tap handle, which also refuses the RPCs while the memory limiter refuses data.
 func (gss *ServerConfig) ToServer(_ context.Context, host component.Host, settings component.TelemetrySettings, extraOpts ...grpc.ServerOption) (*grpc.Server, error) {
 	opts, err := gss.toServerOption(host, settings)
 	if err != nil {
 		return nil, err
 	}
-	opts = append(opts, extraOpts...)
+
+	var tapHandles []tap.ServerInHandle
+	if gss.MemoryLimiter != nil {
+		ml, err := memorylimiterhelper.GetRefuser(*gss.MemoryLimiter, host.GetExtensions())
+		if err != nil {
+			return nil, err
+		}
+		tapHandles = append(tapHandles, memoryLimiterTapHandle(ml))
+	}
+	for _, opt := range extraOpts {
+		if tapOpt, ok := opt.(inTapHandleOption); ok {
+			tapHandles = append(tapHandles, tapOpt.handle)
+			continue
+		}
+		opts = append(opts, opt)
+	}
+	if len(tapHandles) > 0 {
+		opts = append(opts, grpc.InTapHandle(chainTapHandles(tapHandles)))
+	}
 	return grpc.NewServer(opts...), nil
 }
 
+// InTapHandle returns a grpc.ServerOption setting the tap handle of the server returned by ToServer,
+// which is called after the one refusing the RPCs while the memory limiter refuses data.
+func InTapHandle(h tap.ServerInHandle) grpc.ServerOption {
+	return inTapHandleOption{ServerOption: grpc.InTapHandle(h), handle: h}
+}
+
+// inTapHandleOption is recognized by ToServer, which chains its handle with the one of the memory limiter.
+type inTapHandleOption struct {
+	grpc.ServerOption
+	handle tap.ServerInHandle
+}
+
+// chainTapHandles returns a tap handle calling the handles in order, until one of them refuses the RPC.
+func chainTapHandles(handles []tap.ServerInHandle) tap.ServerInHandle {
+	if len(handles) == 1 {
+		return handles[0]
+	}
+	return func(ctx context.Context, info *tap.Info) (context.Context, error) {
+		for _, h := range handles {
+			var err error
+			if ctx, err = h(ctx, info); err != nil {
+				return nil, err
+			}
+		}
+		return ctx, nil
+	}
+}
+
 func (gss *ServerConfig) toServerOption(host component.Host, settings component.TelemetrySettings) ([]grpc.ServerOption, error) {
 	switch gss.NetAddr.Transport {
 	case confignet.TransportTypeTCP, confignet.TransportTypeTCP4, confignet.TransportTypeTCP6, confignet.TransportTypeUDP, confignet.TransportTypeUDP4, confignet.TransportTypeUDP6:
@@ -424,6 +483,16 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 	return opts, nil
 }
 
+// memoryLimiterTapHandle rejects the new streams while the memory limiter refuses data.
+func memoryLimiterTapHandle(ml memorylimiterhelper.Refuser) tap.ServerInHandle {
+	return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		if ml.MustRefuse() {
+			return nil, errMemoryLimited
+		}
+		return ctx, nil
+	}
+}
+
 // getGRPCCompressionName returns compression name registered in grpc.
 func getGRPCCompressionName(compressionType configcompression.Type) (string, error) {
 	switch compressionType {
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,8 +20,11 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -299,6 +303,99 @@ func TestGrpcServerAuthSettings(t *testing.T) {
 	assert.NotNil(t, srv)
 }
 
+func TestGrpcServerMemoryLimiterSettingsError(t *testing.T) {
+	mlID := component.MustNewID("memory_limiter")
+	for _, ext := range []map[component.ID]component.Component{
+		{},
+		{mlID: auth.NewServer()},
+	} {
+		gss := &ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint: "0.0.0.0:1234",
+			},
+			MemoryLimiter: &mlID,
+		}
+		_, err := gss.ToServer(context.Background(), &mockHost{ext: ext}, componenttest.NewNopTelemetrySettings())
+		assert.ErrorContains(t, err, `failed to resolve memory limiter "memory_limiter"`)
+	}
+}
+
+func TestGrpcServerMemoryLimiter(t *testing.T) {
+	mlID := component.MustNewID("memory_limiter")
+	ml := &mockMemoryLimiter{}
+	mock := &grpcTraceServer{}
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		MemoryLimiter: &mlID,
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mlID: ml,
+		},
+	}
+	// The tap handle of the component is chained with the one of the memory limiter.
+	var tapped atomic.Int32
+	tapHandle := func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		tapped.Add(1)
+		return ctx, nil
+	}
+	srv, err := gss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), InTapHandle(tapHandle))
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(srv, mock)
+	defer srv.Stop()
+
+	l, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	gcs := &ClientConfig{
+		Endpoint: l.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+	cl := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+
+	_, err = cl.Export(ctx, ptraceotlp.NewExportRequest())
+	require.NoError(t, err)
+
+	ml.mustRefuse.Store(true)
+	mock.recordedContext = nil
+	_, err = cl.Export(ctx, ptraceotlp.NewExportRequest())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Nil(t, mock.recordedContext)
+	assert.Equal(t, int32(1), tapped.Load())
+}
+
+func TestChainTapHandles(t *testing.T) {
+	var called []int
+	handle := func(i int, err error) tap.ServerInHandle {
+		return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+			called = append(called, i)
+			return ctx, err
+		}
+	}
+
+	_, err := chainTapHandles([]tap.ServerInHandle{handle(0, nil), handle(1, nil)})(context.Background(), &tap.Info{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, called)
+
+	called = nil
+	_, err = chainTapHandles([]tap.ServerInHandle{handle(0, errMemoryLimited), handle(1, nil)})(context.Background(), &tap.Info{})
+	assert.Equal(t, errMemoryLimited, err)
+	assert.Equal(t, []int{0}, called)
+}
+
 func TestGRPCClientSettingsError(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(componentID)
 	require.NoError(t, err)
@@ -1152,6 +1249,16 @@ func tempSocketName(t *testing.T) string {
 	return socket
 }
 
+type mockMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mustRefuse atomic.Bool
+}
+
+func (ml *mockMemoryLimiter) MustRefuse() bool {
+	return ml.mustRefuse.Load()
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
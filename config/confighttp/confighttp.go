@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/config/internal"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/internal/memorylimiterhelper"
 )
 
 const headerContentEncoding = "Content-Encoding"
@@ -293,6 +294,10 @@ type ServerConfig struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
+	// MemoryLimiter is the ID of a memory_limiter extension. While the extension refuses data,
+	// the incoming requests are rejected with 503 Service Unavailable before their body is read.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
+
 	// MaxRequestBodySize sets the maximum request body size in bytes. Default: 20MiB.
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
@@ -390,6 +395,19 @@ func (hss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		handler = authInterceptor(handler, server)
 	}
 
+	if hss.MemoryLimiter != nil {
+		ml, err := memorylimiterhelper.GetRefuser(*hss.MemoryLimiter, host.GetExtensions())
+		if err != nil {
+			return nil, err
+		}
+
+		errHandler := defaultErrorHandler
+		if serverOpts.errHandler != nil {
+			errHandler = serverOpts.errHandler
+		}
+		handler = memoryLimiterInterceptor(handler, ml, errHandler)
+	}
+
 	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) > 0 {
 		co := cors.Options{
 			AllowedOrigins:   hss.CORS.AllowedOrigins,
@@ -485,3 +503,14 @@ func maxRequestBodySizeInterceptor(next http.Handler, maxRecvSize int64) http.Ha
 		next.ServeHTTP(w, r)
 	})
 }
+
+func memoryLimiterInterceptor(next http.Handler, ml memorylimiterhelper.Refuser, errHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ml.MustRefuse() {
+			errHandler(w, r, "data refused due to high memory usage", http.StatusServiceUnavailable)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1242,6 +1242,56 @@ func TestFailedServerAuth(t *testing.T) {
 	assert.Equal(t, response.Result().Status, fmt.Sprintf("%v %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)))
 }
 
+func TestServerMemoryLimiter(t *testing.T) {
+	mlID := component.MustNewID("memory_limiter")
+	ml := &mockMemoryLimiter{}
+	hss := ServerConfig{
+		Endpoint:      "localhost:0",
+		MemoryLimiter: &mlID,
+	}
+	host := &mockHost{
+		ext: map[component.ID]component.Component{
+			mlID: ml,
+		},
+	}
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		handlerCalled = true
+	})
+
+	srv, err := hss.ToServer(context.Background(), host, componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, handlerCalled)
+
+	handlerCalled = false
+	ml.mustRefuse = true
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.False(t, handlerCalled)
+}
+
+func TestInvalidServerMemoryLimiter(t *testing.T) {
+	mlID := component.MustNewID("memory_limiter")
+	for _, ext := range []map[component.ID]component.Component{
+		{},
+		{mlID: auth.NewServer()},
+	} {
+		hss := ServerConfig{
+			MemoryLimiter: &mlID,
+		}
+
+		srv, err := hss.ToServer(context.Background(), &mockHost{ext: ext}, componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+		require.ErrorContains(t, err, `failed to resolve memory limiter "memory_limiter"`)
+		require.Nil(t, srv)
+	}
+}
+
 func TestServerWithErrorHandler(t *testing.T) {
 	// prepare
 	hss := ServerConfig{
@@ -1390,6 +1440,16 @@ func TestDefaultMaxRequestBodySize(t *testing.T) {
 	}
 }
 
+type mockMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mustRefuse bool
+}
+
+func (ml *mockMemoryLimiter) MustRefuse() bool {
+	return ml.mustRefuse
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
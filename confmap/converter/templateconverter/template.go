@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package templateconverter // import "go.opentelemetry.io/collector/confmap/converter/templateconverter"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+const (
+	// keyIf is the key of a map that is only kept when its condition is true.
+	keyIf = "$if"
+	// keyFor is the key of a loop generating map entries or list items.
+	keyFor = "$for"
+
+	loopEach = "each"
+	loopAs   = "as"
+	loopDo   = "do"
+
+	defaultLoopVariable = "item"
+)
+
+type converter struct {
+	funcs template.FuncMap
+}
+
+// NewFactory returns a factory for a confmap.Converter that evaluates the `$if` conditions
+// and the `$for` loops of a confmap.Conf, so that a single configuration can describe
+// several deployments.
+//
+// A map containing a `$if` key is removed, with its parent key or list item, unless the
+// condition evaluates to true. The condition is either a boolean or a text/template, e.g.
+// `$if: '{{ eq (env "CLUSTER") "prod" }}'`. A `$if` key is not allowed at the root.
+//
+// A `$for` key of a map, or a list item that only contains a `$for` key, is replaced by
+// the result of rendering its `do` section once per element of `each`. The current element
+// is available in the keys and string values of `do` as `{{ .item }}`, or under the name
+// given by `as`. `each` is either a list or a comma separated string, that may be a template.
+//
+// Templates can use the `env` function to read environment variables, and the `featureGate`
+// function to check whether a feature gate is enabled.
+func NewFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(newConverter)
+}
+
+func newConverter(confmap.ConverterSettings) confmap.Converter {
+	return converter{
+		funcs: template.FuncMap{
+			"env":         os.Getenv,
+			"featureGate": featureGateEnabled,
+		},
+	}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	raw := conf.ToStringMap()
+	// A false condition would remove the whole configuration.
+	if _, ok := raw[keyIf]; ok {
+		return fmt.Errorf("%s: %q is not allowed at the root of the configuration", formatPath(nil), keyIf)
+	}
+	out, err := c.convertMap(raw, nil, nil)
+	if err != nil {
+		return err
+	}
+	*conf = *confmap.NewFromStringMap(out)
+	return nil
+}
+
+// convertMap returns the converted map, or nil if the map has a `$if` condition that is false.
+// vars holds the loop variables, and is nil outside of loops.
+func (c converter) convertMap(m map[string]any, vars map[string]any, path []string) (map[string]any, error) {
+	if cond, ok := m[keyIf]; ok {
+		keep, err := c.evalCondition(cond, vars, child(path, keyIf))
+		if err != nil || !keep {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]any, len(m))
+	set := func(k string, v any, path []string) error {
+		if _, ok := out[k]; ok {
+			return fmt.Errorf("%s: duplicate key %q", formatPath(path), k)
+		}
+		out[k] = v
+		return nil
+	}
+	for _, k := range keys {
+		switch k {
+		case keyIf:
+			continue
+		case keyFor:
+			items, err := c.expandLoop(m[k], vars, child(path, keyFor))
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				itemMap, ok := item.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("%s: %q must be a map when used as a map key, got %T", formatPath(child(path, keyFor)), loopDo, item)
+				}
+				for ik, iv := range itemMap {
+					if err = set(ik, iv, child(path, keyFor)); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+
+		key := k
+		if vars != nil {
+			rendered, err := c.render(k, vars, child(path, k))
+			if err != nil {
+				return nil, err
+			}
+			key = rendered
+		}
+		val, keep, err := c.convertValue(m[k], vars, child(path, key))
+		if err != nil {
+			return nil, err
+		}
+		if !keep {
+			continue
+		}
+		if err = set(key, val, path); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// convertValue returns the converted value and whether it must be kept.
+func (c converter) convertValue(v any, vars map[string]any, path []string) (any, bool, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		m, err := c.convertMap(val, vars, path)
+		return m, m != nil, err
+	case []any:
+		l, err := c.convertList(val, vars, path)
+		return l, true, err
+	case string:
+		if vars == nil {
+			return val, true, nil
+		}
+		rendered, err := c.renderValue(val, vars, path)
+		return rendered, true, err
+	default:
+		return v, true, nil
+	}
+}
+
+func (c converter) convertList(l []any, vars map[string]any, path []string) ([]any, error) {
+	out := make([]any, 0, len(l))
+	for i, item := range l {
+		itemPath := child(path, strconv.Itoa(i))
+		if m, ok := item.(map[string]any); ok && len(m) == 1 && m[keyFor] != nil {
+			items, err := c.expandLoop(m[keyFor], vars, child(itemPath, keyFor))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, items...)
+			continue
+		}
+		val, keep, err := c.convertValue(item, vars, itemPath)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			out = append(out, val)
+		}
+	}
+	return out, nil
+}
+
+// expandLoop returns the converted `do` section of the loop for every element of `each`.
+func (c converter) expandLoop(v any, vars map[string]any, path []string) ([]any, error) {
+	loop, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a map with %q, %q and %q keys, got %T", formatPath(path), loopEach, loopAs, loopDo, v)
+	}
+	for k := range loop {
+		if k != loopEach && k != loopAs && k != loopDo {
+			return nil, fmt.Errorf("%s: unknown key %q, must be one of %q, %q or %q", formatPath(path), k, loopEach, loopAs, loopDo)
+		}
+	}
+	body, ok := loop[loopDo]
+	if !ok {
+		return nil, fmt.Errorf("%s: missing %q key", formatPath(path), loopDo)
+	}
+	name := defaultLoopVariable
+	if as, ok := loop[loopAs]; ok {
+		if name, ok = as.(string); !ok || name == "" {
+			return nil, fmt.Errorf("%s: %q must be a non empty string", formatPath(child(path, loopAs)), loopAs)
+		}
+	}
+	each, err := c.loopElements(loop[loopEach], vars, child(path, loopEach))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]any, 0, len(each))
+	for _, elem := range each {
+		loopVars := make(map[string]any, len(vars)+1)
+		for k, val := range vars {
+			loopVars[k] = val
+		}
+		loopVars[name] = elem
+		val, keep, err := c.convertValue(body, loopVars, child(path, loopDo))
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			out = append(out, val)
+		}
+	}
+	return out, nil
+}
+
+func (c converter) loopElements(v any, vars map[string]any, path []string) ([]any, error) {
+	switch each := v.(type) {
+	case []any:
+		return each, nil
+	case string:
+		rendered, err := c.render(each, vars, path)
+		if err != nil {
+			return nil, err
+		}
+		var out []any
+		for _, elem := range strings.Split(rendered, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				out = append(out, elem)
+			}
+		}
+		return out, nil
+	case nil:
+		return nil, fmt.Errorf("%s: missing %q key", formatPath(path[:len(path)-1]), loopEach)
+	default:
+		return nil, fmt.Errorf("%s: must be a list or a string, got %T", formatPath(path), v)
+	}
+}
+
+func (c converter) evalCondition(v any, vars map[string]any, path []string) (bool, error) {
+	switch cond := v.(type) {
+	case bool:
+		return cond, nil
+	case string:
+		rendered, err := c.render(cond, vars, path)
+		if err != nil {
+			return false, err
+		}
+		result, err := strconv.ParseBool(strings.TrimSpace(rendered))
+		if err != nil {
+			return false, fmt.Errorf("%s: condition %q must evaluate to a boolean, got %q", formatPath(path), cond, rendered)
+		}
+		return result, nil
+	default:
+		return false, fmt.Errorf("%s: condition must be a boolean or a template, got %T", formatPath(path), v)
+	}
+}
+
+// renderValue renders a string value. A value made of a single template action is
+// decoded as YAML, so that e.g. `{{ .port }}` can produce an integer.
+func (c converter) renderValue(s string, vars map[string]any, path []string) (any, error) {
+	rendered, err := c.render(s, vars, path)
+	if err != nil || rendered == s {
+		return rendered, err
+	}
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{{") || !strings.HasSuffix(trimmed, "}}") || strings.Count(trimmed, "{{") != 1 {
+		return rendered, nil
+	}
+	var out any
+	if err = yaml.Unmarshal([]byte(rendered), &out); err != nil {
+		return rendered, nil //nolint:nilerr // not a YAML value, keep the string
+	}
+	switch out.(type) {
+	case map[string]any, []any, nil:
+		return rendered, nil
+	default:
+		return out, nil
+	}
+}
+
+func (c converter) render(s string, vars map[string]any, path []string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tmpl, err := template.New(formatPath(path)).Funcs(c.funcs).Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("%s: invalid template %q: %w", formatPath(path), s, err)
+	}
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	sb := strings.Builder{}
+	if err = tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("%s: cannot render template %q: %w", formatPath(path), s, err)
+	}
+	return sb.String(), nil
+}
+
+func featureGateEnabled(id string) (bool, error) {
+	enabled, found := false, false
+	featuregate.GlobalRegistry().VisitAll(func(g *featuregate.Gate) {
+		if g.ID() == id {
+			enabled, found = g.IsEnabled(), true
+		}
+	})
+	if !found {
+		return false, fmt.Errorf("unknown feature gate %q", id)
+	}
+	return enabled, nil
+}
+
+// child returns a copy of path extended with key, so that sibling paths never share storage.
+func child(path []string, key string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, key)
+}
+
+func formatPath(path []string) string {
+	if len(path) == 0 {
+		return "<root>"
+	}
+	return strings.Join(path, confmap.KeyDelimiter)
+}
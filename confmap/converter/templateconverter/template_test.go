@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package templateconverter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func createConverter() confmap.Converter {
+	return NewFactory().Create(confmap.ConverterSettings{})
+}
+
+func TestConvert(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "prod")
+	t.Setenv("TENANTS", "red, blue")
+
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "template.yaml"))
+	require.NoError(t, err)
+	expected, err := confmaptest.LoadConf(filepath.Join("testdata", "expected.yaml"))
+	require.NoError(t, err)
+
+	require.NoError(t, createConverter().Convert(context.Background(), conf))
+	assert.Equal(t, expected.ToStringMap(), conf.ToStringMap())
+}
+
+func TestConvertNoTemplate(t *testing.T) {
+	raw := map[string]any{
+		"receivers": map[string]any{
+			"nop": nil,
+			"otlp": map[string]any{
+				"endpoint": "{{ not a template outside of loops }}",
+			},
+		},
+		"list": []any{1, "two", map[string]any{"three": 3.0}},
+	}
+	conf := confmap.NewFromStringMap(raw)
+	require.NoError(t, createConverter().Convert(context.Background(), conf))
+	assert.Equal(t, raw, conf.ToStringMap())
+}
+
+func TestConvertValues(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"ports": map[string]any{
+			"$for": map[string]any{
+				"each": []any{4317, "4318"},
+				"as":   "port",
+				"do": map[string]any{
+					"port_{{ .port }}": map[string]any{
+						"number":  "{{ .port }}",
+						"address": "localhost:{{ .port }}",
+						"enabled": "{{ true }}",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, createConverter().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{
+		"ports": map[string]any{
+			"port_4317": map[string]any{"number": 4317, "address": "localhost:4317", "enabled": true},
+			"port_4318": map[string]any{"number": 4318, "address": "localhost:4318", "enabled": true},
+		},
+	}, conf.ToStringMap())
+}
+
+func TestConvertFeatureGate(t *testing.T) {
+	gate := featuregate.GlobalRegistry().MustRegister("templateconverter.test", featuregate.StageAlpha)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{
+			"enabled":  map[string]any{"$if": `{{ featureGate "templateconverter.test" }}`},
+			"disabled": map[string]any{"$if": `{{ not (featureGate "templateconverter.test") }}`},
+			"always":   map[string]any{"$if": true},
+			"never":    map[string]any{"$if": false},
+		},
+	})
+	require.False(t, gate.IsEnabled())
+	require.NoError(t, createConverter().Convert(context.Background(), conf))
+	assert.Equal(t, map[string]any{
+		"extensions": map[string]any{
+			"disabled": map[string]any{},
+			"always":   map[string]any{},
+		},
+	}, conf.ToStringMap())
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "condition not boolean",
+			raw:         map[string]any{"a": map[string]any{"$if": `{{ env "HOME" | printf "%s-x" }}`}},
+			expectedErr: "a::$if: condition",
+		},
+		{
+			name:        "root condition",
+			raw:         map[string]any{"$if": false, "a": "b"},
+			expectedErr: `<root>: "$if" is not allowed at the root of the configuration`,
+		},
+		{
+			name:        "condition invalid type",
+			raw:         map[string]any{"a": map[string]any{"$if": 1}},
+			expectedErr: "a::$if: condition must be a boolean or a template, got int",
+		},
+		{
+			name:        "invalid template",
+			raw:         map[string]any{"a": map[string]any{"$if": "{{ if }}"}},
+			expectedErr: `a::$if: invalid template "{{ if }}"`,
+		},
+		{
+			name:        "unknown feature gate",
+			raw:         map[string]any{"a": map[string]any{"$if": `{{ featureGate "missing" }}`}},
+			expectedErr: `error calling featureGate: unknown feature gate "missing"`,
+		},
+		{
+			name:        "loop not a map",
+			raw:         map[string]any{"a": map[string]any{"$for": "x"}},
+			expectedErr: `a::$for: must be a map with "each", "as" and "do" keys, got string`,
+		},
+		{
+			name:        "loop unknown key",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"each": []any{1}, "do": 1, "in": 2}}},
+			expectedErr: `a::$for: unknown key "in"`,
+		},
+		{
+			name:        "loop missing do",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"each": []any{1}}}},
+			expectedErr: `a::$for: missing "do" key`,
+		},
+		{
+			name:        "loop missing each",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"do": map[string]any{}}}},
+			expectedErr: `a::$for: missing "each" key`,
+		},
+		{
+			name:        "loop invalid each",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"each": 1, "do": map[string]any{}}}},
+			expectedErr: `a::$for::each: must be a list or a string, got int`,
+		},
+		{
+			name:        "loop invalid as",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"each": []any{1}, "as": 1, "do": map[string]any{}}}},
+			expectedErr: `a::$for::as: "as" must be a non empty string`,
+		},
+		{
+			name:        "loop body not a map",
+			raw:         map[string]any{"a": map[string]any{"$for": map[string]any{"each": []any{1}, "do": "{{ .item }}"}}},
+			expectedErr: `a::$for: "do" must be a map when used as a map key, got int`,
+		},
+		{
+			name:        "duplicate key",
+			raw:         map[string]any{"a": map[string]any{"x": 1, "$for": map[string]any{"each": []any{"x"}, "do": map[string]any{"{{ .item }}": 2}}}},
+			expectedErr: `a: duplicate key "x"`,
+		},
+		{
+			name:        "missing variable",
+			raw:         map[string]any{"a": []any{map[string]any{"$for": map[string]any{"each": []any{1}, "do": "{{ .missing }}"}}}},
+			expectedErr: `a::0::$for::do: cannot render template "{{ .missing }}"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(tt.raw)
+			err := createConverter().Convert(context.Background(), conf)
+			require.ErrorContains(t, err, tt.expectedErr)
+			// The configuration is left untouched on error.
+			assert.Equal(t, tt.raw, conf.ToStringMap())
+		})
+	}
+}
@@ -10,10 +10,16 @@ import (
 	"path/filepath"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"go.opentelemetry.io/collector/confmap"
 )
 
-const schemeName = "file"
+const (
+	schemeName = "file"
+	// includeKey is the key of a map that lists the files merged into the map.
+	includeKey = "$include"
+)
 
 type provider struct{}
 
@@ -32,6 +38,18 @@ type provider struct{}
 // `file:/path/to/file` - absolute path (unix, windows)
 // `file:c:/path/to/file` - absolute path including drive-letter (windows)
 // `file:c:\path\to\file` - absolute path including drive-letter (windows)
+//
+// A map of the file can contain a `$include` key, with a path or a list of paths of YAML files
+// whose content is merged into the map. Relative paths are relative to the directory of the
+// including file, and included files can include other files, as long as there is no cycle.
+// Files are merged in order, and the keys of the including map take precedence over the
+// included ones, e.g. a team can own its pipeline in a separate file:
+//
+//	service:
+//	  $include: [pipelines/team-a.yaml, pipelines/team-b.yaml]
+//	  telemetry:
+//	    logs:
+//	      level: info
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
@@ -46,12 +64,32 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 	}
 
 	// Clean the path before using it.
-	content, err := os.ReadFile(filepath.Clean(uri[len(schemeName)+1:]))
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
 	}
+	if !strings.Contains(string(content), includeKey) {
+		return confmap.NewRetrievedFromYAML(content)
+	}
 
-	return confmap.NewRetrievedFromYAML(content)
+	var rawConf any
+	if err = yaml.Unmarshal(content, &rawConf); err != nil {
+		return nil, fmt.Errorf("unable to parse the file %v: %w", uri, err)
+	}
+	if !hasInclude(rawConf) {
+		// The key only appears in a comment or a value, keep the string representation of the file.
+		return confmap.NewRetrievedFromYAML(content)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	inc := &includer{stack: []string{absPath}}
+	if rawConf, err = inc.resolve(rawConf, filepath.Dir(absPath)); err != nil {
+		return nil, fmt.Errorf("unable to resolve the includes of the file %v: %w", uri, err)
+	}
+	return confmap.NewRetrieved(rawConf)
 }
 
 func (*provider) Scheme() string {
@@ -61,3 +99,150 @@ func (*provider) Scheme() string {
 func (*provider) Shutdown(context.Context) error {
 	return nil
 }
+
+// includer resolves the `$include` keys of a file, and of the files it includes.
+type includer struct {
+	// stack holds the absolute paths of the files being included, to detect cycles.
+	stack []string
+}
+
+// resolve returns v where every map with a `$include` key is merged with the included files.
+// dir is the directory relative paths are resolved from.
+func (inc *includer) resolve(v any, dir string) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			if k == includeKey {
+				continue
+			}
+			resolved, err := inc.resolve(item, dir)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = resolved
+		}
+		includes, ok := val[includeKey]
+		if !ok {
+			return out, nil
+		}
+		paths, err := includePaths(includes)
+		if err != nil {
+			return nil, err
+		}
+		base := map[string]any{}
+		for _, p := range paths {
+			included, err := inc.include(p, dir)
+			if err != nil {
+				return nil, err
+			}
+			merge(base, included)
+		}
+		merge(base, out)
+		return base, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			resolved, err := inc.resolve(item, dir)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// hasInclude returns whether v holds a map with a `$include` key.
+func hasInclude(v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		if _, ok := val[includeKey]; ok {
+			return true
+		}
+		for _, item := range val {
+			if hasInclude(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if hasInclude(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// include reads the YAML map stored at path, relative to dir, and resolves its includes.
+func (inc *includer) include(path string, dir string) (map[string]any, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	for i, p := range inc.stack {
+		if p == path {
+			cycle := append(inc.stack[i:len(inc.stack):len(inc.stack)], path)
+			return nil, fmt.Errorf("include cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the included file %v: %w", path, err)
+	}
+	var rawConf any
+	if err = yaml.Unmarshal(content, &rawConf); err != nil {
+		return nil, fmt.Errorf("unable to parse the included file %v: %w", path, err)
+	}
+	if rawConf == nil {
+		return map[string]any{}, nil
+	}
+	if _, ok := rawConf.(map[string]any); !ok {
+		return nil, fmt.Errorf("the included file %v must contain a map, got %T", path, rawConf)
+	}
+
+	inc.stack = append(inc.stack, path)
+	defer func() { inc.stack = inc.stack[:len(inc.stack)-1] }()
+	resolved, err := inc.resolve(rawConf, filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]any), nil
+}
+
+func includePaths(v any) ([]string, error) {
+	switch val := v.(type) {
+	case string:
+		return []string{val}, nil
+	case []any:
+		paths := make([]string, 0, len(val))
+		for _, item := range val {
+			p, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%q must be a path or a list of paths, got an item of type %T", includeKey, item)
+			}
+			paths = append(paths, p)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("%q must be a path or a list of paths, got %T", includeKey, v)
+	}
+}
+
+// merge deeply merges src into dst, the values of src taking precedence except for maps,
+// which are merged recursively.
+func merge(dst map[string]any, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			merge(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
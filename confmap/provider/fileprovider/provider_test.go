@@ -91,3 +91,64 @@ func absolutePath(t *testing.T, relativePath string) string {
 func createProvider() confmap.Provider {
 	return NewFactory().Create(confmaptest.NewNopProviderSettings())
 }
+
+func TestInclude(t *testing.T) {
+	fp := createProvider()
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "include", "config.yaml"), nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	expectedMap := confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{"batch": nil},
+		"exporters": map[string]any{
+			"otlp": map[string]any{"endpoint": "collector:4317", "compression": "gzip"},
+		},
+		"service": map[string]any{
+			"telemetry": map[string]any{"logs": map[string]any{"level": "info"}},
+			"pipelines": map[string]any{
+				"traces/team-a":  map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlp"}},
+				"metrics/team-b": map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlp"}},
+			},
+		},
+	})
+	assert.Equal(t, expectedMap.ToStringMap(), retMap.ToStringMap())
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestIncludeKeyInText(t *testing.T) {
+	fp := createProvider()
+	path := filepath.Join("testdata", "include", "no-include.yaml")
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, nil)
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	str, err := ret.AsString()
+	require.NoError(t, err)
+	assert.Equal(t, string(content), str)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"exporters": map[string]any{"otlp": map[string]any{"endpoint": "$include"}}}, retMap.ToStringMap())
+	assert.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestIncludeErrors(t *testing.T) {
+	tests := []struct {
+		file        string
+		expectedErr string
+	}{
+		{file: "cycle-a.yaml", expectedErr: "include cycle detected: " + absolutePath(t, filepath.Join("testdata", "include", "cycle-a.yaml"))},
+		{file: "invalid.yaml", expectedErr: `"$include" must be a path or a list of paths, got an item of type int`},
+		{file: "not-a-map.yaml", expectedErr: "must contain a map, got []interface {}"},
+		{file: "invalid-include.yaml", expectedErr: "unable to parse the included file"},
+		{file: "missing.yaml", expectedErr: "unable to read the included file"},
+		{file: "invalid-yaml.yaml", expectedErr: "unable to parse the file " + fileSchemePrefix + filepath.Join("testdata", "include", "invalid-yaml.yaml") + ": yaml:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			fp := createProvider()
+			_, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "include", tt.file), nil)
+			assert.ErrorContains(t, err, tt.expectedErr)
+			assert.NoError(t, fp.Shutdown(context.Background()))
+		})
+	}
+}
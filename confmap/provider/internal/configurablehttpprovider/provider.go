@@ -43,7 +43,13 @@ func New(scheme SchemeType, _ confmap.ProviderSettings) confmap.Provider {
 
 // Create the client based on the type of scheme that was selected.
 func (fmp *provider) createClient() (*http.Client, error) {
-	switch fmp.scheme {
+	return NewClient(fmp.scheme, fmp.caCertPath, fmp.insecureSkipVerify)
+}
+
+// NewClient creates an HTTP client for the given scheme. For HTTPSScheme the system root
+// certificates are trusted, as well as the CA found at caCertPath if not empty.
+func NewClient(scheme SchemeType, caCertPath string, insecureSkipVerify bool) (*http.Client, error) {
+	switch scheme {
 	case HTTPScheme:
 		return &http.Client{}, nil
 	case HTTPSScheme:
@@ -53,32 +59,32 @@ func (fmp *provider) createClient() (*http.Client, error) {
 			return nil, fmt.Errorf("unable to create a cert pool: %w", err)
 		}
 
-		if fmp.caCertPath != "" {
-			cert, err := os.ReadFile(filepath.Clean(fmp.caCertPath))
+		if caCertPath != "" {
+			cert, err := os.ReadFile(filepath.Clean(caCertPath))
 
 			if err != nil {
-				return nil, fmt.Errorf("unable to read CA from %q URI: %w", fmp.caCertPath, err)
+				return nil, fmt.Errorf("unable to read CA from %q URI: %w", caCertPath, err)
 			}
 
 			if ok := pool.AppendCertsFromPEM(cert); !ok {
-				return nil, fmt.Errorf("unable to add CA from uri: %s into the cert pool", fmp.caCertPath)
+				return nil, fmt.Errorf("unable to add CA from uri: %s into the cert pool", caCertPath)
 			}
 		}
 
 		return &http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: fmp.insecureSkipVerify,
+					InsecureSkipVerify: insecureSkipVerify,
 					RootCAs:            pool,
 				},
 			},
 		}, nil
 	default:
-		return nil, fmt.Errorf("invalid scheme type: %s", fmp.scheme)
+		return nil, fmt.Errorf("invalid scheme type: %s", scheme)
 	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
 
 	if !strings.HasPrefix(uri, string(fmp.scheme)+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, string(fmp.scheme))
@@ -90,8 +96,27 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to configure http transport layer: %w", err)
 	}
 
+	body, err := Get(ctx, client, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return confmap.NewRetrievedFromYAML(body)
+}
+
+// Get sends a HTTP GET request with the given header to uri and returns the body of the response.
+// An error is returned if the response status code is not 200.
+func Get(ctx context.Context, client *http.Client, uri string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the HTTP GET request for uri %q: %w", uri, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
 	// send a HTTP GET request
-	resp, err := client.Get(uri)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("unable to download the file via HTTP GET for uri %q: %w ", uri, err)
 	}
@@ -107,8 +132,7 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 	if err != nil {
 		return nil, fmt.Errorf("fail to read the response body from uri %q: %w", uri, err)
 	}
-
-	return confmap.NewRetrievedFromYAML(body)
+	return body, nil
 }
 
 func (fmp *provider) Scheme() string {
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vaultprovider // import "go.opentelemetry.io/collector/confmap/provider/vaultprovider"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/configurablehttpprovider"
+)
+
+const (
+	schemeName       = "vault"
+	fieldSeparator   = "#"
+	apiVersionPrefix = "/v1/"
+	headerToken      = "X-Vault-Token"
+	headerNamespace  = "X-Vault-Namespace"
+)
+
+// Environment variables configuring the provider, named after the ones used by the Vault CLI.
+const (
+	envAddress    = "VAULT_ADDR"
+	envToken      = "VAULT_TOKEN"
+	envTokenFile  = "VAULT_TOKEN_FILE"
+	envNamespace  = "VAULT_NAMESPACE"
+	envCACert     = "VAULT_CACERT"
+	envSkipVerify = "VAULT_SKIP_VERIFY"
+)
+
+var errMissingAddress = errors.New("the " + envAddress + " environment variable must be set")
+
+type provider struct {
+	logger *zap.Logger
+	// leaseUnit is the unit of the lease durations returned by the server, used for tests.
+	leaseUnit time.Duration
+
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads secrets from a Vault
+// compatible HTTP API, e.g. the Vault KV secrets engine.
+//
+// This Provider supports "vault" scheme, and can be called with a secret path and an optional field:
+// `vault:secret/data/otelcol#api_key`. The path is relative to the `/v1/` API prefix.
+// Responses of the KV version 2 engine are detected and unwrapped automatically.
+// Without a field, all the fields of the secret are returned as a map.
+//
+// The server address is read from the VAULT_ADDR environment variable. The token is read
+// from VAULT_TOKEN, or from the file referenced by VAULT_TOKEN_FILE (e.g. a Vault agent sink)
+// each time a secret is fetched. VAULT_NAMESPACE, VAULT_CACERT and VAULT_SKIP_VERIFY are also supported.
+//
+// If the secret has a lease, it is fetched again when the lease expires and the
+// confmap.WatcherFunc is called if its value changed.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(set confmap.ProviderSettings) confmap.Provider {
+	return &provider{
+		logger:    set.Logger,
+		leaseUnit: time.Second,
+		watchers:  map[*watcher]struct{}{},
+	}
+}
+
+func (vp *provider) Retrieve(ctx context.Context, uri string, watcherFunc confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	secretPath, field, _ := strings.Cut(uri[len(schemeName)+1:], fieldSeparator)
+	if secretPath == "" {
+		return nil, fmt.Errorf("%q uri must contain a secret path", uri)
+	}
+
+	val, lease, err := vp.fetch(ctx, secretPath, field)
+	if err != nil {
+		return nil, err
+	}
+
+	if lease <= 0 || watcherFunc == nil {
+		return confmap.NewRetrieved(val)
+	}
+	// Keep a snapshot to detect changes, as the returned value may be modified by the caller.
+	snapshot, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	w := vp.watch(secretPath, field, snapshot, lease, watcherFunc)
+	return confmap.NewRetrieved(val, confmap.WithRetrievedClose(func(context.Context) error {
+		vp.stop(w)
+		return nil
+	}))
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (vp *provider) Shutdown(context.Context) error {
+	vp.mu.Lock()
+	watchers := make([]*watcher, 0, len(vp.watchers))
+	for w := range vp.watchers {
+		watchers = append(watchers, w)
+	}
+	vp.mu.Unlock()
+
+	for _, w := range watchers {
+		vp.stop(w)
+	}
+	return nil
+}
+
+// secretResponse is the subset of a Vault API response used by the provider.
+type secretResponse struct {
+	LeaseDuration int            `json:"lease_duration"`
+	Data          map[string]any `json:"data"`
+}
+
+// fetch returns the secret (or the field of the secret) stored at secretPath and its lease duration.
+func (vp *provider) fetch(ctx context.Context, secretPath string, field string) (any, time.Duration, error) {
+	address := os.Getenv(envAddress)
+	if address == "" {
+		return nil, 0, errMissingAddress
+	}
+	scheme, _, _ := strings.Cut(address, ":")
+	client, err := configurablehttpprovider.NewClient(configurablehttpprovider.SchemeType(scheme), os.Getenv(envCACert), os.Getenv(envSkipVerify) == "true")
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to configure http transport layer: %w", err)
+	}
+
+	header := http.Header{}
+	token, err := readToken()
+	if err != nil {
+		return nil, 0, err
+	}
+	if token != "" {
+		header.Set(headerToken, token)
+	}
+	if namespace := os.Getenv(envNamespace); namespace != "" {
+		header.Set(headerNamespace, namespace)
+	}
+
+	body, err := configurablehttpprovider.Get(ctx, client, strings.TrimSuffix(address, "/")+apiVersionPrefix+strings.TrimPrefix(secretPath, "/"), header)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp := secretResponse{}
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, 0, fmt.Errorf("unable to decode secret %q: %w", secretPath, err)
+	}
+	data := unwrapKVv2(resp.Data)
+	lease := time.Duration(resp.LeaseDuration) * vp.leaseUnit
+
+	if field == "" {
+		return normalize(data), lease, nil
+	}
+	val, ok := data[field]
+	if !ok {
+		return nil, 0, fmt.Errorf("field %q not found in secret %q", field, secretPath)
+	}
+	return normalize(val), lease, nil
+}
+
+// readToken returns the token from the environment, or from the token file if set.
+func readToken() (string, error) {
+	if token := os.Getenv(envToken); token != "" {
+		return token, nil
+	}
+	tokenFile := os.Getenv(envTokenFile)
+	if tokenFile == "" {
+		return "", nil
+	}
+	token, err := os.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return "", fmt.Errorf("unable to read token from %q: %w", tokenFile, err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// unwrapKVv2 returns the fields of a secret read from a KV version 2 engine,
+// which are nested under "data" next to the secret "metadata".
+func unwrapKVv2(data map[string]any) map[string]any {
+	if len(data) != 2 {
+		return data
+	}
+	inner, isMap := data["data"].(map[string]any)
+	_, hasMetadata := data["metadata"].(map[string]any)
+	if !isMap || !hasMetadata {
+		return data
+	}
+	return inner
+}
+
+// normalize converts the JSON numbers with no fractional part to int, so that
+// they can be used where the configuration expects integers.
+func normalize(v any) any {
+	switch val := v.(type) {
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < math.MaxInt64 {
+			return int(val)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = normalize(val[i])
+		}
+		return val
+	case map[string]any:
+		for k := range val {
+			val[k] = normalize(val[k])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// watcher fetches a secret again when its lease expires.
+type watcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (vp *provider) watch(secretPath string, field string, snapshot []byte, lease time.Duration, watcherFunc confmap.WatcherFunc) *watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &watcher{cancel: cancel, done: make(chan struct{})}
+	vp.mu.Lock()
+	vp.watchers[w] = struct{}{}
+	vp.mu.Unlock()
+
+	go func() {
+		defer close(w.done)
+		timer := time.NewTimer(lease)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			newVal, newLease, err := vp.fetch(ctx, secretPath, field)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				watcherFunc(&confmap.ChangeEvent{Error: err})
+				return
+			}
+			if newSnapshot, _ := json.Marshal(newVal); !bytes.Equal(snapshot, newSnapshot) {
+				vp.logger.Debug("Secret changed after lease expiration", zap.String("path", secretPath))
+				watcherFunc(&confmap.ChangeEvent{})
+				return
+			}
+			if newLease <= 0 {
+				return
+			}
+			timer.Reset(newLease)
+		}
+	}()
+	return w
+}
+
+func (vp *provider) stop(w *watcher) {
+	vp.mu.Lock()
+	delete(vp.watchers, w)
+	vp.mu.Unlock()
+	w.cancel()
+	<-w.done
+}
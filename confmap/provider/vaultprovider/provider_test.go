@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vaultprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+const testToken = "s.test-token"
+
+// newTestServer starts a stand-in for the Vault HTTP API serving the given responses by path.
+func newTestServer(t *testing.T, responses map[string]func() string) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headerToken) != testToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(resp()))
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv(envAddress, ts.URL)
+	return ts
+}
+
+func staticResponse(resp string) func() string {
+	return func() string { return resp }
+}
+
+func createProvider() confmap.Provider {
+	return NewFactory().Create(confmaptest.NewNopProviderSettings())
+}
+
+func TestSupportedScheme(t *testing.T) {
+	vp := createProvider()
+	assert.Equal(t, "vault", vp.Scheme())
+	require.NoError(t, vp.Shutdown(context.Background()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	vp := createProvider()
+	_, err := vp.Retrieve(context.Background(), "https://secret/data/test", nil)
+	require.Error(t, err)
+	_, err = vp.Retrieve(context.Background(), "vault:#field", nil)
+	require.Error(t, err)
+	require.NoError(t, vp.Shutdown(context.Background()))
+}
+
+func TestMissingAddress(t *testing.T) {
+	t.Setenv(envAddress, "")
+	vp := createProvider()
+	_, err := vp.Retrieve(context.Background(), "vault:secret/data/test", nil)
+	require.ErrorIs(t, err, errMissingAddress)
+	require.NoError(t, vp.Shutdown(context.Background()))
+}
+
+func TestRetrieve(t *testing.T) {
+	newTestServer(t, map[string]func() string{
+		"/v1/secret/data/otelcol": staticResponse(`{"data": {"data": {"api_key": "abc", "port": 4317, "ratio": 0.5}, "metadata": {"version": 3}}}`),
+		"/v1/kv/otelcol":          staticResponse(`{"lease_duration": 0, "data": {"password": "s3cr3t"}}`),
+	})
+	t.Setenv(envToken, testToken)
+
+	tests := []struct {
+		name     string
+		uri      string
+		expected any
+	}{
+		{name: "kv v2 field", uri: "vault:secret/data/otelcol#api_key", expected: "abc"},
+		{name: "kv v2 integer field", uri: "vault:secret/data/otelcol#port", expected: 4317},
+		{name: "kv v2 whole secret", uri: "vault:secret/data/otelcol", expected: map[string]any{"api_key": "abc", "port": 4317, "ratio": 0.5}},
+		{name: "kv v1 field", uri: "vault:kv/otelcol#password", expected: "s3cr3t"},
+		{name: "leading slash", uri: "vault:/kv/otelcol#password", expected: "s3cr3t"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := createProvider()
+			ret, err := vp.Retrieve(context.Background(), tt.uri, nil)
+			require.NoError(t, err)
+			raw, err := ret.AsRaw()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, raw)
+			require.NoError(t, ret.Close(context.Background()))
+			require.NoError(t, vp.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRetrieveErrors(t *testing.T) {
+	newTestServer(t, map[string]func() string{
+		"/v1/kv/otelcol": staticResponse(`{"data": {"password": "s3cr3t"}}`),
+		"/v1/kv/invalid": staticResponse(`not json`),
+	})
+
+	tests := []struct {
+		name        string
+		token       string
+		uri         string
+		expectedErr string
+	}{
+		{name: "missing token", uri: "vault:kv/otelcol#password", expectedErr: "status code: 403"},
+		{name: "not found", token: testToken, uri: "vault:kv/missing#password", expectedErr: "status code: 404"},
+		{name: "missing field", token: testToken, uri: "vault:kv/otelcol#user", expectedErr: `field "user" not found in secret "kv/otelcol"`},
+		{name: "invalid response", token: testToken, uri: "vault:kv/invalid", expectedErr: `unable to decode secret "kv/invalid"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envToken, tt.token)
+			vp := createProvider()
+			_, err := vp.Retrieve(context.Background(), tt.uri, nil)
+			require.ErrorContains(t, err, tt.expectedErr)
+			require.NoError(t, vp.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRetrieveTokenFile(t *testing.T) {
+	newTestServer(t, map[string]func() string{
+		"/v1/kv/otelcol": staticResponse(`{"data": {"password": "s3cr3t"}}`),
+	})
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(testToken+"\n"), 0600))
+	t.Setenv(envToken, "")
+	t.Setenv(envTokenFile, tokenFile)
+
+	vp := createProvider()
+	ret, err := vp.Retrieve(context.Background(), "vault:kv/otelcol#password", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+	require.NoError(t, vp.Shutdown(context.Background()))
+
+	t.Setenv(envTokenFile, filepath.Join(t.TempDir(), "missing"))
+	_, err = vp.Retrieve(context.Background(), "vault:kv/otelcol#password", nil)
+	require.ErrorContains(t, err, "unable to read token")
+}
+
+func TestRetrieveLeaseRefresh(t *testing.T) {
+	var version atomic.Int32
+	newTestServer(t, map[string]func() string{
+		"/v1/database/creds/otelcol": func() string {
+			return fmt.Sprintf(`{"lease_duration": 10, "data": {"password": "p%d"}}`, version.Load())
+		},
+	})
+	t.Setenv(envToken, testToken)
+
+	vp := createProvider()
+	vp.(*provider).leaseUnit = time.Millisecond
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := vp.Retrieve(context.Background(), "vault:database/creds/otelcol#password", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "p0", raw)
+
+	// The value does not change over a few leases.
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	version.Store(1)
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, vp.Shutdown(context.Background()))
+}
+
+func TestRetrieveLeaseRefreshError(t *testing.T) {
+	var failing atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"lease_duration": 10, "data": {"password": "p"}}`))
+	}))
+	defer ts.Close()
+	t.Setenv(envAddress, ts.URL)
+
+	vp := createProvider()
+	vp.(*provider).leaseUnit = time.Millisecond
+
+	failing.Store(true)
+	events := make(chan *confmap.ChangeEvent, 1)
+	_, err := vp.Retrieve(context.Background(), "vault:database/creds/otelcol#password", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.Error(t, err)
+
+	failing.Store(false)
+	_, err = vp.Retrieve(context.Background(), "vault:database/creds/otelcol#password", func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	failing.Store(true)
+
+	select {
+	case event := <-events:
+		require.ErrorContains(t, event.Error, "status code: 500")
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+	// Shutdown stops the watchers that were not closed.
+	require.NoError(t, vp.Shutdown(context.Background()))
+}
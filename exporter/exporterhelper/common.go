@@ -5,6 +5,7 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.uber.org/multierr"
@@ -16,6 +17,8 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/statusreporter"
 )
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -231,8 +234,9 @@ type baseExporter struct {
 	marshaler   exporterqueue.Marshaler[Request]
 	unmarshaler exporterqueue.Unmarshaler[Request]
 
-	set    exporter.Settings
-	obsrep *ObsReport
+	set            exporter.Settings
+	obsrep         *ObsReport
+	statusReporter *statusreporter.Reporter
 
 	// Message for the user to be added with an export failure message.
 	exportFailureMessage string
@@ -242,6 +246,7 @@ type baseExporter struct {
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
 	batchSender   requestSender
 	queueSender   requestSender
+	statusSender  requestSender
 	obsrepSender  requestSender
 	retrySender   requestSender
 	timeoutSender *timeoutSender // timeoutSender is always initialized.
@@ -255,17 +260,20 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 		return nil, err
 	}
 
+	sr := statusreporter.New(set.TelemetrySettings)
 	be := &baseExporter{
 		signal: signal,
 
 		batchSender:   &baseRequestSender{},
 		queueSender:   &baseRequestSender{},
+		statusSender:  &statusSender{reporter: sr},
 		obsrepSender:  osf(obsReport),
 		retrySender:   &baseRequestSender{},
 		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings()},
 
-		set:    set,
-		obsrep: obsReport,
+		set:            set,
+		obsrep:         obsReport,
+		statusReporter: sr,
 	}
 
 	for _, op := range options {
@@ -292,6 +300,9 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 // send sends the request using the first sender in the chain.
 func (be *baseExporter) send(ctx context.Context, req Request) error {
 	err := be.queueSender.send(ctx, req)
+	if errors.Is(err, queue.ErrQueueIsFull) {
+		be.statusReporter.Recoverable(err)
+	}
 	if err != nil {
 		be.set.Logger.Error("Exporting failed. Rejecting data."+be.exportFailureMessage,
 			zap.Error(err), zap.Int("rejected_items", req.ItemsCount()))
@@ -302,7 +313,8 @@ func (be *baseExporter) send(ctx context.Context, req Request) error {
 // connectSenders connects the senders in the predefined order.
 func (be *baseExporter) connectSenders() {
 	be.queueSender.setNextSender(be.batchSender)
-	be.batchSender.setNextSender(be.obsrepSender)
+	be.batchSender.setNextSender(be.statusSender)
+	be.statusSender.setNextSender(be.obsrepSender)
 	be.obsrepSender.setNextSender(be.retrySender)
 	be.retrySender.setNextSender(be.timeoutSender)
 }
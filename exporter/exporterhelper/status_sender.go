@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+	"go.opentelemetry.io/collector/internal/statusreporter"
+)
+
+// statusSender reports the outcome of sending the requests, after the retries, to the statusreporter.Reporter.
+// Permanent errors are ignored, as those are specific to the data being sent.
+type statusSender struct {
+	baseRequestSender
+	reporter *statusreporter.Reporter
+}
+
+func (ss *statusSender) send(ctx context.Context, req Request) error {
+	err := ss.nextSender.send(ctx, req)
+	switch {
+	case err == nil:
+		ss.reporter.OK()
+	case !consumererror.IsPermanent(err) && !experr.IsShutdownErr(err):
+		ss.reporter.Recoverable(err)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// statusRecorder records the status events reported by a component.
+type statusRecorder struct {
+	mu     sync.Mutex
+	events []*component.StatusEvent
+}
+
+func (sr *statusRecorder) reportStatus(ev *component.StatusEvent) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.events = append(sr.events, ev)
+}
+
+func (sr *statusRecorder) statuses() []component.Status {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	statuses := make([]component.Status, 0, len(sr.events))
+	for _, ev := range sr.events {
+		statuses = append(statuses, ev.Status())
+	}
+	return statuses
+}
+
+func TestTracesExporter_StatusReporting(t *testing.T) {
+	rec := &statusRecorder{}
+	set := exportertest.NewNopSettings()
+	set.ReportStatus = rec.reportStatus
+
+	var pushErr error
+	te, err := NewTracesExporter(context.Background(), set, &fakeTracesExporterConfig, func(context.Context, ptrace.Traces) error {
+		return pushErr
+	})
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, rec.statuses())
+
+	// Permanent errors are specific to the data, and do not change the status.
+	pushErr = consumererror.NewPermanent(errors.New("invalid data"))
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, rec.statuses())
+
+	pushErr = errors.New("connection refused")
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	require.Error(t, te.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, []component.Status{component.StatusRecoverableError}, rec.statuses())
+
+	pushErr = nil
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, rec.statuses())
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestTracesExporter_StatusReportingQueueFull(t *testing.T) {
+	rec := &statusRecorder{}
+	set := exportertest.NewNopSettings()
+	set.ReportStatus = rec.reportStatus
+
+	release := make(chan struct{})
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 1
+	te, err := NewTracesExporter(context.Background(), set, &fakeTracesExporterConfig, func(context.Context, ptrace.Traces) error {
+		<-release
+		return nil
+	}, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraces(1)
+	// The first request is blocked in the consumer, the second one fills the queue.
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.Eventually(t, func() bool {
+		return te.(*traceExporter).queueSender.(*queueSender).queue.Size() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.ErrorIs(t, te.ConsumeTraces(context.Background(), td), queue.ErrQueueIsFull)
+	assert.Equal(t, []component.Status{component.StatusRecoverableError}, rec.statuses())
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return len(rec.statuses()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, component.StatusOK, rec.statuses()[1])
+	require.NoError(t, te.Shutdown(context.Background()))
+}
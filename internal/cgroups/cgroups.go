@@ -53,6 +53,9 @@ const (
 	// _cgroupv2MemoryMax is the file name for the CGroup-V2 Memory max
 	// parameter.
 	_cgroupv2MemoryMax = "memory.max"
+	// _cgroupv2MemoryHigh is the file name for the CGroup-V2 Memory high
+	// (throttle) parameter.
+	_cgroupv2MemoryHigh = "memory.high"
 	// _cgroupFSType is the Linux CGroup-V2 file system type used in
 	// `/proc/$PID/mountinfo`.
 	_cgroupv2FSType = "cgroup2"
@@ -149,10 +152,18 @@ func isCGroupV2(procPathMountInfo string) (bool, error) {
 }
 
 // MemoryQuotaV2 returns the total memory limit of the process
-// It is a result of cgroupv2 `memory.max`. If the value of
-// `memory.max` was not set (max), the method returns `(-1, false, nil)`.
+// It is a result of cgroupv2 `memory.max` of the cgroup of the process and of
+// its ancestors. If the value of `memory.max` was not set (max), the method
+// returns `(-1, false, nil)`.
 func MemoryQuotaV2() (int64, bool, error) {
-	return memoryQuotaV2(_cgroupv2MountPoint, _cgroupv2MemoryMax)
+	cg, err := NewCGroupV2ForCurrentProcess()
+	if err != nil {
+		return -1, false, err
+	}
+	if cg == nil {
+		return memoryQuotaV2(_cgroupv2MountPoint, _cgroupv2MemoryMax)
+	}
+	return cg.MemoryQuota()
 }
 
 func memoryQuotaV2(cgroupv2MountPoint, cgroupv2MemoryMax string) (int64, bool, error) {
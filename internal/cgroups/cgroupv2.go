@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package cgroups // import "go.opentelemetry.io/collector/internal/cgroups"
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// _cgroupv2HierarchyID is the hierarchy ID of the unified hierarchy in
+// `/proc/$PID/cgroup`.
+const _cgroupv2HierarchyID = 0
+
+// CGroupV2 represents the cgroup of a process in the unified (cgroup2) hierarchy.
+// The limits of a cgroup2 apply to all its descendants, the effective limits of
+// the process are the lowest ones set from its cgroup up to the root of the hierarchy.
+type CGroupV2 struct {
+	*CGroup
+	// mountPoint is the path the unified hierarchy is mounted at.
+	mountPoint string
+}
+
+// NewCGroupV2 returns the *CGroupV2 of a process from its `mountinfo` and
+// `cgroup` files under the `/proc` file system, or nil if the process does not
+// belong to a mounted unified hierarchy.
+// Both the pure unified hierarchy and the hybrid one, where the cgroup2 file
+// system is mounted next to the cgroup v1 controllers, are supported.
+func NewCGroupV2(procPathMountInfo, procPathCGroup string) (*CGroupV2, error) {
+	cgroupSubsystems, err := parseCGroupSubsystems(procPathCGroup)
+	if err != nil {
+		return nil, err
+	}
+	// The unified hierarchy has no controller listed in `/proc/$PID/cgroup`.
+	subsys, exists := cgroupSubsystems[""]
+	if !exists || subsys.ID != _cgroupv2HierarchyID {
+		return nil, nil
+	}
+
+	var cgroup *CGroupV2
+	newMountPoint := func(mp *MountPoint) error {
+		if mp.FSType != _cgroupv2FSType || cgroup != nil {
+			return nil
+		}
+		cgroupPath, err := mp.Translate(subsys.Name)
+		if err != nil {
+			var notExposedErr pathNotExposedFromMountPointError
+			if !errors.As(err, &notExposedErr) {
+				return err
+			}
+			// The cgroup namespace of the process hides the ancestors of its
+			// cgroup, the mount point is the closest visible cgroup.
+			cgroupPath = mp.MountPoint
+		}
+		cgroup = &CGroupV2{CGroup: NewCGroup(cgroupPath), mountPoint: mp.MountPoint}
+		return nil
+	}
+	if err := parseMountInfo(procPathMountInfo, newMountPoint); err != nil {
+		return nil, err
+	}
+	return cgroup, nil
+}
+
+// NewCGroupV2ForCurrentProcess returns the *CGroupV2 of the current process,
+// or nil if the current process does not belong to a mounted unified hierarchy.
+func NewCGroupV2ForCurrentProcess() (*CGroupV2, error) {
+	return NewCGroupV2(_procPathMountInfo, _procPathCGroup)
+}
+
+// MountPoint returns the path the unified hierarchy is mounted at.
+func (cg *CGroupV2) MountPoint() string {
+	return cg.mountPoint
+}
+
+// MemoryQuota returns the effective hard memory limit of the process, the lowest
+// `memory.max` of its cgroup and of its ancestors. If no `memory.max` was set (max),
+// the method returns `(-1, false, nil)`.
+func (cg *CGroupV2) MemoryQuota() (int64, bool, error) {
+	return cg.lowestLimit(_cgroupv2MemoryMax)
+}
+
+// MemoryHigh returns the effective memory throttle limit of the process, the lowest
+// `memory.high` of its cgroup and of its ancestors. If no `memory.high` was set (max),
+// the method returns `(-1, false, nil)`.
+func (cg *CGroupV2) MemoryHigh() (int64, bool, error) {
+	return cg.lowestLimit(_cgroupv2MemoryHigh)
+}
+
+// lowestLimit returns the lowest limit set in the param file of the cgroup and of
+// its ancestors, up to the mount point. The param files are missing from the root
+// cgroup, and from the cgroups whose parent does not enable the memory controller.
+func (cg *CGroupV2) lowestLimit(param string) (int64, bool, error) {
+	lowest, defined := int64(-1), false
+	path := cg.path
+	for {
+		limit, ok, err := memoryQuotaV2(path, param)
+		if err != nil {
+			return -1, false, err
+		}
+		if ok && (!defined || limit < lowest) {
+			lowest, defined = limit, true
+		}
+		if path == cg.mountPoint || !strings.HasPrefix(path, cg.mountPoint) {
+			return lowest, defined, nil
+		}
+		path = filepath.Dir(path)
+	}
+}
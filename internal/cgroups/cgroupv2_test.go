@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package cgroups
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCGroupV2(t *testing.T) {
+	testTable := []struct {
+		name               string
+		expectedPath       string
+		expectedMountPoint string
+	}{
+		{
+			name:               "cgroupv2",
+			expectedPath:       "/sys/fs/cgroup",
+			expectedMountPoint: "/sys/fs/cgroup",
+		},
+		{
+			name:               "nested",
+			expectedPath:       "/sys/fs/cgroup/kubepods.slice/pod1/container1",
+			expectedMountPoint: "/sys/fs/cgroup",
+		},
+		{
+			name:               "cgroupv1v2",
+			expectedPath:       "/sys/fs/cgroup/unified/user.slice/session-1.scope",
+			expectedMountPoint: "/sys/fs/cgroup/unified",
+		},
+		{
+			name:               "namespaced",
+			expectedPath:       "/sys/fs/cgroup",
+			expectedMountPoint: "/sys/fs/cgroup",
+		},
+	}
+
+	for _, tt := range testTable {
+		basePath := filepath.Join(testDataProcPath, "v2", tt.name)
+		cg, err := NewCGroupV2(filepath.Join(basePath, "mountinfo"), filepath.Join(basePath, "cgroup"))
+		require.NoError(t, err, tt.name)
+		require.NotNil(t, cg, tt.name)
+		assert.Equal(t, tt.expectedPath, cg.Path(), tt.name)
+		assert.Equal(t, tt.expectedMountPoint, cg.MountPoint(), tt.name)
+	}
+}
+
+func TestNewCGroupV2WithoutUnifiedHierarchy(t *testing.T) {
+	basePath := filepath.Join(testDataProcPath, "v2", "cgroupv1")
+	cg, err := NewCGroupV2(filepath.Join(basePath, "mountinfo"), filepath.Join(basePath, "cgroup"))
+	assert.NoError(t, err)
+	assert.Nil(t, cg)
+
+	// The process belongs to the unified hierarchy, but it is not mounted.
+	cg, err = NewCGroupV2(filepath.Join(basePath, "mountinfo"), filepath.Join(testDataProcPath, "v2", "cgroupv2", "cgroup"))
+	assert.NoError(t, err)
+	assert.Nil(t, cg)
+}
+
+func TestNewCGroupV2WithErrors(t *testing.T) {
+	testTable := []struct {
+		mountInfoPath string
+		cgroupPath    string
+	}{
+		{"non-existing-file", filepath.Join(testDataProcPath, "v2", "cgroupv2", "cgroup")},
+		{"/dev/null", "non-existing-file"},
+		{"/dev/null", filepath.Join(testDataProcPath, "invalid-cgroup", "cgroup")},
+		{filepath.Join(testDataProcPath, "invalid-mountinfo", "mountinfo"), filepath.Join(testDataProcPath, "v2", "cgroupv2", "cgroup")},
+	}
+
+	for _, tt := range testTable {
+		cg, err := NewCGroupV2(tt.mountInfoPath, tt.cgroupPath)
+		assert.Nil(t, cg)
+		assert.Error(t, err)
+	}
+}
+
+func TestCGroupV2MemoryLimits(t *testing.T) {
+	mountPoint := filepath.Join(testDataCGroupsPath, "v2", "nested")
+	testTable := []struct {
+		name                string
+		path                string
+		expectedQuota       int64
+		expectedDefined     bool
+		expectedHigh        int64
+		expectedHighDefined bool
+	}{
+		{
+			name:                "leaf",
+			path:                filepath.Join(mountPoint, "kubepods.slice", "pod1", "container1"),
+			expectedQuota:       600000000,
+			expectedDefined:     true,
+			expectedHigh:        400000000,
+			expectedHighDefined: true,
+		},
+		{
+			name:                "parent",
+			path:                filepath.Join(mountPoint, "kubepods.slice"),
+			expectedQuota:       -1,
+			expectedDefined:     false,
+			expectedHigh:        400000000,
+			expectedHighDefined: true,
+		},
+		{
+			name:                "root",
+			path:                mountPoint,
+			expectedQuota:       -1,
+			expectedDefined:     false,
+			expectedHigh:        -1,
+			expectedHighDefined: false,
+		},
+	}
+
+	for _, tt := range testTable {
+		cg := &CGroupV2{CGroup: NewCGroup(tt.path), mountPoint: mountPoint}
+
+		quota, defined, err := cg.MemoryQuota()
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expectedQuota, quota, tt.name)
+		assert.Equal(t, tt.expectedDefined, defined, tt.name)
+
+		high, defined, err := cg.MemoryHigh()
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expectedHigh, high, tt.name)
+		assert.Equal(t, tt.expectedHighDefined, defined, tt.name)
+	}
+}
+
+func TestCGroupV2MemoryLimitsWithErrors(t *testing.T) {
+	mountPoint := filepath.Join(testDataCGroupsPath, "v2")
+	cg := &CGroupV2{CGroup: NewCGroup(filepath.Join(mountPoint, "invalid")), mountPoint: mountPoint}
+
+	quota, defined, err := cg.MemoryQuota()
+	assert.Error(t, err)
+	assert.Equal(t, int64(-1), quota)
+	assert.False(t, defined)
+}
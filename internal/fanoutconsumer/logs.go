@@ -16,8 +16,8 @@ import (
 
 // NewLogs wraps multiple log consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Gives a copy-on-write copy of the data to the consumers that need to mutate it.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(lcs) == 1 && !lcs[0].Capabilities().MutatesData {
@@ -41,7 +41,7 @@ type logsConsumer struct {
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
+	// If all consumers are mutating, then the original data may be passed to one of them.
 	return consumer.Capabilities{MutatesData: len(lsc.mutable) > 0 && len(lsc.readonly) == 0}
 }
 
@@ -50,18 +50,15 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
 
 	if len(lsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(lsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, lsc.mutable[i].ConsumeLogs(ctx, cloneLogs(ld)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
-		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, ld))
+		// Send data as is to the mutating consumer only if it is the only consumer and the data is mutable.
+		// Otherwise, every mutating consumer gets a copy-on-write copy of the data which clones only the parts
+		// it modifies, so the data is never changed under the other consumers, which may process it async.
+		if len(lsc.mutable) == 1 && len(lsc.readonly) == 0 && !ld.IsReadOnly() {
+			errs = multierr.Append(errs, lsc.mutable[0].ConsumeLogs(ctx, ld))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, cloneLogs(ld)))
+			for _, mc := range lsc.mutable {
+				errs = multierr.Append(errs, mc.ConsumeLogs(ctx, ld.CopyOnWrite()))
+			}
 		}
 	}
 
@@ -75,9 +72,3 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 	return errs
 }
-
-func cloneLogs(ld plog.Logs) plog.Logs {
-	clonedLogs := plog.NewLogs()
-	ld.CopyTo(clonedLogs)
-	return clonedLogs
-}
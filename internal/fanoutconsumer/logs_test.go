@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -82,19 +84,18 @@ func TestLogsMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[1]))
 
 	assert.True(t, ld != p2.AllLogs()[0])
 	assert.True(t, ld != p2.AllLogs()[1])
-	assert.EqualValues(t, ld, p2.AllLogs()[0])
-	assert.EqualValues(t, ld, p2.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p2.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p2.AllLogs()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, ld == p3.AllLogs()[0])
-	assert.True(t, ld == p3.AllLogs()[1])
-	assert.EqualValues(t, ld, p3.AllLogs()[0])
-	assert.EqualValues(t, ld, p3.AllLogs()[1])
+	assert.True(t, ld != p3.AllLogs()[0])
+	assert.True(t, ld != p3.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p3.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p3.AllLogs()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, ld.IsReadOnly())
@@ -119,22 +120,22 @@ func TestReadOnlyLogsMultiplexingMutating(t *testing.T) {
 		}
 	}
 
-	// All consumers should receive the cloned data.
+	// All consumers should receive a copy-on-write copy of the data.
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ldOrig, p1.AllLogs()[0])
-	assert.EqualValues(t, ldOrig, p1.AllLogs()[1])
+	assert.EqualValues(t, ldOrig, copyLogs(p1.AllLogs()[0]))
+	assert.EqualValues(t, ldOrig, copyLogs(p1.AllLogs()[1]))
 
 	assert.True(t, ld != p2.AllLogs()[0])
 	assert.True(t, ld != p2.AllLogs()[1])
-	assert.EqualValues(t, ldOrig, p2.AllLogs()[0])
-	assert.EqualValues(t, ldOrig, p2.AllLogs()[1])
+	assert.EqualValues(t, ldOrig, copyLogs(p2.AllLogs()[0]))
+	assert.EqualValues(t, ldOrig, copyLogs(p2.AllLogs()[1]))
 
 	assert.True(t, ld != p3.AllLogs()[0])
 	assert.True(t, ld != p3.AllLogs()[1])
-	assert.EqualValues(t, ldOrig, p3.AllLogs()[0])
-	assert.EqualValues(t, ldOrig, p3.AllLogs()[1])
+	assert.EqualValues(t, ldOrig, copyLogs(p3.AllLogs()[0]))
+	assert.EqualValues(t, ldOrig, copyLogs(p3.AllLogs()[1]))
 }
 
 func TestLogsMultiplexingMixLastMutating(t *testing.T) {
@@ -156,8 +157,8 @@ func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, ld == p2.AllLogs()[0])
@@ -165,11 +166,11 @@ func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 	assert.EqualValues(t, ld, p2.AllLogs()[0])
 	assert.EqualValues(t, ld, p2.AllLogs()[1])
 
-	// For this consumer, will clone the initial data.
+	// For this consumer, will receive a copy-on-write copy of the initial data.
 	assert.True(t, ld != p3.AllLogs()[0])
 	assert.True(t, ld != p3.AllLogs()[1])
-	assert.EqualValues(t, ld, p3.AllLogs()[0])
-	assert.EqualValues(t, ld, p3.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p3.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p3.AllLogs()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, ld.IsReadOnly())
@@ -194,13 +195,13 @@ func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, ld != p1.AllLogs()[0])
 	assert.True(t, ld != p1.AllLogs()[1])
-	assert.EqualValues(t, ld, p1.AllLogs()[0])
-	assert.EqualValues(t, ld, p1.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[1]))
 
 	assert.True(t, ld != p2.AllLogs()[0])
 	assert.True(t, ld != p2.AllLogs()[1])
-	assert.EqualValues(t, ld, p2.AllLogs()[0])
-	assert.EqualValues(t, ld, p2.AllLogs()[1])
+	assert.EqualValues(t, ld, copyLogs(p2.AllLogs()[0]))
+	assert.EqualValues(t, ld, copyLogs(p2.AllLogs()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, ld == p3.AllLogs()[0])
@@ -230,6 +231,35 @@ func TestLogsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsMultiplexingMutatingCopyOnWrite(t *testing.T) {
+	mutating, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		ld.ResourceLogs().At(0).Resource().Attributes().PutStr("mutated", "true")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p2 := new(consumertest.LogsSink)
+
+	fc := NewLogs([]consumer.Logs{mutating, p1, p2})
+	ld := testdata.GenerateLogs(2)
+	assert.NoError(t, fc.ConsumeLogs(context.Background(), ld))
+
+	// Changes made by a mutating consumer are not visible to the other consumers.
+	assert.EqualValues(t, testdata.GenerateLogs(2), ld)
+	assert.EqualValues(t, ld, copyLogs(p1.AllLogs()[0]))
+	assert.True(t, ld == p2.AllLogs()[0])
+}
+
+func TestLogsNotMultiplexingMutatingData(t *testing.T) {
+	p := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	fc := NewLogs([]consumer.Logs{p})
+	ld := testdata.GenerateLogs(1)
+	assert.NoError(t, fc.ConsumeLogs(context.Background(), ld))
+
+	// The only consumer receives the initial data.
+	assert.True(t, ld == p.AllLogs()[0])
+}
+
 type mutatingLogsSink struct {
 	*consumertest.LogsSink
 }
@@ -245,3 +275,10 @@ type mutatingErr struct {
 func (mts mutatingErr) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// copyLogs returns a copy of the logs which does not share any element with other data.
+func copyLogs(ld plog.Logs) plog.Logs {
+	logsCopy := plog.NewLogs()
+	ld.CopyTo(logsCopy)
+	return logsCopy
+}
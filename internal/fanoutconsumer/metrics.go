@@ -14,8 +14,8 @@ import (
 
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Gives a copy-on-write copy of the data to the consumers that need to mutate it.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(mcs) == 1 && !mcs[0].Capabilities().MutatesData {
@@ -39,7 +39,7 @@ type metricsConsumer struct {
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
+	// If all consumers are mutating, then the original data may be passed to one of them.
 	return consumer.Capabilities{MutatesData: len(msc.mutable) > 0 && len(msc.readonly) == 0}
 }
 
@@ -48,18 +48,15 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 	var errs error
 
 	if len(msc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(msc.mutable)-1; i++ {
-			errs = multierr.Append(errs, msc.mutable[i].ConsumeMetrics(ctx, cloneMetrics(md)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := msc.mutable[len(msc.mutable)-1]
-		if len(msc.readonly) == 0 && !md.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, md))
+		// Send data as is to the mutating consumer only if it is the only consumer and the data is mutable.
+		// Otherwise, every mutating consumer gets a copy-on-write copy of the data which clones only the parts
+		// it modifies, so the data is never changed under the other consumers, which may process it async.
+		if len(msc.mutable) == 1 && len(msc.readonly) == 0 && !md.IsReadOnly() {
+			errs = multierr.Append(errs, msc.mutable[0].ConsumeMetrics(ctx, md))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, cloneMetrics(md)))
+			for _, mc := range msc.mutable {
+				errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md.CopyOnWrite()))
+			}
 		}
 	}
 
@@ -73,9 +70,3 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 
 	return errs
 }
-
-func cloneMetrics(md pmetric.Metrics) pmetric.Metrics {
-	clonedMetrics := pmetric.NewMetrics()
-	md.CopyTo(clonedMetrics)
-	return clonedMetrics
-}
@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -82,19 +84,18 @@ func TestMetricsMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[1]))
 
 	assert.True(t, md != p2.AllMetrics()[0])
 	assert.True(t, md != p2.AllMetrics()[1])
-	assert.EqualValues(t, md, p2.AllMetrics()[0])
-	assert.EqualValues(t, md, p2.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p2.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p2.AllMetrics()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, md == p3.AllMetrics()[0])
-	assert.True(t, md == p3.AllMetrics()[1])
-	assert.EqualValues(t, md, p3.AllMetrics()[0])
-	assert.EqualValues(t, md, p3.AllMetrics()[1])
+	assert.True(t, md != p3.AllMetrics()[0])
+	assert.True(t, md != p3.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p3.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p3.AllMetrics()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, md.IsReadOnly())
@@ -119,22 +120,22 @@ func TestReadOnlyMetricsMultiplexingMixFirstMutating(t *testing.T) {
 		}
 	}
 
-	// All consumers should receive the cloned data.
+	// All consumers should receive a copy-on-write copy of the data.
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, mdOrig, p1.AllMetrics()[0])
-	assert.EqualValues(t, mdOrig, p1.AllMetrics()[1])
+	assert.EqualValues(t, mdOrig, copyMetrics(p1.AllMetrics()[0]))
+	assert.EqualValues(t, mdOrig, copyMetrics(p1.AllMetrics()[1]))
 
 	assert.True(t, md != p2.AllMetrics()[0])
 	assert.True(t, md != p2.AllMetrics()[1])
-	assert.EqualValues(t, mdOrig, p2.AllMetrics()[0])
-	assert.EqualValues(t, mdOrig, p2.AllMetrics()[1])
+	assert.EqualValues(t, mdOrig, copyMetrics(p2.AllMetrics()[0]))
+	assert.EqualValues(t, mdOrig, copyMetrics(p2.AllMetrics()[1]))
 
 	assert.True(t, md != p3.AllMetrics()[0])
 	assert.True(t, md != p3.AllMetrics()[1])
-	assert.EqualValues(t, mdOrig, p3.AllMetrics()[0])
-	assert.EqualValues(t, mdOrig, p3.AllMetrics()[1])
+	assert.EqualValues(t, mdOrig, copyMetrics(p3.AllMetrics()[0]))
+	assert.EqualValues(t, mdOrig, copyMetrics(p3.AllMetrics()[1]))
 }
 
 func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
@@ -156,8 +157,8 @@ func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, md == p2.AllMetrics()[0])
@@ -165,11 +166,11 @@ func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 	assert.EqualValues(t, md, p2.AllMetrics()[0])
 	assert.EqualValues(t, md, p2.AllMetrics()[1])
 
-	// For this consumer, will clone the initial data.
+	// For this consumer, will receive a copy-on-write copy of the initial data.
 	assert.True(t, md != p3.AllMetrics()[0])
 	assert.True(t, md != p3.AllMetrics()[1])
-	assert.EqualValues(t, md, p3.AllMetrics()[0])
-	assert.EqualValues(t, md, p3.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p3.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p3.AllMetrics()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, md.IsReadOnly())
@@ -194,13 +195,13 @@ func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, md != p1.AllMetrics()[0])
 	assert.True(t, md != p1.AllMetrics()[1])
-	assert.EqualValues(t, md, p1.AllMetrics()[0])
-	assert.EqualValues(t, md, p1.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[1]))
 
 	assert.True(t, md != p2.AllMetrics()[0])
 	assert.True(t, md != p2.AllMetrics()[1])
-	assert.EqualValues(t, md, p2.AllMetrics()[0])
-	assert.EqualValues(t, md, p2.AllMetrics()[1])
+	assert.EqualValues(t, md, copyMetrics(p2.AllMetrics()[0]))
+	assert.EqualValues(t, md, copyMetrics(p2.AllMetrics()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, md == p3.AllMetrics()[0])
@@ -230,6 +231,35 @@ func TestMetricsWhenErrors(t *testing.T) {
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsMultiplexingMutatingCopyOnWrite(t *testing.T) {
+	mutating, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		md.ResourceMetrics().At(0).Resource().Attributes().PutStr("mutated", "true")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p2 := new(consumertest.MetricsSink)
+
+	fc := NewMetrics([]consumer.Metrics{mutating, p1, p2})
+	md := testdata.GenerateMetrics(2)
+	assert.NoError(t, fc.ConsumeMetrics(context.Background(), md))
+
+	// Changes made by a mutating consumer are not visible to the other consumers.
+	assert.EqualValues(t, testdata.GenerateMetrics(2), md)
+	assert.EqualValues(t, md, copyMetrics(p1.AllMetrics()[0]))
+	assert.True(t, md == p2.AllMetrics()[0])
+}
+
+func TestMetricsNotMultiplexingMutatingData(t *testing.T) {
+	p := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	fc := NewMetrics([]consumer.Metrics{p})
+	md := testdata.GenerateMetrics(1)
+	assert.NoError(t, fc.ConsumeMetrics(context.Background(), md))
+
+	// The only consumer receives the initial data.
+	assert.True(t, md == p.AllMetrics()[0])
+}
+
 type mutatingMetricsSink struct {
 	*consumertest.MetricsSink
 }
@@ -237,3 +267,10 @@ type mutatingMetricsSink struct {
 func (mts *mutatingMetricsSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// copyMetrics returns a copy of the metrics which does not share any element with other data.
+func copyMetrics(md pmetric.Metrics) pmetric.Metrics {
+	metricsCopy := pmetric.NewMetrics()
+	md.CopyTo(metricsCopy)
+	return metricsCopy
+}
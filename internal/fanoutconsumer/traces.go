@@ -14,8 +14,8 @@ import (
 
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Gives a copy-on-write copy of the data to the consumers that need to mutate it.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
@@ -39,7 +39,7 @@ type tracesConsumer struct {
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
+	// If all consumers are mutating, then the original data may be passed to one of them.
 	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
 }
 
@@ -48,18 +48,15 @@ func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 	var errs error
 
 	if len(tsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeTraces(ctx, cloneTraces(td)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
-		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td))
+		// Send data as is to the mutating consumer only if it is the only consumer and the data is mutable.
+		// Otherwise, every mutating consumer gets a copy-on-write copy of the data which clones only the parts
+		// it modifies, so the data is never changed under the other consumers, which may process it async.
+		if len(tsc.mutable) == 1 && len(tsc.readonly) == 0 && !td.IsReadOnly() {
+			errs = multierr.Append(errs, tsc.mutable[0].ConsumeTraces(ctx, td))
 		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, cloneTraces(td)))
+			for _, mc := range tsc.mutable {
+				errs = multierr.Append(errs, mc.ConsumeTraces(ctx, td.CopyOnWrite()))
+			}
 		}
 	}
 
@@ -73,9 +70,3 @@ func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 
 	return errs
 }
-
-func cloneTraces(td ptrace.Traces) ptrace.Traces {
-	clonedTraces := ptrace.NewTraces()
-	td.CopyTo(clonedTraces)
-	return clonedTraces
-}
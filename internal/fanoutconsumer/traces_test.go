@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -82,19 +84,18 @@ func TestTracesMultiplexingMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[1]))
 
 	assert.True(t, td != p2.AllTraces()[0])
 	assert.True(t, td != p2.AllTraces()[1])
-	assert.EqualValues(t, td, p2.AllTraces()[0])
-	assert.EqualValues(t, td, p2.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p2.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p2.AllTraces()[1]))
 
-	// For this consumer, will receive the initial data.
-	assert.True(t, td == p3.AllTraces()[0])
-	assert.True(t, td == p3.AllTraces()[1])
-	assert.EqualValues(t, td, p3.AllTraces()[0])
-	assert.EqualValues(t, td, p3.AllTraces()[1])
+	assert.True(t, td != p3.AllTraces()[0])
+	assert.True(t, td != p3.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p3.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p3.AllTraces()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, td.IsReadOnly())
@@ -120,22 +121,22 @@ func TestReadOnlyTracesMultiplexingMutating(t *testing.T) {
 		}
 	}
 
-	// All consumers should receive the cloned data.
+	// All consumers should receive a copy-on-write copy of the data.
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, tdOrig, p1.AllTraces()[0])
-	assert.EqualValues(t, tdOrig, p1.AllTraces()[1])
+	assert.EqualValues(t, tdOrig, copyTraces(p1.AllTraces()[0]))
+	assert.EqualValues(t, tdOrig, copyTraces(p1.AllTraces()[1]))
 
 	assert.True(t, td != p2.AllTraces()[0])
 	assert.True(t, td != p2.AllTraces()[1])
-	assert.EqualValues(t, tdOrig, p2.AllTraces()[0])
-	assert.EqualValues(t, tdOrig, p2.AllTraces()[1])
+	assert.EqualValues(t, tdOrig, copyTraces(p2.AllTraces()[0]))
+	assert.EqualValues(t, tdOrig, copyTraces(p2.AllTraces()[1]))
 
 	assert.True(t, td != p3.AllTraces()[0])
 	assert.True(t, td != p3.AllTraces()[1])
-	assert.EqualValues(t, tdOrig, p3.AllTraces()[0])
-	assert.EqualValues(t, tdOrig, p3.AllTraces()[1])
+	assert.EqualValues(t, tdOrig, copyTraces(p3.AllTraces()[0]))
+	assert.EqualValues(t, tdOrig, copyTraces(p3.AllTraces()[1]))
 }
 
 func TestTracesMultiplexingMixLastMutating(t *testing.T) {
@@ -157,8 +158,8 @@ func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, td == p2.AllTraces()[0])
@@ -166,11 +167,11 @@ func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 	assert.EqualValues(t, td, p2.AllTraces()[0])
 	assert.EqualValues(t, td, p2.AllTraces()[1])
 
-	// For this consumer, will clone the initial data.
+	// For this consumer, will receive a copy-on-write copy of the initial data.
 	assert.True(t, td != p3.AllTraces()[0])
 	assert.True(t, td != p3.AllTraces()[1])
-	assert.EqualValues(t, td, p3.AllTraces()[0])
-	assert.EqualValues(t, td, p3.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p3.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p3.AllTraces()[1]))
 
 	// The data should not be marked as read only.
 	assert.False(t, td.IsReadOnly())
@@ -195,13 +196,13 @@ func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
 
 	assert.True(t, td != p1.AllTraces()[0])
 	assert.True(t, td != p1.AllTraces()[1])
-	assert.EqualValues(t, td, p1.AllTraces()[0])
-	assert.EqualValues(t, td, p1.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[1]))
 
 	assert.True(t, td != p2.AllTraces()[0])
 	assert.True(t, td != p2.AllTraces()[1])
-	assert.EqualValues(t, td, p2.AllTraces()[0])
-	assert.EqualValues(t, td, p2.AllTraces()[1])
+	assert.EqualValues(t, td, copyTraces(p2.AllTraces()[0]))
+	assert.EqualValues(t, td, copyTraces(p2.AllTraces()[1]))
 
 	// For this consumer, will receive the initial data.
 	assert.True(t, td == p3.AllTraces()[0])
@@ -231,6 +232,35 @@ func TestTracesWhenErrors(t *testing.T) {
 	assert.EqualValues(t, td, p3.AllTraces()[1])
 }
 
+func TestTracesMultiplexingMutatingCopyOnWrite(t *testing.T) {
+	mutating, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		td.ResourceSpans().At(0).Resource().Attributes().PutStr("mutated", "true")
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := new(consumertest.TracesSink)
+
+	fc := NewTraces([]consumer.Traces{mutating, p1, p2})
+	td := testdata.GenerateTraces(2)
+	assert.NoError(t, fc.ConsumeTraces(context.Background(), td))
+
+	// Changes made by a mutating consumer are not visible to the other consumers.
+	assert.EqualValues(t, testdata.GenerateTraces(2), td)
+	assert.EqualValues(t, td, copyTraces(p1.AllTraces()[0]))
+	assert.True(t, td == p2.AllTraces()[0])
+}
+
+func TestTracesNotMultiplexingMutatingData(t *testing.T) {
+	p := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	fc := NewTraces([]consumer.Traces{p})
+	td := testdata.GenerateTraces(1)
+	assert.NoError(t, fc.ConsumeTraces(context.Background(), td))
+
+	// The only consumer receives the initial data.
+	assert.True(t, td == p.AllTraces()[0])
+}
+
 type mutatingTracesSink struct {
 	*consumertest.TracesSink
 }
@@ -238,3 +268,10 @@ type mutatingTracesSink struct {
 func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// copyTraces returns a copy of the traces which does not share any element with other data.
+func copyTraces(td ptrace.Traces) ptrace.Traces {
+	tracesCopy := ptrace.NewTraces()
+	td.CopyTo(tracesCopy)
+	return tracesCopy
+}
@@ -13,6 +13,8 @@ const unlimitedMemorySize = 9223372036854771712
 
 // TotalMemory returns total available memory.
 // This implementation is meant for linux and uses cgroups to determine available memory.
+// With cgroup v2, the total memory is the lowest of the effective `memory.max` and
+// `memory.high` limits, as the process gets throttled once it exceeds the latter.
 func TotalMemory() (uint64, error) {
 	var memoryQuota int64
 	var defined bool
@@ -24,7 +26,7 @@ func TotalMemory() (uint64, error) {
 	}
 
 	if isV2 {
-		memoryQuota, defined, err = cgroups.MemoryQuotaV2()
+		memoryQuota, defined, err = memoryLimitV2()
 		if err != nil {
 			return 0, err
 		}
@@ -37,6 +39,14 @@ func TotalMemory() (uint64, error) {
 		if err != nil {
 			return 0, err
 		}
+		// In the hybrid hierarchy, the memory controller may be attached
+		// to the unified hierarchy rather than to a cgroup v1 one.
+		if !defined {
+			memoryQuota, defined, err = memoryLimitV2()
+			if err != nil {
+				return 0, err
+			}
+		}
 	}
 
 	// If memory is not defined or is set to unlimitedMemorySize (v1 unset),
@@ -51,3 +61,23 @@ func TotalMemory() (uint64, error) {
 
 	return uint64(memoryQuota), nil
 }
+
+// memoryLimitV2 returns the lowest of the effective cgroup v2 memory limits of the current process.
+func memoryLimitV2() (int64, bool, error) {
+	cg, err := cgroups.NewCGroupV2ForCurrentProcess()
+	if err != nil || cg == nil {
+		return -1, false, err
+	}
+	quota, quotaDefined, err := cg.MemoryQuota()
+	if err != nil {
+		return -1, false, err
+	}
+	high, highDefined, err := cg.MemoryHigh()
+	if err != nil {
+		return -1, false, err
+	}
+	if highDefined && (!quotaDefined || high < quota) {
+		return high, true, nil
+	}
+	return quota, quotaDefined, nil
+}
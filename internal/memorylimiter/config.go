@@ -17,6 +17,18 @@ var (
 	errSpikeLimitPercentageOutOfRange = errors.New("'spike_limit_percentage' must be smaller than 'limit_percentage'")
 	errLimitPercentageOutOfRange      = errors.New(
 		"'limit_percentage' and 'spike_limit_percentage' must be greater than zero and less than or equal to hundred")
+	errModeInvalid                = errors.New("'mode' must be either 'forced_gc' or 'gomemlimit'")
+	errGCCPUPercentageOutOfRange  = errors.New("'gc_cpu_percentage' must be less than or equal to hundred")
+	errGCCPUPercentageUnsupported = errors.New("'gc_cpu_percentage' is only supported with the 'gomemlimit' mode")
+)
+
+const (
+	// modeForcedGC compares the heap allocations against the limits, and forces a GC
+	// when they are exceeded.
+	modeForcedGC = "forced_gc"
+	// modeGoMemLimit sets the Go runtime soft memory limit to the hard limit, and
+	// refuses data according to the GC pressure reported by the runtime.
+	modeGoMemLimit = "gomemlimit"
 )
 
 // Config defines configuration for memory memoryLimiter processor.
@@ -41,6 +53,19 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// SetGoMemLimit sets the Go runtime soft memory limit (GOMEMLIMIT) to the soft limit
+	// computed from the settings above, unless the GOMEMLIMIT environment variable is set.
+	SetGoMemLimit bool `mapstructure:"set_gomemlimit"`
+
+	// Mode is the way the memory usage is checked and reduced, either "forced_gc" or "gomemlimit".
+	// Defaults to "forced_gc".
+	Mode string `mapstructure:"mode"`
+
+	// GCCPUPercentage is the share, in percents, of the CPU time spent by the GC between
+	// two checks above which data is refused, provided the heap goal reaches the soft limit.
+	// Only used with the "gomemlimit" mode, defaults to 40.
+	GCCPUPercentage uint32 `mapstructure:"gc_cpu_percentage"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -62,5 +87,17 @@ func (cfg *Config) Validate() error {
 	if cfg.MemoryLimitPercentage > 0 && cfg.MemoryLimitPercentage <= cfg.MemorySpikePercentage {
 		return errSpikeLimitPercentageOutOfRange
 	}
+	switch cfg.Mode {
+	case "", modeForcedGC:
+		if cfg.GCCPUPercentage != 0 {
+			return errGCCPUPercentageUnsupported
+		}
+	case modeGoMemLimit:
+		if cfg.GCCPUPercentage > 100 {
+			return errGCCPUPercentageOutOfRange
+		}
+	default:
+		return errModeInvalid
+	}
 	return nil
 }
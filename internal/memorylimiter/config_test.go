@@ -84,6 +84,44 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: errSpikeLimitPercentageOutOfRange,
 		},
+		{
+			name: "valid gomemlimit mode",
+			cfg: &Config{
+				CheckInterval:   1 * time.Second,
+				MemoryLimitMiB:  100,
+				Mode:            "gomemlimit",
+				GCCPUPercentage: 30,
+			},
+			err: nil,
+		},
+		{
+			name: "invalid mode",
+			cfg: &Config{
+				CheckInterval:  1 * time.Second,
+				MemoryLimitMiB: 100,
+				Mode:           "gc",
+			},
+			err: errModeInvalid,
+		},
+		{
+			name: "invalid gc cpu percentage",
+			cfg: &Config{
+				CheckInterval:   1 * time.Second,
+				MemoryLimitMiB:  100,
+				Mode:            "gomemlimit",
+				GCCPUPercentage: 101,
+			},
+			err: errGCCPUPercentageOutOfRange,
+		},
+		{
+			name: "gc cpu percentage with forced gc mode",
+			cfg: &Config{
+				CheckInterval:   1 * time.Second,
+				MemoryLimitMiB:  100,
+				GCCPUPercentage: 30,
+			},
+			err: errGCCPUPercentageUnsupported,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -7,7 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,6 +27,19 @@ const (
 	// Minimum interval between forced GC when in soft limited mode. We don't want to
 	// do GCs too frequently since it is a CPU-heavy operation.
 	minGCIntervalWhenSoftLimited = 10 * time.Second
+
+	// defaultGCCPUPercentage is the default share of the CPU time spent by the GC above
+	// which data is refused in the gomemlimit mode. The runtime caps the GC CPU usage
+	// at 50% when the memory limit is reached.
+	defaultGCCPUPercentage = 40
+)
+
+// Names of the runtime/metrics read in the gomemlimit mode.
+const (
+	metricHeapLive     = "/gc/heap/live:bytes"
+	metricHeapGoal     = "/gc/heap/goal:bytes"
+	metricGCCPUTime    = "/cpu/classes/gc/total:cpu-seconds"
+	metricTotalCPUTime = "/cpu/classes/total:cpu-seconds"
 )
 
 var (
@@ -34,11 +50,59 @@ var (
 	// ErrShutdownNotStarted indicates no memorylimiter has not start when shutdown
 	ErrShutdownNotStarted = errors.New("no existing monitoring routine is running")
 
-	// GetMemoryFn and ReadMemStatsFn make it overridable by tests
-	GetMemoryFn    = iruntime.TotalMemory
-	ReadMemStatsFn = runtime.ReadMemStats
+	// GetMemoryFn, ReadMemStatsFn and SetMemoryLimitFn make it overridable by tests
+	GetMemoryFn      = iruntime.TotalMemory
+	ReadMemStatsFn   = runtime.ReadMemStats
+	SetMemoryLimitFn = debug.SetMemoryLimit
 )
 
+// goMemLimitEnv is the environment variable setting the Go runtime soft memory limit.
+const goMemLimitEnv = "GOMEMLIMIT"
+
+// goMemLimits holds the Go runtime soft memory limits of the running limiters setting it. As the limit is
+// process-wide, the lowest of them applies, and the limit set before the first of them started is restored
+// once they have all stopped, whatever the order they stop in.
+var goMemLimits = struct {
+	sync.Mutex
+	limits map[*MemoryLimiter]int64
+	prev   int64
+}{limits: map[*MemoryLimiter]int64{}}
+
+// addGoMemLimit registers the limit of the limiter, and returns the limit applied to the Go runtime.
+func addGoMemLimit(ml *MemoryLimiter, limit int64) int64 {
+	goMemLimits.Lock()
+	defer goMemLimits.Unlock()
+	if len(goMemLimits.limits) == 0 {
+		goMemLimits.prev = SetMemoryLimitFn(limit)
+	}
+	goMemLimits.limits[ml] = limit
+	return applyGoMemLimit()
+}
+
+// removeGoMemLimit unregisters the limit of the limiter, and restores the previous limit if it was the last one.
+func removeGoMemLimit(ml *MemoryLimiter) {
+	goMemLimits.Lock()
+	defer goMemLimits.Unlock()
+	delete(goMemLimits.limits, ml)
+	if len(goMemLimits.limits) == 0 {
+		SetMemoryLimitFn(goMemLimits.prev)
+		return
+	}
+	applyGoMemLimit()
+}
+
+// Note: the lock of goMemLimits must be acquired before calling this function.
+func applyGoMemLimit() int64 {
+	lowest := int64(-1)
+	for _, limit := range goMemLimits.limits {
+		if lowest < 0 || limit < lowest {
+			lowest = limit
+		}
+	}
+	SetMemoryLimitFn(lowest)
+	return lowest
+}
+
 // MemoryLimiter is used to prevent out of memory situations on the collector.
 type MemoryLimiter struct {
 	usageChecker memUsageChecker
@@ -46,6 +110,15 @@ type MemoryLimiter struct {
 	memCheckWait time.Duration
 	ballastSize  uint64
 
+	// setGoMemLimit indicates whether the Go runtime soft memory limit is set while monitoring.
+	setGoMemLimit bool
+
+	// Fields used by the gomemlimit mode: gcCPUFraction is the share of the CPU time spent
+	// by the GC above which data is refused, lastGCStats are the stats read by the previous check.
+	mode          string
+	gcCPUFraction float64
+	lastGCStats   gcStats
+
 	// mustRefuse is used to indicate when data should be refused.
 	mustRefuse *atomic.Bool
 
@@ -53,9 +126,10 @@ type MemoryLimiter struct {
 
 	lastGCDone time.Time
 
-	// The function to read the mem values is set as a reference to help with
+	// The functions to read the mem values are set as a reference to help with
 	// testing different values.
 	readMemStatsFn func(m *runtime.MemStats)
+	readGCStatsFn  func() gcStats
 
 	// Fields used for logging.
 	logger                 *zap.Logger
@@ -79,11 +153,31 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
 		zap.Duration("check_interval", cfg.CheckInterval))
 
+	mode := cfg.Mode
+	if mode == "" {
+		mode = modeForcedGC
+	}
+	gcCPUPercentage := cfg.GCCPUPercentage
+	if gcCPUPercentage == 0 {
+		gcCPUPercentage = defaultGCCPUPercentage
+	}
+
+	setGoMemLimit := cfg.SetGoMemLimit || mode == modeGoMemLimit
+	if env, ok := os.LookupEnv(goMemLimitEnv); ok && setGoMemLimit {
+		logger.Info("GOMEMLIMIT environment variable is set, the Go runtime soft memory limit is not changed",
+			zap.String("gomemlimit", env))
+		setGoMemLimit = false
+	}
+
 	return &MemoryLimiter{
 		usageChecker:   *usageChecker,
 		memCheckWait:   cfg.CheckInterval,
+		setGoMemLimit:  setGoMemLimit,
+		mode:           mode,
+		gcCPUFraction:  float64(gcCPUPercentage) / 100,
 		ticker:         time.NewTicker(cfg.CheckInterval),
 		readMemStatsFn: ReadMemStatsFn,
+		readGCStatsFn:  readRuntimeGCStats,
 		logger:         logger,
 		mustRefuse:     &atomic.Bool{},
 	}, nil
@@ -97,6 +191,23 @@ func (ml *MemoryLimiter) startMonitoring() {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		if ml.setGoMemLimit {
+			// The Go runtime limit accounts for the ballast, unlike the limits of the checker.
+			// In the gomemlimit mode the GC keeps the memory usage below the hard limit,
+			// otherwise it starts working harder once the soft limit is reached.
+			limit := ml.usageChecker.memAllocLimit - ml.usageChecker.memSpikeLimit + ml.ballastSize
+			if ml.mode == modeGoMemLimit {
+				limit = ml.usageChecker.memAllocLimit + ml.ballastSize
+			}
+			applied := addGoMemLimit(ml, int64(limit))
+			ml.logger.Info("Go runtime soft memory limit set",
+				zap.Uint64("gomemlimit_mib", limit/mibBytes),
+				zap.Int64("applied_gomemlimit_mib", applied/mibBytes))
+		}
+		if ml.mode == modeGoMemLimit {
+			// The GC CPU usage is measured from the start of the monitoring.
+			ml.lastGCStats = ml.readGCStats()
+		}
 		ml.closed = make(chan struct{})
 		ml.waitGroup.Add(1)
 		go func() {
@@ -137,6 +248,9 @@ func (ml *MemoryLimiter) Shutdown(context.Context) error {
 		ml.ticker.Stop()
 		close(ml.closed)
 		ml.waitGroup.Wait()
+		if ml.setGoMemLimit {
+			removeGoMemLimit(ml)
+		}
 	}
 	ml.refCounter--
 	return nil
@@ -195,6 +309,11 @@ func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
 
 // CheckMemLimits inspects current memory usage against threshold and toggle mustRefuse when threshold is exceeded
 func (ml *MemoryLimiter) CheckMemLimits() {
+	if ml.mode == modeGoMemLimit {
+		ml.checkGCPressure()
+		return
+	}
+
 	ms := ml.readMemStats()
 
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
@@ -233,6 +352,88 @@ func (ml *MemoryLimiter) CheckMemLimits() {
 	ml.mustRefuse.Store(mustRefuse)
 }
 
+// gcStats are the GC pressure signals read from runtime/metrics.
+type gcStats struct {
+	heapLive     uint64
+	heapGoal     uint64
+	gcCPUTime    float64
+	totalCPUTime float64
+}
+
+func readRuntimeGCStats() gcStats {
+	samples := []metrics.Sample{
+		{Name: metricHeapLive},
+		{Name: metricHeapGoal},
+		{Name: metricGCCPUTime},
+		{Name: metricTotalCPUTime},
+	}
+	metrics.Read(samples)
+	return gcStats{
+		heapLive:     sampleUint64(samples[0]),
+		heapGoal:     sampleUint64(samples[1]),
+		gcCPUTime:    sampleFloat64(samples[2]),
+		totalCPUTime: sampleFloat64(samples[3]),
+	}
+}
+
+func (ml *MemoryLimiter) readGCStats() gcStats {
+	stats := ml.readGCStatsFn()
+	// The ballast is part of the heap, but not of the limits of the checker.
+	stats.heapLive -= min(stats.heapLive, ml.ballastSize)
+	stats.heapGoal -= min(stats.heapGoal, ml.ballastSize)
+	return stats
+}
+
+// sampleUint64 returns the value of an uint64 sample, zero if the metric is not supported by the runtime.
+func sampleUint64(s metrics.Sample) uint64 {
+	if s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s.Value.Uint64()
+}
+
+// sampleFloat64 returns the value of a float64 sample, zero if the metric is not supported by the runtime.
+func sampleFloat64(s metrics.Sample) float64 {
+	if s.Value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	return s.Value.Float64()
+}
+
+// checkGCPressure toggles mustRefuse according to the GC pressure, without forcing any GC:
+// data is refused when the live heap exceeds the soft limit, or when the heap goal reaches
+// the soft limit and the GC used more than its share of the CPU time since the previous check.
+func (ml *MemoryLimiter) checkGCPressure() {
+	stats := ml.readGCStats()
+	var gcCPUFraction float64
+	if total := stats.totalCPUTime - ml.lastGCStats.totalCPUTime; total > 0 {
+		gcCPUFraction = (stats.gcCPUTime - ml.lastGCStats.gcCPUTime) / total
+	}
+	ml.lastGCStats = stats
+
+	fields := []zap.Field{
+		zap.Uint64("cur_mem_mib", stats.heapLive/mibBytes),
+		zap.Uint64("heap_goal_mib", stats.heapGoal/mibBytes),
+		zap.Float64("gc_cpu_percentage", 100*gcCPUFraction),
+	}
+	ml.logger.Debug("Current GC pressure.", fields...)
+
+	softLimit := ml.usageChecker.memAllocLimit - ml.usageChecker.memSpikeLimit
+	aboveSoftLimit := stats.heapLive >= softLimit
+	mustRefuse := aboveSoftLimit || (stats.heapGoal >= softLimit && gcCPUFraction >= ml.gcCPUFraction)
+
+	wasRefusing := ml.mustRefuse.Load()
+	switch {
+	case wasRefusing && !mustRefuse:
+		ml.logger.Info("GC pressure back within limits. Resuming normal operation.", fields...)
+	case !wasRefusing && aboveSoftLimit:
+		ml.logger.Warn("Live heap is above soft limit. Refusing data.", fields...)
+	case !wasRefusing && mustRefuse:
+		ml.logger.Warn("GC CPU usage is above limit. Refusing data.", fields...)
+	}
+	ml.mustRefuse.Store(mustRefuse)
+}
+
 type memUsageChecker struct {
 	memAllocLimit uint64
 	memSpikeLimit uint64
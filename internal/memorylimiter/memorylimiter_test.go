@@ -5,7 +5,9 @@ package memorylimiter
 
 import (
 	"context"
+	"math"
 	"runtime"
+	"runtime/debug"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -73,6 +75,67 @@ func TestMemoryPressureResponse(t *testing.T) {
 	assert.True(t, ml.MustRefuse())
 }
 
+// TestGCPressureResponse manipulates the GC stats reported by the runtime in the
+// gomemlimit mode and check expected side effects.
+func TestGCPressureResponse(t *testing.T) {
+	var stats gcStats
+	ml := &MemoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+			memSpikeLimit: 224,
+		},
+		mode:          modeGoMemLimit,
+		gcCPUFraction: 0.4,
+		mustRefuse:    &atomic.Bool{},
+		readGCStatsFn: func() gcStats {
+			return stats
+		},
+		readMemStatsFn: func(*runtime.MemStats) {
+			assert.Fail(t, "heap allocations must not be read in the gomemlimit mode")
+		},
+		logger: zap.NewNop(),
+	}
+
+	// Below soft limit.
+	stats = gcStats{heapLive: 400, heapGoal: 800, gcCPUTime: 1, totalCPUTime: 10}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Live heap above soft limit.
+	stats = gcStats{heapLive: 850, heapGoal: 1000, gcCPUTime: 1.5, totalCPUTime: 20}
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Heap goal above soft limit, but the GC is not under pressure.
+	stats = gcStats{heapLive: 600, heapGoal: 1000, gcCPUTime: 2, totalCPUTime: 30}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Heap goal above soft limit, and the GC uses half of the CPU time.
+	stats = gcStats{heapLive: 600, heapGoal: 1000, gcCPUTime: 7, totalCPUTime: 40}
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Heap goal below soft limit, even though the GC uses half of the CPU time.
+	stats = gcStats{heapLive: 300, heapGoal: 600, gcCPUTime: 12, totalCPUTime: 50}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Check ballast effect
+	ml.ballastSize = 1000
+
+	// Below soft limit accounting for ballast.
+	stats = gcStats{heapLive: 400 + ml.ballastSize, heapGoal: 700 + ml.ballastSize, gcCPUTime: 17, totalCPUTime: 60}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+}
+
+func TestReadRuntimeGCStats(t *testing.T) {
+	stats := readRuntimeGCStats()
+	assert.Positive(t, stats.heapGoal)
+	assert.Positive(t, stats.totalCPUTime)
+}
+
 func TestGetDecision(t *testing.T) {
 	t.Run("fixed_limit", func(t *testing.T) {
 		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
@@ -166,6 +229,76 @@ func TestBallastSize(t *testing.T) {
 	require.NoError(t, got.Shutdown(context.Background()))
 }
 
+func TestSetGoMemLimit(t *testing.T) {
+	t.Cleanup(func() {
+		SetMemoryLimitFn = debug.SetMemoryLimit
+	})
+	goMemLimit := int64(math.MaxInt64)
+	SetMemoryLimitFn = func(limit int64) int64 {
+		prev := goMemLimit
+		goMemLimit = limit
+		return prev
+	}
+	cfg := &Config{
+		CheckInterval:       10 * time.Second,
+		MemoryLimitMiB:      1024,
+		MemorySpikeLimitMiB: 200,
+		SetGoMemLimit:       true,
+	}
+
+	t.Run("set", func(t *testing.T) {
+		ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, ml.Start(context.Background(), &host{ballastSize: 100 * mibBytes}))
+		assert.Equal(t, int64(924*mibBytes), goMemLimit)
+		require.NoError(t, ml.Shutdown(context.Background()))
+		assert.Equal(t, int64(math.MaxInt64), goMemLimit)
+	})
+
+	t.Run("gomemlimit mode", func(t *testing.T) {
+		gcCfg := *cfg
+		gcCfg.SetGoMemLimit = false
+		gcCfg.Mode = "gomemlimit"
+		ml, err := NewMemoryLimiter(&gcCfg, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, ml.Start(context.Background(), &host{}))
+		assert.Equal(t, int64(1024*mibBytes), goMemLimit)
+		require.NoError(t, ml.Shutdown(context.Background()))
+		assert.Equal(t, int64(math.MaxInt64), goMemLimit)
+	})
+
+	t.Run("several limiters", func(t *testing.T) {
+		lowCfg := *cfg
+		lowCfg.MemoryLimitMiB = 512
+		ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+		require.NoError(t, err)
+		lowML, err := NewMemoryLimiter(&lowCfg, zap.NewNop())
+		require.NoError(t, err)
+
+		// The lowest limit applies, and the initial limit is restored once both limiters stopped.
+		require.NoError(t, ml.Start(context.Background(), &host{}))
+		require.NoError(t, lowML.Start(context.Background(), &host{}))
+		assert.Equal(t, int64(312*mibBytes), goMemLimit)
+		require.NoError(t, lowML.Shutdown(context.Background()))
+		assert.Equal(t, int64(824*mibBytes), goMemLimit)
+
+		require.NoError(t, lowML.Start(context.Background(), &host{}))
+		require.NoError(t, ml.Shutdown(context.Background()))
+		assert.Equal(t, int64(312*mibBytes), goMemLimit)
+		require.NoError(t, lowML.Shutdown(context.Background()))
+		assert.Equal(t, int64(math.MaxInt64), goMemLimit)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(goMemLimitEnv, "1GiB")
+		ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, ml.Start(context.Background(), &host{}))
+		assert.Equal(t, int64(math.MaxInt64), goMemLimit)
+		require.NoError(t, ml.Shutdown(context.Background()))
+	})
+}
+
 type host struct {
 	ballastSize uint64
 	component.Host
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiterhelper // import "go.opentelemetry.io/collector/internal/memorylimiterhelper"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Refuser is implemented by the memory_limiter extension.
+type Refuser interface {
+	// MustRefuse returns true while the data must be refused due to high memory usage.
+	MustRefuse() bool
+}
+
+// GetRefuser returns the memory_limiter extension with the id, which the servers use to refuse
+// the incoming requests while the memory usage is high.
+func GetRefuser(id component.ID, extensions map[component.ID]component.Component) (Refuser, error) {
+	ext, found := extensions[id]
+	if !found {
+		return nil, fmt.Errorf("failed to resolve memory limiter %q: extension not found", id)
+	}
+	ml, ok := ext.(Refuser)
+	if !ok {
+		return nil, fmt.Errorf("failed to resolve memory limiter %q: extension is not a memory limiter", id)
+	}
+	return ml, nil
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiterhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type refuserExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (refuserExtension) MustRefuse() bool {
+	return true
+}
+
+func TestGetRefuser(t *testing.T) {
+	id := component.MustNewID("memory_limiter")
+	extensions := map[component.ID]component.Component{
+		id:                       refuserExtension{},
+		component.MustNewID("x"): struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{},
+	}
+
+	ml, err := GetRefuser(id, extensions)
+	require.NoError(t, err)
+	assert.True(t, ml.MustRefuse())
+
+	_, err = GetRefuser(component.MustNewID("missing"), extensions)
+	assert.EqualError(t, err, `failed to resolve memory limiter "missing": extension not found`)
+
+	_, err = GetRefuser(component.MustNewID("x"), extensions)
+	assert.EqualError(t, err, `failed to resolve memory limiter "x": extension is not a memory limiter`)
+}
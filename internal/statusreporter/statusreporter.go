@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statusreporter reports the status of a component from the outcome of the data it handles,
+// it is shared by the exporter and processor helpers.
+package statusreporter // import "go.opentelemetry.io/collector/internal/statusreporter"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Reporter reports a recoverable error status when a component starts failing to handle
+// data, and reports the component back to OK once it succeeds again. Only the changes
+// are reported, as the status of a component cannot transition to the same status.
+type Reporter struct {
+	reportStatus func(*component.StatusEvent)
+
+	mu      sync.Mutex
+	failing bool
+}
+
+// New returns a Reporter reporting the status of the component through set.ReportStatus.
+func New(set component.TelemetrySettings) *Reporter {
+	reportStatus := set.ReportStatus
+	if reportStatus == nil {
+		reportStatus = func(*component.StatusEvent) {}
+	}
+	return &Reporter{reportStatus: reportStatus}
+}
+
+// Recoverable reports a recoverable error, unless one was already reported.
+func (r *Reporter) Recoverable(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failing {
+		return
+	}
+	r.failing = true
+	r.reportStatus(component.NewRecoverableErrorEvent(err))
+}
+
+// OK reports the component back to OK, if an error was reported.
+func (r *Reporter) OK() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.failing {
+		return
+	}
+	r.failing = false
+	r.reportStatus(component.NewStatusEvent(component.StatusOK))
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statusreporter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestReporter(t *testing.T) {
+	var events []*component.StatusEvent
+	set := componenttest.NewNopTelemetrySettings()
+	set.ReportStatus = func(ev *component.StatusEvent) {
+		events = append(events, ev)
+	}
+	r := New(set)
+
+	r.OK()
+	assert.Empty(t, events)
+
+	r.Recoverable(errors.New("failed"))
+	r.Recoverable(errors.New("failed again"))
+	r.OK()
+	r.OK()
+	r.Recoverable(errors.New("failed later"))
+	require.Len(t, events, 3)
+	assert.Equal(t, component.StatusRecoverableError, events[0].Status())
+	assert.EqualError(t, events[0].Err(), "failed")
+	assert.Equal(t, component.StatusOK, events[1].Status())
+	assert.Equal(t, component.StatusRecoverableError, events[2].Status())
+	assert.EqualError(t, events[2].Err(), "failed later")
+}
+
+func TestReporterWithoutReportStatus(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+	set.ReportStatus = nil
+	r := New(set)
+	r.Recoverable(errors.New("failed"))
+	r.OK()
+}
@@ -39,6 +39,7 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
+	rootCmd.AddCommand(newSchemaCommand(set))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// newSchemaCommand constructs a new schema command using the given CollectorSettings.
+func newSchemaCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Outputs the JSON Schema of the configuration accepted by this collector distribution",
+		Long:  "Outputs a JSON Schema describing the configuration of all the components available in this collector distribution, as well as the service pipelines and telemetry sections. The output format is not stable and can change between releases.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+
+			jsonData, err := json.MarshalIndent(configSchema(factories, set.BuildInfo), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+			return nil
+		},
+	}
+}
+
+// configSchema builds the JSON Schema for the collector configuration. Every component
+// config is stored under "$defs" as "<kind>/<type>" and referenced from its section,
+// where keys must match the component type optionally followed by "/<name>".
+func configSchema(factories Factories, info component.BuildInfo) *configschema.Schema {
+	root := &configschema.Schema{
+		Schema:               configschema.Version,
+		Title:                fmt.Sprintf("%s %s configuration", info.Command, info.Version),
+		Description:          info.Description,
+		Type:                 "object",
+		Properties:           map[string]*configschema.Schema{},
+		AdditionalProperties: false,
+		Defs:                 map[string]*configschema.Schema{},
+	}
+
+	root.Properties["receivers"] = componentsSchema(root, "receivers", factories.Receivers, func(f receiver.Factory) map[string]component.StabilityLevel {
+		return map[string]component.StabilityLevel{
+			"logs":    f.LogsReceiverStability(),
+			"metrics": f.MetricsReceiverStability(),
+			"traces":  f.TracesReceiverStability(),
+		}
+	})
+	root.Properties["processors"] = componentsSchema(root, "processors", factories.Processors, func(f processor.Factory) map[string]component.StabilityLevel {
+		return map[string]component.StabilityLevel{
+			"logs":    f.LogsProcessorStability(),
+			"metrics": f.MetricsProcessorStability(),
+			"traces":  f.TracesProcessorStability(),
+		}
+	})
+	root.Properties["exporters"] = componentsSchema(root, "exporters", factories.Exporters, func(f exporter.Factory) map[string]component.StabilityLevel {
+		return map[string]component.StabilityLevel{
+			"logs":    f.LogsExporterStability(),
+			"metrics": f.MetricsExporterStability(),
+			"traces":  f.TracesExporterStability(),
+		}
+	})
+	root.Properties["connectors"] = componentsSchema(root, "connectors", factories.Connectors, func(f connector.Factory) map[string]component.StabilityLevel {
+		return map[string]component.StabilityLevel{
+			"logs-to-logs":       f.LogsToLogsStability(),
+			"logs-to-metrics":    f.LogsToMetricsStability(),
+			"logs-to-traces":     f.LogsToTracesStability(),
+			"metrics-to-logs":    f.MetricsToLogsStability(),
+			"metrics-to-metrics": f.MetricsToMetricsStability(),
+			"metrics-to-traces":  f.MetricsToTracesStability(),
+			"traces-to-logs":     f.TracesToLogsStability(),
+			"traces-to-metrics":  f.TracesToMetricsStability(),
+			"traces-to-traces":   f.TracesToTracesStability(),
+		}
+	})
+	root.Properties["extensions"] = componentsSchema(root, "extensions", factories.Extensions, func(f extension.Factory) map[string]component.StabilityLevel {
+		return map[string]component.StabilityLevel{
+			"extension": f.ExtensionStability(),
+		}
+	})
+
+	// The service section is unmarshaled on top of the default telemetry and health configs, see unmarshal.
+	root.Properties["service"] = configschema.FromConfig(&service.Config{
+		Telemetry: *telemetry.NewFactory().CreateDefaultConfig().(*telemetry.Config),
+		Health:    health.NewDefaultConfig(),
+	})
+	serviceSchema := root.Properties["service"]
+	serviceSchema.Properties["pipelines"].PropertyNames = &configschema.Schema{
+		Pattern: idPattern(component.DataTypeTraces.String(), component.DataTypeMetrics.String(), component.DataTypeLogs.String()),
+	}
+
+	return root
+}
+
+func componentsSchema[F component.Factory](root *configschema.Schema, kind string, factories map[component.Type]F, stability func(F) map[string]component.StabilityLevel) *configschema.Schema {
+	section := &configschema.Schema{
+		Type:                 "object",
+		PatternProperties:    map[string]*configschema.Schema{},
+		AdditionalProperties: false,
+	}
+	for _, f := range sortFactoriesByType(factories) {
+		def := configschema.FromConfig(f.CreateDefaultConfig())
+		def.Title = fmt.Sprintf("%s %s", f.Type(), strings.TrimSuffix(kind, "s"))
+		def.Description, def.Deprecated = describeStability(stability(f))
+		defName := kind + "/" + f.Type().String()
+		root.Defs[defName] = def
+		section.PatternProperties[idPattern(f.Type().String())] = &configschema.Schema{Ref: "#/$defs/" + defName}
+	}
+	return section
+}
+
+// describeStability returns a description of the given stability levels, and whether
+// the component is deprecated for all the data types it supports.
+func describeStability(levels map[string]component.StabilityLevel) (string, bool) {
+	names := make([]string, 0, len(levels))
+	for name, level := range levels {
+		if level != component.StabilityLevelUndefined {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deprecated := len(names) > 0
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, levels[name]))
+		deprecated = deprecated && levels[name] == component.StabilityLevelDeprecated
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return "Stability: " + strings.Join(parts, ", "), deprecated
+}
+
+// idPattern returns a pattern matching the string representation of a component.ID
+// with one of the given types.
+func idPattern(types ...string) string {
+	quoted := make([]string, 0, len(types))
+	for _, t := range types {
+		quoted = append(quoted, regexp.QuoteMeta(t))
+	}
+	return fmt.Sprintf("^(%s)(/.+)?$", strings.Join(quoted, "|"))
+}
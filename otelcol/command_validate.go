@@ -4,28 +4,145 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+)
+
+const (
+	strictFlag      = "strict"
+	printConfigFlag = "print-config"
+
+	strictModeWarn  = "warn"
+	strictModeError = "error"
 )
 
 // newValidateSubCommand constructs a new validate sub command using the given CollectorSettings.
 func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var strictMode string
+	var printConfig bool
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the config without running the collector",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if strictMode != "" && strictMode != strictModeWarn && strictMode != strictModeError {
+				return fmt.Errorf("invalid value %q for --%s, must be one of %q or %q", strictMode, strictFlag, strictModeWarn, strictModeError)
+			}
 			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
 				return err
 			}
-			col, err := NewCollector(set)
-			if err != nil {
-				return err
+			if strictMode == "" && !printConfig {
+				col, err := NewCollector(set)
+				if err != nil {
+					return err
+				}
+				return col.DryRun(cmd.Context())
 			}
-			return col.DryRun(cmd.Context())
+			return validateResolved(cmd, set, strictMode, printConfig)
 		},
 	}
 	validateCmd.Flags().AddGoFlagSet(flagSet)
+	validateCmd.Flags().StringVar(&strictMode, strictFlag, "",
+		"Report weakly typed values, deprecated components and fields, and unexpanded ${} references. "+
+			"Must be \""+strictModeWarn+"\" to only print them, or \""+strictModeError+"\" to also fail the validation.")
+	validateCmd.Flags().Lookup(strictFlag).NoOptDefVal = strictModeWarn
+	validateCmd.Flags().BoolVar(&printConfig, printConfigFlag, false,
+		"Print the configuration resolved by the providers and converters, e.g. to preview the result of templates, before validating it. "+
+			"The output can contain sensitive values such as secrets fetched by the providers.")
 	return validateCmd
 }
+
+// validateResolved validates the configuration as Collector.DryRun does, after printing it to the standard
+// output if printConfig is true, and the issues found by configschema.Schema.Check in its raw values to the
+// standard error if strictMode is set. The configuration is only resolved once.
+func validateResolved(cmd *cobra.Command, set CollectorSettings, strictMode string, printConfig bool) (err error) {
+	ctx := cmd.Context()
+	factories, err := set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	escaped := configschema.EscapedReferences{}
+	resolverSet := set.ConfigProviderSettings.ResolverSettings
+	resolverSet.ProviderFactories = collectEscapedReferences(resolverSet.ProviderFactories, escaped)
+	resolverSet.ProviderSettings = confmap.ProviderSettings{Logger: zap.NewNop()}
+	resolverSet.ConverterSettings = confmap.ConverterSettings{Logger: zap.NewNop()}
+	resolver, err := confmap.NewResolver(resolverSet)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resolver.Shutdown(ctx))
+	}()
+
+	conf, err := resolver.Resolve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get config: cannot resolve the configuration: %w", err)
+	}
+
+	if printConfig {
+		yamlData, yamlErr := yaml.Marshal(conf.ToStringMap())
+		if yamlErr != nil {
+			return yamlErr
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+	}
+
+	// Issues are also reported when the validation fails, as they
+	// point to the exact location of weakly typed values.
+	var issues []configschema.Issue
+	if strictMode != "" {
+		issues = configSchema(factories, set.BuildInfo).Check(conf.ToStringMap(), escaped)
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", strictMode, issue)
+	}
+	cfg, err := unmarshalConfig(conf, factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	if strictMode == strictModeError && len(issues) > 0 {
+		return fmt.Errorf("strict validation found %d issue(s)", len(issues))
+	}
+	return nil
+}
+
+// collectEscapedReferences wraps the provider factories so that the escaped references of the
+// retrieved configurations are collected, as the resolution turns them into literal strings.
+func collectEscapedReferences(factories []confmap.ProviderFactory, escaped configschema.EscapedReferences) []confmap.ProviderFactory {
+	wrapped := make([]confmap.ProviderFactory, 0, len(factories))
+	for _, factory := range factories {
+		factory := factory
+		wrapped = append(wrapped, confmap.NewProviderFactory(func(set confmap.ProviderSettings) confmap.Provider {
+			return &escapeCollectingProvider{Provider: factory.Create(set), escaped: escaped}
+		}))
+	}
+	return wrapped
+}
+
+type escapeCollectingProvider struct {
+	confmap.Provider
+	escaped configschema.EscapedReferences
+}
+
+func (p *escapeCollectingProvider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	ret, err := p.Provider.Retrieve(ctx, uri, watcher)
+	if err != nil {
+		return nil, err
+	}
+	if raw, rawErr := ret.AsRaw(); rawErr == nil {
+		p.escaped.Collect(raw)
+	}
+	return ret, nil
+}
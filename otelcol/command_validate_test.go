@@ -4,14 +4,18 @@
 package otelcol
 
 import (
+	"bytes"
 	"context"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/globalgates"
 )
 
 func TestValidateSubCommandNoConfig(t *testing.T) {
@@ -29,10 +33,135 @@ func TestValidateSubCommandInvalidComponents(t *testing.T) {
 	cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
 		ResolverSettings: confmap.ResolverSettings{
 			URIs:              []string{filePath},
-			ProviderFactories: []confmap.ProviderFactory{fileProvider},
+			ProviderFactories: []confmap.ProviderFactory{fileProvider, newEnvProvider()},
 		},
 	}}, flags(featuregate.GlobalRegistry()))
 	err := cmd.Execute()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unknown type: \"nosuchprocessor\"")
 }
+
+func TestValidateSubCommandStrict(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		mode        string
+		weaklyTyped bool
+		expectedErr string
+		expectedOut []string
+	}{
+		{
+			name:        "invalid mode",
+			file:        "otelcol-nop.yaml",
+			mode:        "--strict=fail",
+			expectedErr: `invalid value "fail" for --strict`,
+		},
+		{
+			name: "valid config",
+			file: "otelcol-strict.yaml",
+			mode: "--strict=error",
+		},
+		{
+			name:        "deprecated field warning",
+			file:        "otelcol-nop.yaml",
+			mode:        "--strict",
+			expectedOut: []string{`warn: service::telemetry::metrics::address: "address" is deprecated`},
+		},
+		{
+			name:        "deprecated field error",
+			file:        "otelcol-nop.yaml",
+			mode:        "--strict=error",
+			expectedOut: []string{`error: service::telemetry::metrics::address: "address" is deprecated`},
+			expectedErr: "strict validation found 1 issue(s)",
+		},
+		{
+			name: "weak type error",
+			file: "weak-implicit-string-to-int.yaml",
+			mode: "--strict=error",
+			expectedOut: []string{
+				`error: service::telemetry::logs::sampling::initial: weakly typed string value "100" will be rejected, expected an integer`,
+			},
+			expectedErr: "expected type 'int', got unconvertible type 'string'",
+		},
+		{
+			name:        "weak type error without strictly typed input",
+			file:        "weak-implicit-string-to-int.yaml",
+			mode:        "--strict=error",
+			weaklyTyped: true,
+			expectedOut: []string{
+				`error: service::telemetry::logs::sampling::initial: weakly typed string value "100" is converted to integer`,
+			},
+			expectedErr: "strict validation found 2 issue(s)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.weaklyTyped {
+				prev := globalgates.StrictlyTypedInputGate.IsEnabled()
+				defer func() {
+					require.NoError(t, featuregate.GlobalRegistry().Set(globalgates.StrictlyTypedInputID, prev))
+				}()
+				require.NoError(t, featuregate.GlobalRegistry().Set(globalgates.StrictlyTypedInputID, false))
+			}
+			filePath := filepath.Join("testdata", tt.file)
+			fileProvider := newFakeProvider("file", func(_ context.Context, _ string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+				return confmap.NewRetrieved(newConfFromFile(t, filePath))
+			})
+			cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+				ResolverSettings: confmap.ResolverSettings{
+					URIs:              []string{filePath},
+					ProviderFactories: []confmap.ProviderFactory{fileProvider, newEnvProvider()},
+				},
+			}}, flags(featuregate.GlobalRegistry()))
+			cmd.SetArgs([]string{tt.mode})
+			out := bytes.NewBufferString("")
+			cmd.SetErr(out)
+
+			err := cmd.Execute()
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if len(tt.expectedOut) == 0 && tt.expectedErr == "" {
+				assert.Empty(t, out.String())
+			}
+			for _, line := range tt.expectedOut {
+				assert.Contains(t, out.String(), line)
+			}
+		})
+	}
+}
+
+func TestValidateSubCommandPrintConfig(t *testing.T) {
+	for _, file := range []string{"otelcol-nop.yaml", "otelcol-invalid-components.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			filePath := filepath.Join("testdata", file)
+			fileProvider := newFakeProvider("file", func(_ context.Context, _ string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+				return confmap.NewRetrieved(newConfFromFile(t, filePath))
+			})
+			cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+				ResolverSettings: confmap.ResolverSettings{
+					URIs:              []string{filePath},
+					ProviderFactories: []confmap.ProviderFactory{fileProvider, newEnvProvider()},
+				},
+			}}, flags(featuregate.GlobalRegistry()))
+			// As for the root command, the usage is not printed on errors.
+			cmd.SilenceUsage = true
+			cmd.SetArgs([]string{"--print-config"})
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+
+			// The configuration is printed even if it is invalid.
+			err := cmd.Execute()
+			if file == "otelcol-nop.yaml" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "unknown type: \"nosuchprocessor\"")
+			}
+			var printed map[string]any
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), &printed))
+			assert.Equal(t, newConfFromFile(t, filePath), printed)
+		})
+	}
+}
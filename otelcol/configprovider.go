@@ -102,7 +102,12 @@ func (cm *configProvider) Get(ctx context.Context, factories Factories) (*Config
 	if err != nil {
 		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
+	return unmarshalConfig(conf, factories)
+}
 
+// unmarshalConfig returns the service configuration held by the resolved configuration.
+func unmarshalConfig(conf *confmap.Conf, factories Factories) (*Config, error) {
+	var err error
 	var cfg *configSettings
 	if cfg, err = unmarshal(conf, factories); err != nil {
 		err = fmt.Errorf("cannot unmarshal the configuration: %w", err)
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/internal/globalgates"
+)
+
+const defsPrefix = "#/$defs/"
+
+// Issue is a problem found in a raw configuration, e.g. a value that is only accepted
+// because of weak typing, or rejected if the confmap.strictlyTypedInput feature gate is enabled.
+type Issue struct {
+	// Path is the location of the value, with keys separated by confmap.KeyDelimiter.
+	Path string
+	// Message describes the issue.
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// EscapedReferences holds the `${...}` references which are escaped as `$${...}` in the
+// configuration retrieved by the providers. The resolution turns them into literal strings,
+// which cannot be told apart from references that were not expanded.
+type EscapedReferences map[string]struct{}
+
+// Collect records the escaped references found in the strings of the retrieved value.
+func (e EscapedReferences) Collect(v any) {
+	switch val := v.(type) {
+	case string:
+		forEachReference(val, func(ref string, escaped bool) {
+			if escaped {
+				e[ref] = struct{}{}
+			}
+		})
+	case []any:
+		for _, item := range val {
+			e.Collect(item)
+		}
+	case map[string]any:
+		for _, item := range val {
+			e.Collect(item)
+		}
+	}
+}
+
+// Check walks the raw configuration alongside the schema and reports every value that
+// relies on a weak type conversion, which is rejected if the confmap.strictlyTypedInput
+// feature gate is enabled, every deprecated key that is set and every string
+// that still contains a `${...}` reference after resolution, unless the reference is escaped.
+// Values that cannot be unmarshaled at all are ignored, as those are reported by the
+// unmarshaling itself. Issues are sorted by path.
+func (s *Schema) Check(raw map[string]any, escaped EscapedReferences) []Issue {
+	c := &checker{
+		root:          s,
+		patterns:      map[string]*regexp.Regexp{},
+		strictlyTyped: globalgates.StrictlyTypedInputGate.IsEnabled(),
+		escaped:       escaped,
+	}
+	c.check(s, raw, nil)
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		return c.issues[i].Path < c.issues[j].Path
+	})
+	return c.issues
+}
+
+type checker struct {
+	root          *Schema
+	patterns      map[string]*regexp.Regexp
+	strictlyTyped bool
+	escaped       EscapedReferences
+	issues        []Issue
+}
+
+func (c *checker) report(path []string, format string, args ...any) {
+	c.issues = append(c.issues, Issue{
+		Path:    strings.Join(path, confmap.KeyDelimiter),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// reportWeak reports a value of the wrong type, which is converted by the weak typing of confmap,
+// or rejected if the confmap.strictlyTypedInput feature gate is enabled.
+func (c *checker) reportWeak(path []string, value, expected, conversion string) {
+	if c.strictlyTyped {
+		c.report(path, "weakly typed %s will be rejected, expected %s", value, expected)
+		return
+	}
+	c.report(path, "weakly typed %s is %s", value, conversion)
+}
+
+func (c *checker) regexp(pattern string) *regexp.Regexp {
+	re, ok := c.patterns[pattern]
+	if !ok {
+		re = regexp.MustCompile(pattern)
+		c.patterns[pattern] = re
+	}
+	return re
+}
+
+func (c *checker) check(s *Schema, v any, path []string) {
+	if s.Ref != "" {
+		def, ok := c.root.Defs[strings.TrimPrefix(s.Ref, defsPrefix)]
+		if !ok {
+			return
+		}
+		s = def
+	}
+	if v == nil {
+		return
+	}
+	if s.Deprecated {
+		c.report(path, "%s is deprecated", describe(s, path))
+	}
+
+	switch s.Type {
+	case "object":
+		switch val := v.(type) {
+		case map[string]any:
+			c.checkObject(s, val, path)
+		case []any:
+			c.reportWeak(path, "list", "a map", "merged into a map")
+		}
+	case "array":
+		c.checkArray(s, v, path)
+	case "string":
+		c.checkString(s, v, path)
+	case "boolean":
+		switch v.(type) {
+		case bool:
+		case string, int, int64, uint64, float64:
+			c.reportWeak(path, describeValue(v), "a boolean", "converted to boolean")
+		}
+	case "integer":
+		switch v.(type) {
+		case int, int64, uint64:
+		case float64, string, bool:
+			c.reportWeak(path, describeValue(v), "an integer", "converted to integer")
+		}
+	case "number":
+		switch v.(type) {
+		case int, int64, uint64, float64:
+		case string, bool:
+			c.reportWeak(path, describeValue(v), "a number", "converted to number")
+		}
+	default:
+		if str, ok := v.(string); ok {
+			c.checkReference(str, path)
+		}
+	}
+}
+
+func (c *checker) checkObject(s *Schema, m map[string]any, path []string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		sub := c.propertySchema(s, k)
+		if sub != nil {
+			c.check(sub, m[k], append(path[:len(path):len(path)], k))
+		}
+	}
+}
+
+// propertySchema returns the schema of the value stored under key, or nil if unknown.
+func (c *checker) propertySchema(s *Schema, key string) *Schema {
+	if sub, ok := s.Properties[key]; ok {
+		return sub
+	}
+	for pattern, sub := range s.PatternProperties {
+		if c.regexp(pattern).MatchString(key) {
+			return sub
+		}
+	}
+	if sub, ok := s.AdditionalProperties.(*Schema); ok {
+		return sub
+	}
+	return nil
+}
+
+func (c *checker) checkArray(s *Schema, v any, path []string) {
+	switch val := v.(type) {
+	case []any:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range val {
+			c.check(s.Items, item, append(path[:len(path):len(path)], strconv.Itoa(i)))
+		}
+	case string:
+		c.reportWeak(path, describeValue(val), "a list", "split on ',' into a list")
+		c.checkReference(val, path)
+	case map[string]any:
+		if len(val) == 0 {
+			c.reportWeak(path, "empty map", "a list", "converted to an empty list")
+		}
+	default:
+		c.reportWeak(path, describeValue(v), "a list", "converted to a list")
+	}
+}
+
+func (c *checker) checkString(s *Schema, v any, path []string) {
+	switch val := v.(type) {
+	case string:
+		c.checkReference(val, path)
+	case int, int64, uint64:
+		if s.Pattern == durationPattern {
+			c.report(path, "integer value %v interpreted as a duration in nanoseconds, use a duration string such as \"5s\" instead", val)
+			return
+		}
+		c.reportWeak(path, describeValue(val), "a string", "converted to string")
+	case float64, bool:
+		c.reportWeak(path, describeValue(val), "a string", "converted to string")
+	}
+}
+
+func (c *checker) checkReference(s string, path []string) {
+	forEachReference(s, func(ref string, escaped bool) {
+		if _, ok := c.escaped[ref]; !ok && !escaped {
+			c.report(path, "reference %q was not expanded", ref)
+		}
+	})
+}
+
+// forEachReference calls f with each `${...}` reference of s, and whether it is escaped
+// by an odd number of `$`, as done by the confmap.Resolver.
+func forEachReference(s string, f func(ref string, escaped bool)) {
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			return
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			return
+		}
+		dollars := start - len(strings.TrimRight(s[:start], "$"))
+		f(s[start:start+end+1], dollars%2 == 1)
+		s = s[start+end+1:]
+	}
+}
+
+func describe(s *Schema, path []string) string {
+	if s.Title != "" {
+		return s.Title
+	}
+	if len(path) == 0 {
+		return "configuration"
+	}
+	return fmt.Sprintf("%q", path[len(path)-1])
+}
+
+// describeValue returns the kind and the value, e.g. `string value "3"`.
+func describeValue(v any) string {
+	return fmt.Sprintf("%s value %s", kindOf(v), formatValue(v))
+}
+
+func kindOf(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "float"
+	case int, int64, uint64:
+		return "integer"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/globalgates"
+)
+
+func TestCheck(t *testing.T) {
+	root := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"components": {
+				Type: "object",
+				PatternProperties: map[string]*Schema{
+					"^(test)(/.+)?$": {Ref: "#/$defs/test"},
+					"^(old)(/.+)?$":  {Ref: "#/$defs/old"},
+				},
+				AdditionalProperties: false,
+			},
+		},
+		Defs: map[string]*Schema{
+			"test": FromType(reflect.TypeOf(testConfig{})),
+			"old":  {Title: "old component", Type: "object", Deprecated: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		raw      map[string]any
+		expected []Issue
+		// expectedWeak are the issues when the strictly typed input feature gate is disabled, if different.
+		expectedWeak []Issue
+	}{
+		{
+			name: "valid",
+			raw: map[string]any{"components": map[string]any{"test/1": map[string]any{
+				"endpoint": "localhost:4317",
+				"count":    3,
+				"ratio":    1,
+				"tags":     []any{"a"},
+				"nested":   map[string]any{"enabled": true, "timeout": "5s"},
+				"unknown":  "ignored",
+			}}},
+		},
+		{
+			name: "weak types",
+			raw: map[string]any{"components": map[string]any{"test": map[string]any{
+				"endpoint": 4317,
+				"count":    "3",
+				"ratio":    "0.5",
+				"tags":     "a,b",
+				"headers":  []any{map[string]any{"a": "b"}},
+				"nested":   map[string]any{"enabled": "true", "timeout": 5000},
+			}}},
+			expected: []Issue{
+				{Path: "components::test::count", Message: `weakly typed string value "3" will be rejected, expected an integer`},
+				{Path: "components::test::endpoint", Message: "weakly typed integer value 4317 will be rejected, expected a string"},
+				{Path: "components::test::headers", Message: "weakly typed list will be rejected, expected a map"},
+				{Path: "components::test::nested::enabled", Message: `weakly typed string value "true" will be rejected, expected a boolean`},
+				{Path: "components::test::nested::timeout", Message: `integer value 5000 interpreted as a duration in nanoseconds, use a duration string such as "5s" instead`},
+				{Path: "components::test::ratio", Message: `weakly typed string value "0.5" will be rejected, expected a number`},
+				{Path: "components::test::tags", Message: `weakly typed string value "a,b" will be rejected, expected a list`},
+			},
+			expectedWeak: []Issue{
+				{Path: "components::test::count", Message: `weakly typed string value "3" is converted to integer`},
+				{Path: "components::test::endpoint", Message: "weakly typed integer value 4317 is converted to string"},
+				{Path: "components::test::headers", Message: "weakly typed list is merged into a map"},
+				{Path: "components::test::nested::enabled", Message: `weakly typed string value "true" is converted to boolean`},
+				{Path: "components::test::nested::timeout", Message: `integer value 5000 interpreted as a duration in nanoseconds, use a duration string such as "5s" instead`},
+				{Path: "components::test::ratio", Message: `weakly typed string value "0.5" is converted to number`},
+				{Path: "components::test::tags", Message: `weakly typed string value "a,b" is split on ',' into a list`},
+			},
+		},
+		{
+			name: "lists",
+			raw: map[string]any{"components": map[string]any{"test": map[string]any{
+				"tags": map[string]any{},
+				"recursive": map[string]any{
+					"name":     true,
+					"children": []any{map[string]any{"name": 1}},
+				},
+			}}},
+			expected: []Issue{
+				{Path: "components::test::recursive::name", Message: "weakly typed boolean value true will be rejected, expected a string"},
+				{Path: "components::test::tags", Message: "weakly typed empty map will be rejected, expected a list"},
+			},
+			expectedWeak: []Issue{
+				{Path: "components::test::recursive::name", Message: "weakly typed boolean value true is converted to string"},
+				{Path: "components::test::tags", Message: "weakly typed empty map is converted to an empty list"},
+			},
+		},
+		{
+			name: "deprecated",
+			raw:  map[string]any{"components": map[string]any{"old/a": nil, "old/b": map[string]any{}}},
+			expected: []Issue{
+				{Path: "components::old/b", Message: "old component is deprecated"},
+			},
+		},
+		{
+			name: "deprecated field",
+			raw:  map[string]any{"components": map[string]any{"test": map[string]any{"legacy": "localhost:4317"}}},
+			expected: []Issue{
+				{Path: "components::test::legacy", Message: `"legacy" is deprecated`},
+			},
+		},
+		{
+			name: "unexpanded references",
+			raw: map[string]any{"components": map[string]any{"test": map[string]any{
+				"endpoint": "${HOST}:4317",
+				"any":      "${env:VALUE}",
+				"legacy":   "$${env:ESCAPED} ${env:ESCAPED_BY_PROVIDER}",
+			}}},
+			expected: []Issue{
+				{Path: "components::test::any", Message: `reference "${env:VALUE}" was not expanded`},
+				{Path: "components::test::endpoint", Message: `reference "${HOST}" was not expanded`},
+				{Path: "components::test::legacy", Message: `"legacy" is deprecated`},
+			},
+		},
+	}
+	for _, strictlyTyped := range []bool{true, false} {
+		setStrictlyTypedInput(t, strictlyTyped)
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/strictly_typed=%t", tt.name, strictlyTyped), func(t *testing.T) {
+				expected := tt.expected
+				if !strictlyTyped && tt.expectedWeak != nil {
+					expected = tt.expectedWeak
+				}
+				assert.Equal(t, expected, root.Check(tt.raw, EscapedReferences{"${env:ESCAPED_BY_PROVIDER}": {}}))
+			})
+		}
+	}
+}
+
+func setStrictlyTypedInput(t *testing.T, enabled bool) {
+	prev := globalgates.StrictlyTypedInputGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(globalgates.StrictlyTypedInputID, enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(globalgates.StrictlyTypedInputID, prev))
+	})
+}
+
+func TestEscapedReferencesCollect(t *testing.T) {
+	escaped := EscapedReferences{}
+	escaped.Collect(map[string]any{
+		"escaped":   "$${env:A}",
+		"unescaped": "$$${env:B}",
+		"list":      []any{"${env:C} $${env:D}", 1},
+	})
+	assert.Equal(t, EscapedReferences{"${env:A}": {}, "${env:D}": {}}, escaped)
+}
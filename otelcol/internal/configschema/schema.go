@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Version is the JSON Schema dialect used by the generated schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	tagNameMapStructure = "mapstructure"
+	// tagNameDeprecated marks a deprecated field, its value describes what to use instead.
+	tagNameDeprecated = "deprecated"
+	optionSeparator   = ","
+	optionSquash      = "squash"
+	optionRemain      = "remain"
+	optionSkip        = "-"
+)
+
+// durationPattern matches the duration strings accepted by time.ParseDuration.
+const durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*confmap.Unmarshaler)(nil)).Elem()
+)
+
+// Schema is the subset of JSON Schema needed to describe collector configuration.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+
+	Properties        map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties map[string]*Schema `json:"patternProperties,omitempty"`
+	PropertyNames     *Schema            `json:"propertyNames,omitempty"`
+	// AdditionalProperties is either a *Schema or a bool.
+	AdditionalProperties any     `json:"additionalProperties,omitempty"`
+	Items                *Schema `json:"items,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// FromType generates the Schema describing how a value of the given type is
+// unmarshalled from a confmap.Conf, following the `mapstructure` tags.
+func FromType(t reflect.Type) *Schema {
+	return newGenerator().fromType(t)
+}
+
+// FromConfig generates the Schema for the given config and records its non-zero
+// values as defaults. It is meant to be called with the result of a factory's
+// CreateDefaultConfig.
+func FromConfig(cfg any) *Schema {
+	if cfg == nil {
+		return &Schema{}
+	}
+	s := FromType(reflect.TypeOf(cfg))
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err == nil {
+		applyDefaults(s, conf.ToStringMap())
+	}
+	return s
+}
+
+type generator struct {
+	// visiting tracks the struct types being generated to break recursive types.
+	visiting map[reflect.Type]bool
+}
+
+func newGenerator() *generator {
+	return &generator{visiting: map[reflect.Type]bool{}}
+}
+
+func (g *generator) fromType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		return &Schema{Type: "string", Pattern: durationPattern}
+	}
+	if t.Kind() != reflect.Interface && (t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: g.fromType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.fromType(t.Elem())}
+	case reflect.Struct:
+		return g.fromStruct(t)
+	default:
+		// Interfaces, funcs and channels can hold anything.
+		return &Schema{}
+	}
+}
+
+func (g *generator) fromStruct(t reflect.Type) *Schema {
+	if g.visiting[t] {
+		// Recursive type: stop here and allow any object.
+		return &Schema{Type: "object"}
+	}
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts := parseTag(f)
+		if name == optionSkip {
+			continue
+		}
+		switch {
+		case opts[optionRemain]:
+			// The remaining keys are collected into a map, allow them all.
+			s.AdditionalProperties = g.fromType(f.Type).AdditionalProperties
+		case opts[optionSquash]:
+			sub := g.fromType(f.Type)
+			for k, v := range sub.Properties {
+				s.Properties[k] = v
+			}
+			if sub.AdditionalProperties != nil {
+				s.AdditionalProperties = sub.AdditionalProperties
+			}
+		default:
+			prop := g.fromType(f.Type)
+			if hint, ok := f.Tag.Lookup(tagNameDeprecated); ok {
+				prop.Deprecated = true
+				prop.Description = hint
+			}
+			s.Properties[name] = prop
+		}
+	}
+	if s.AdditionalProperties == nil {
+		// Custom unmarshalers may accept keys that are not struct fields.
+		s.AdditionalProperties = reflect.PointerTo(t).Implements(unmarshalerType)
+	}
+	return s
+}
+
+// parseTag returns the key used by mapstructure for the field and the tag options.
+func parseTag(f reflect.StructField) (string, map[string]bool) {
+	tag, ok := f.Tag.Lookup(tagNameMapStructure)
+	if !ok {
+		return f.Name, map[string]bool{}
+	}
+	parts := strings.Split(tag, optionSeparator)
+	opts := make(map[string]bool, len(parts)-1)
+	for _, opt := range parts[1:] {
+		opts[strings.TrimSpace(opt)] = true
+	}
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	return name, opts
+}
+
+// applyDefaults walks the marshaled default configuration alongside the schema
+// and records every non-zero scalar or list as the default of its property.
+func applyDefaults(s *Schema, v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, sub := range val {
+			if prop, ok := s.Properties[k]; ok {
+				applyDefaults(prop, sub)
+			}
+		}
+	case nil:
+	case time.Duration:
+		if val != 0 {
+			s.Default = val.String()
+		}
+	case []any:
+		if len(val) > 0 {
+			s.Default = val
+		}
+	default:
+		if !reflect.ValueOf(val).IsZero() {
+			s.Default = val
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type EmbeddedConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type nestedConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type recursiveConfig struct {
+	Name     string            `mapstructure:"name"`
+	Children []recursiveConfig `mapstructure:"children"`
+}
+
+type testConfig struct {
+	EmbeddedConfig `mapstructure:",squash"`
+	ID             component.ID      `mapstructure:"id"`
+	Count          int               `mapstructure:"count"`
+	Ratio          float64           `mapstructure:"ratio"`
+	Tags           []string          `mapstructure:"tags"`
+	Headers        map[string]string `mapstructure:"headers"`
+	Nested         *nestedConfig     `mapstructure:"nested"`
+	Recursive      recursiveConfig   `mapstructure:"recursive"`
+	Raw            []byte            `mapstructure:"raw"`
+	Any            any               `mapstructure:"any"`
+	Legacy         string            `mapstructure:"legacy" deprecated:"Use endpoint instead."`
+	Skipped        string            `mapstructure:"-"`
+	Untagged       string
+	unexported     string //nolint:unused
+}
+
+type remainConfig struct {
+	Known string         `mapstructure:"known"`
+	Rest  map[string]any `mapstructure:",remain"`
+}
+
+func TestFromType(t *testing.T) {
+	s := FromType(reflect.TypeOf(&testConfig{}))
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.ElementsMatch(t,
+		[]string{"endpoint", "id", "count", "ratio", "tags", "headers", "nested", "recursive", "raw", "any", "legacy", "Untagged"},
+		keys(s.Properties))
+
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["endpoint"])
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["id"])
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["count"])
+	assert.Equal(t, &Schema{Type: "number"}, s.Properties["ratio"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, s.Properties["tags"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, s.Properties["headers"])
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["raw"])
+	assert.Equal(t, &Schema{}, s.Properties["any"])
+	assert.Equal(t, &Schema{Type: "string", Deprecated: true, Description: "Use endpoint instead."}, s.Properties["legacy"])
+
+	nested := s.Properties["nested"]
+	assert.Equal(t, &Schema{Type: "boolean"}, nested.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "string", Pattern: durationPattern}, nested.Properties["timeout"])
+
+	recursive := s.Properties["recursive"]
+	assert.Equal(t, &Schema{Type: "object"}, recursive.Properties["children"].Items)
+}
+
+func TestFromTypeRemain(t *testing.T) {
+	s := FromType(reflect.TypeOf(remainConfig{}))
+	assert.Equal(t, []string{"known"}, keys(s.Properties))
+	assert.Equal(t, &Schema{}, s.AdditionalProperties)
+}
+
+func TestFromConfigDefaults(t *testing.T) {
+	s := FromConfig(&testConfig{
+		EmbeddedConfig: EmbeddedConfig{Endpoint: "localhost:4317"},
+		Count:          3,
+		Tags:           []string{"a", "b"},
+		Nested:         &nestedConfig{Timeout: 5 * time.Second},
+	})
+	assert.Equal(t, "localhost:4317", s.Properties["endpoint"].Default)
+	assert.Equal(t, 3, s.Properties["count"].Default)
+	assert.Equal(t, []any{"a", "b"}, s.Properties["tags"].Default)
+	assert.Equal(t, "5s", s.Properties["nested"].Properties["timeout"].Default)
+	assert.Nil(t, s.Properties["nested"].Properties["enabled"].Default)
+	assert.Nil(t, s.Properties["ratio"].Default)
+
+	assert.Equal(t, &Schema{}, FromConfig(nil))
+}
+
+func TestSchemaJSON(t *testing.T) {
+	b, err := json.Marshal(FromType(reflect.TypeOf(nestedConfig{})))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"enabled": {"type": "boolean"},
+			"timeout": {"type": "string", "pattern": `+mustJSON(t, durationPattern)+`}
+		},
+		"additionalProperties": false
+	}`, string(b))
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func mustJSON(t *testing.T, v any) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
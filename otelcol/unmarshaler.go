@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -41,6 +42,7 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
 		Service: service.Config{
 			Telemetry: defaultTelConfig,
+			Health:    health.NewDefaultConfig(),
 		},
 	}
 
@@ -28,8 +28,8 @@ func new{{ .structName }}(orig *[]{{ .originElementType }}, state *internal.Stat
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New{{ .structName }}() {{ .structName }} {
 	orig := []{{ .originElementType }}(nil)
-	state := internal.StateMutable
-	return new{{ .structName }}(&orig, &state)
+	state := internal.NewState()
+	return new{{ .structName }}(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -47,7 +47,11 @@ func (es {{ .structName }}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es {{ .structName }}) At(i int) {{ .elementName }} {
+	{{- if .copyOnWrite }}
+	return new{{ .elementName }}(internal.LoadNode(es.state, &(*es.orig)[i], cloneShared{{ .elementName }}), es.state)
+	{{- else }}
 	return {{ .newElement }}
+	{{- end }}
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -71,12 +75,22 @@ func (es {{ .structName }}) EnsureCapacity(newCap int) {
 	newOrig := make([]{{ .originElementType }}, len(*es.orig), newCap)
 	copy(newOrig, *es.orig)
 	*es.orig = newOrig
+	{{- if eq .type "sliceOfValues" }}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
+	{{- end }}
 }
 
 // AppendEmpty will append to the end of the slice an empty {{ .elementName }}.
 // It returns the newly added {{ .elementName }}.
 func (es {{ .structName }}) AppendEmpty() {{ .elementName }} {
 	es.state.AssertMutable()
+	{{- if eq .type "sliceOfValues" }}
+	if len(*es.orig) == cap(*es.orig) {
+		// The maps of the elements are moved.
+		es.state.ResetMapIndexes()
+	}
+	{{- end }}
 	*es.orig = append(*es.orig, {{ .emptyOriginElement }})
 	return es.At(es.Len() - 1)
 }
@@ -92,6 +106,14 @@ func (es {{ .structName }}) MoveAndAppendTo(dest {{ .structName }}) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	{{- if .copyOnWrite }}
+	dest.state.InheritShared(es.state)
+	{{- end }}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
+	{{- if eq .type "sliceOfValues" }}
+	dest.state.ResetMapIndexes()
+	{{- end }}
 	*es.orig = nil
 }
 
@@ -112,6 +134,10 @@ func (es {{ .structName }}) RemoveIf(f func({{ .elementName }}) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded{{ if eq .type "sliceOfValues" }}, and the maps of the others are moved{{ end }}.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
@@ -119,6 +145,8 @@ func (es {{ .structName }}) RemoveIf(f func({{ .elementName }}) bool) {
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es {{ .structName }}) CopyTo(dest {{ .structName }}) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -126,7 +154,13 @@ func (es {{ .structName }}) CopyTo(dest {{ .structName }}) {
 
 	{{- if eq .type "sliceOfPtrs" }}
 		for i := range *es.orig {
-			new{{ .elementName }}((*es.orig)[i], es.state).CopyTo(new{{ .elementName }}((*dest.orig)[i], dest.state))
+			{{- if .copyOnWrite }}
+			if dest.state.Unshare((*dest.orig)[i]) {
+				// The shared element is overridden, so it does not need to be cloned.
+				(*dest.orig)[i] = {{ .emptyOriginElement }}
+			}
+			{{- end }}
+			{{ .readElement }}.CopyTo(new{{ .elementName }}((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -134,7 +168,7 @@ func (es {{ .structName }}) CopyTo(dest {{ .structName }}) {
 	wrappers := make([]*{{ .originName }}, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		new{{ .elementName }}((*es.orig)[i], es.state).CopyTo(new{{ .elementName }}(wrappers[i], dest.state))
+		{{ .readElement }}.CopyTo(new{{ .elementName }}(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 
@@ -161,8 +195,8 @@ func (es {{ .structName }}) Sort(less func(a, b {{ .elementName }}) bool) {
 const sliceTestTemplate = `func Test{{ .structName }}(t *testing.T) {
 	es := New{{ .structName }}()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = new{{ .structName }}(&[]{{ .originElementType }}{}, &state)
+	state := internal.NewState()
+	es = new{{ .structName }}(&[]{{ .originElementType }}{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := New{{ .elementName }}()
@@ -177,8 +211,8 @@ const sliceTestTemplate = `func Test{{ .structName }}(t *testing.T) {
 }
 
 func Test{{ .structName }}ReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := new{{ .structName }}(&[]{{ .originElementType }}{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := new{{ .structName }}(&[]{{ .originElementType }}{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -281,6 +315,41 @@ func Test{{ .structName }}_Sort(t *testing.T) {
 		assert.True(t, uintptr(unsafe.Pointer(es.At(i-1).orig)) > uintptr(unsafe.Pointer(es.At(i).orig)))
 	}
 }
+{{- end }}
+
+{{ if .copyOnWrite -}}
+func Test{{ .structName }}_CopyOnWrite(t *testing.T) {
+	es := generateTest{{ .structName }}()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, new{{ .structName }}(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, new{{ .elementName }}(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTest{{ .structName }}()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTest{{ .elementName }}().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTest{{ .structName }}()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
 {{- end }}`
 
 const sliceGenerateTest = `func generateTest{{ .structName }}() {{ .structName }} {
@@ -307,6 +376,9 @@ type sliceOfPtrs struct {
 	structName  string
 	packageName string
 	element     *messageValueStruct
+	// copyOnWrite indicates that the elements may be shared with other data, see internal.State.
+	// The package must provide a cloneShared<element> function returning a copy of a shared element.
+	copyOnWrite bool
 }
 
 func (ss *sliceOfPtrs) getName() string {
@@ -347,7 +419,17 @@ func (ss *sliceOfPtrs) templateFields() map[string]any {
 		"originElementType":  "*" + ss.element.originFullName,
 		"emptyOriginElement": "&" + ss.element.originFullName + "{}",
 		"newElement":         "new" + ss.element.structName + "((*es.orig)[i], es.state)",
+		"copyOnWrite":        ss.copyOnWrite,
+		"readElement":        ss.readElement(),
+	}
+}
+
+// readElement returns the expression reading the element i of es without modifying the slice.
+func (ss *sliceOfPtrs) readElement() string {
+	if ss.copyOnWrite {
+		return "new" + ss.element.structName + "(internal.ReadNode(es.state, &(*es.orig)[i]), es.state)"
 	}
+	return "new" + ss.element.structName + "((*es.orig)[i], es.state)"
 }
 
 func (ss *sliceOfPtrs) generateInternal(*bytes.Buffer) {}
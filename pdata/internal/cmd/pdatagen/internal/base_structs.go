@@ -38,8 +38,8 @@ func new{{ .structName }}(orig *{{ .originName }}, state *internal.State) {{ .st
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func New{{ .structName }}() {{ .structName }} {
-	state := internal.StateMutable
-	return new{{ .structName }}(&{{ .originName }}{}, &state)
+	state := internal.NewState()
+	return new{{ .structName }}(&{{ .originName }}{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -49,6 +49,12 @@ func (ms {{ .structName }}) MoveTo(dest {{ .structName }}) {
 	dest.{{- if .isCommon }}getState(){{ else }}state{{ end }}.AssertMutable()
 	*dest.{{ .origAccessor }} = *ms.{{ .origAccessor }}
 	*ms.{{ .origAccessor }} = {{ .originName }}{}
+	{{- if .copyOnWrite }}
+	dest.state.InheritShared(ms.state)
+	{{- end }}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.{{- if .isCommon }}getState(){{ else }}state{{ end }}.ResetMapIndexes()
+	ms.{{- if .isCommon }}getState(){{ else }}state{{ end }}.ResetMapIndexes()
 }
 
 {{ if .isCommon -}}
@@ -80,9 +86,9 @@ func Test{{ .structName }}_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, New{{ .structName }}(), ms)
 	assert.Equal(t, {{ .generateTestData }}, dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(new{{ .structName }}(&{{ .originName }}{}, &sharedState)) })
-	assert.Panics(t, func() { new{{ .structName }}(&{{ .originName }}{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(new{{ .structName }}(&{{ .originName }}{}, sharedState)) })
+	assert.Panics(t, func() { new{{ .structName }}(&{{ .originName }}{}, sharedState).MoveTo(dest) })
 }
 
 func Test{{ .structName }}_CopyTo(t *testing.T) {
@@ -93,8 +99,8 @@ func Test{{ .structName }}_CopyTo(t *testing.T) {
 	orig = {{ .generateTestData }}
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(new{{ .structName }}(&{{ .originName }}{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(new{{ .structName }}(&{{ .originName }}{}, sharedState)) })
 }
 
 {{ range .fields }}
@@ -104,9 +110,9 @@ func Test{{ .structName }}_CopyTo(t *testing.T) {
 const messageValueGenerateTestTemplate = `func {{ upperIfInternal "g" }}enerateTest{{ .structName }}() {{ .structName }} {
 	{{- if .isCommon }}
 	orig := {{ .originName }}{}
-	state := StateMutable
+	state := NewState()
 	{{- end }}
-	tv := New{{ .structName }}({{ if .isCommon }}&orig, &state{{ end }})
+	tv := New{{ .structName }}({{ if .isCommon }}&orig, state{{ end }})
 	{{ upperIfInternal "f" }}illTest{{ .structName }}(tv)
 	return tv
 }
@@ -151,6 +157,8 @@ type messageValueStruct struct {
 	description    string
 	originFullName string
 	fields         []baseField
+	// copyOnWrite indicates that the struct may contain elements shared with other data, see internal.State.
+	copyOnWrite bool
 }
 
 func (ms *messageValueStruct) getName() string {
@@ -208,6 +216,7 @@ func (ms *messageValueStruct) templateFields() map[string]any {
 		"description":  ms.description,
 		"isCommon":     usedByOtherDataTypes(ms.packageName),
 		"origAccessor": origAccessor(ms),
+		"copyOnWrite":  ms.copyOnWrite,
 	}
 }
 
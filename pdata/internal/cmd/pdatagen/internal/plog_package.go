@@ -36,14 +36,16 @@ var plog = &Package{
 }
 
 var resourceLogsSlice = &sliceOfPtrs{
-	structName: "ResourceLogsSlice",
-	element:    resourceLogs,
+	structName:  "ResourceLogsSlice",
+	element:     resourceLogs,
+	copyOnWrite: true,
 }
 
 var resourceLogs = &messageValueStruct{
 	structName:     "ResourceLogs",
 	description:    "// ResourceLogs is a collection of logs from a Resource.",
 	originFullName: "otlplogs.ResourceLogs",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -55,8 +57,9 @@ var resourceLogs = &messageValueStruct{
 }
 
 var scopeLogsSlice = &sliceOfPtrs{
-	structName: "ScopeLogsSlice",
-	element:    scopeLogs,
+	structName:  "ScopeLogsSlice",
+	element:     scopeLogs,
+	copyOnWrite: true,
 }
 
 var scopeLogs = &messageValueStruct{
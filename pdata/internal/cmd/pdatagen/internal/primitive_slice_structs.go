@@ -27,8 +27,8 @@ func (ms {{ .structName }}) getState() *internal.State {
 // New{{ .structName }} creates a new empty {{ .structName }}.
 func New{{ .structName }}() {{ .structName }} {
 	orig := []{{ .itemType }}(nil)
-	state := internal.StateMutable
-	return {{ .structName }}(internal.New{{ .structName }}(&orig, &state))
+	state := internal.NewState()
+	return {{ .structName }}(internal.New{{ .structName }}(&orig, state))
 }
 
 // AsRaw returns a copy of the []{{ .itemType }} slice.
@@ -139,8 +139,8 @@ const immutableSliceTestTemplate = `func TestNew{{ .structName }}(t *testing.T)
 
 func Test{{ .structName }}ReadOnly(t *testing.T) {
 	raw := []{{ .itemType }}{ {{ .testOrigVal }}}
-	state := internal.StateReadOnly
-	ms := {{ .structName }}(internal.New{{ .structName }}(&raw, &state))
+	state := internal.NewReadOnlyState()
+	ms := {{ .structName }}(internal.New{{ .structName }}(&raw, state))
 
 	assert.Equal(t, 3, ms.Len())
 	assert.Equal(t, {{ .itemType }}({{ index .testInterfaceOrigVal 0 }}), ms.At(0))
@@ -196,10 +196,10 @@ func FillTest{{ .structName }}(tv {{ .structName}}) {
 }
 
 func GenerateTest{{ .structName }}() {{ .structName }} {
-	state := StateMutable
+	state := NewState()
 	var orig []{{ .itemType }} = nil
 
-	return {{ .structName }}{&orig, &state}
+	return {{ .structName }}{&orig, state}
 }`
 
 // primitiveSliceStruct generates a struct for a slice of primitive value elements. The structs are always generated
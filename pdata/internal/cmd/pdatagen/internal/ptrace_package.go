@@ -41,14 +41,16 @@ var ptrace = &Package{
 }
 
 var resourceSpansSlice = &sliceOfPtrs{
-	structName: "ResourceSpansSlice",
-	element:    resourceSpans,
+	structName:  "ResourceSpansSlice",
+	element:     resourceSpans,
+	copyOnWrite: true,
 }
 
 var resourceSpans = &messageValueStruct{
 	structName:     "ResourceSpans",
 	description:    "// ResourceSpans is a collection of spans from a Resource.",
 	originFullName: "otlptrace.ResourceSpans",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -60,8 +62,9 @@ var resourceSpans = &messageValueStruct{
 }
 
 var scopeSpansSlice = &sliceOfPtrs{
-	structName: "ScopeSpansSlice",
-	element:    scopeSpans,
+	structName:  "ScopeSpansSlice",
+	element:     scopeSpans,
+	copyOnWrite: true,
 }
 
 var scopeSpans = &messageValueStruct{
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exphistogram // import "go.opentelemetry.io/collector/pdata/internal/exphistogram"
+
+// Buckets holds the counts of consecutive buckets of an exponential histogram, starting at the bucket of index Offset.
+type Buckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+// Downscale merges the buckets to lower the scale of the histogram by the given amount.
+func (b *Buckets) Downscale(by int32) {
+	if by <= 0 || len(b.Counts) == 0 {
+		return
+	}
+	// Each bucket is merged into a bucket of a lower or equal position, so merging them in order
+	// never overwrites a bucket not merged yet.
+	offset := b.Offset >> by
+	n := 0
+	for i, count := range b.Counts {
+		j := int((b.Offset+int32(i))>>by - offset)
+		if j != i {
+			b.Counts[j] += count
+			b.Counts[i] = 0
+		}
+		n = j + 1
+	}
+	b.Offset = offset
+	b.Counts = b.Counts[:n]
+}
+
+// Add adds the counts of the buckets at the same scale.
+func (b *Buckets) Add(o Buckets) {
+	if len(o.Counts) == 0 {
+		return
+	}
+	if len(b.Counts) == 0 {
+		b.Offset = o.Offset
+		b.Counts = append(b.Counts[:0], o.Counts...)
+		return
+	}
+	start := min(b.Offset, o.Offset)
+	end := max(b.Offset+int32(len(b.Counts)), o.Offset+int32(len(o.Counts)))
+	if start != b.Offset || int(end-start) != len(b.Counts) {
+		counts := make([]uint64, end-start)
+		copy(counts[b.Offset-start:], b.Counts)
+		b.Offset, b.Counts = start, counts
+	}
+	for i, count := range o.Counts {
+		b.Counts[o.Offset-b.Offset+int32(i)] += count
+	}
+}
+
+// Trim removes the empty buckets at both ends.
+func (b *Buckets) Trim() {
+	start, end := 0, len(b.Counts)
+	for start < end && b.Counts[start] == 0 {
+		start++
+	}
+	for end > start && b.Counts[end-1] == 0 {
+		end--
+	}
+	if start == end {
+		b.Offset, b.Counts = 0, b.Counts[:0]
+		return
+	}
+	b.Offset += int32(start)
+	b.Counts = b.Counts[start:end]
+}
+
+// ScaleReduction returns how much the scale of the buckets must be lowered for them to fit in maxSize buckets.
+func (b *Buckets) ScaleReduction(maxSize int) int32 {
+	if len(b.Counts) == 0 {
+		return 0
+	}
+	return ScaleReduction(b.Offset, b.Offset+int32(len(b.Counts))-1, maxSize)
+}
+
+// ScaleReduction returns how much the scale of the buckets from the index low to the index high
+// must be lowered for them to fit in maxSize buckets.
+func ScaleReduction(low, high int32, maxSize int) int32 {
+	by := int32(0)
+	for int64(high>>by)-int64(low>>by) >= int64(maxSize) && by < MaxScale-MinScale {
+		by++
+	}
+	return by
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exphistogram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketsDownscale(t *testing.T) {
+	b := Buckets{Offset: -3, Counts: []uint64{1, 2, 3, 4, 5, 6}}
+	b.Downscale(1)
+	assert.Equal(t, Buckets{Offset: -2, Counts: []uint64{1, 5, 9, 6}}, b)
+	b.Downscale(2)
+	assert.Equal(t, Buckets{Offset: -1, Counts: []uint64{6, 15}}, b)
+	b.Downscale(0)
+	assert.Equal(t, Buckets{Offset: -1, Counts: []uint64{6, 15}}, b)
+}
+
+func TestBucketsAdd(t *testing.T) {
+	var b Buckets
+	b.Add(Buckets{Offset: 2, Counts: []uint64{1, 2}})
+	assert.Equal(t, Buckets{Offset: 2, Counts: []uint64{1, 2}}, b)
+	b.Add(Buckets{Offset: 3, Counts: []uint64{1}})
+	assert.Equal(t, Buckets{Offset: 2, Counts: []uint64{1, 3}}, b)
+	b.Add(Buckets{Offset: 0, Counts: []uint64{1}})
+	assert.Equal(t, Buckets{Offset: 0, Counts: []uint64{1, 0, 1, 3}}, b)
+	b.Add(Buckets{Offset: 5, Counts: []uint64{1}})
+	assert.Equal(t, Buckets{Offset: 0, Counts: []uint64{1, 0, 1, 3, 0, 1}}, b)
+	b.Add(Buckets{})
+	assert.Equal(t, Buckets{Offset: 0, Counts: []uint64{1, 0, 1, 3, 0, 1}}, b)
+}
+
+func TestBucketsTrim(t *testing.T) {
+	b := Buckets{Offset: -1, Counts: []uint64{0, 0, 1, 0, 2, 0}}
+	b.Trim()
+	assert.Equal(t, Buckets{Offset: 1, Counts: []uint64{1, 0, 2}}, b)
+	b = Buckets{Offset: 3, Counts: []uint64{0, 0}}
+	b.Trim()
+	assert.Equal(t, Buckets{Offset: 0, Counts: []uint64{}}, b)
+}
+
+func TestScaleReduction(t *testing.T) {
+	b := Buckets{Offset: -3, Counts: []uint64{1, 2, 3, 4, 5, 6}}
+	assert.Equal(t, int32(0), b.ScaleReduction(6))
+	assert.Equal(t, int32(1), b.ScaleReduction(5))
+	assert.Equal(t, int32(1), b.ScaleReduction(4))
+	assert.Equal(t, int32(2), b.ScaleReduction(3))
+	assert.Equal(t, int32(2), b.ScaleReduction(2))
+	// Buckets of negative and positive indexes are never merged.
+	assert.Equal(t, MaxScale-MinScale, b.ScaleReduction(1))
+	assert.Equal(t, int32(2), (&Buckets{Offset: 0, Counts: []uint64{1, 2, 3, 4}}).ScaleReduction(1))
+	assert.Equal(t, int32(0), (&Buckets{}).ScaleReduction(1))
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exphistogram // import "go.opentelemetry.io/collector/pdata/internal/exphistogram"
+
+import (
+	"math"
+)
+
+const (
+	// MinScale is the lowest scale of an exponential histogram, its buckets hold all the float64 values in a few buckets.
+	MinScale int32 = -10
+	// MaxScale is the highest scale of an exponential histogram supported by the OpenTelemetry SDKs.
+	MaxScale int32 = 20
+)
+
+// Index returns the index of the bucket holding the positive value at the scale. The bucket of index i
+// holds the values in (base^i, base^(i+1)], where base is 2^(2^-scale).
+func Index(value float64, scale int32) int32 {
+	frac, exp := math.Frexp(value)
+	// Powers of two are the upper boundaries of their buckets.
+	powerOfTwo := frac == 0.5
+	if scale <= 0 {
+		if powerOfTwo {
+			exp--
+		}
+		return int32(exp-1) >> -scale
+	}
+	if powerOfTwo {
+		return int32(exp-1)<<scale - 1
+	}
+	// The logarithm is inexact near the boundaries of the buckets, correct it with the boundaries.
+	index := int32(math.Ceil(math.Log2(value)*math.Exp2(float64(scale)))) - 1
+	switch {
+	case value <= LowerBoundary(index, scale):
+		index--
+	case value > LowerBoundary(index+1, scale):
+		index++
+	}
+	return index
+}
+
+// LowerBoundary returns the lower boundary of the bucket of index at the scale.
+func LowerBoundary(index, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	// Split the exponent index/2^scale into its integer and fractional parts for Exp2 to stay accurate.
+	exp := index >> scale
+	frac := float64(index-exp<<scale) / float64(int64(1)<<scale)
+	return math.Ldexp(math.Exp2(frac), int(exp))
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exphistogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	tests := []struct {
+		value    float64
+		scale    int32
+		expected int32
+	}{
+		{value: 1, scale: 0, expected: -1},
+		{value: 1.5, scale: 0, expected: 0},
+		{value: 2, scale: 0, expected: 0},
+		{value: 3, scale: 0, expected: 1},
+		{value: 0.25, scale: 0, expected: -3},
+		{value: 0.3, scale: 0, expected: -2},
+		{value: 4, scale: -1, expected: 0},
+		{value: 5, scale: -1, expected: 1},
+		{value: 16, scale: -1, expected: 1},
+		{value: 17, scale: -1, expected: 2},
+		{value: 0.5, scale: -1, expected: -1},
+		{value: 2, scale: 1, expected: 1},
+		{value: 1.41, scale: 1, expected: 0},
+		{value: 1.5, scale: 1, expected: 1},
+		{value: 1.2, scale: 1, expected: 0},
+		{value: 0.9, scale: 1, expected: -1},
+		{value: 1, scale: 20, expected: -1},
+		{value: 2, scale: 20, expected: 1<<20 - 1},
+		{value: math.MaxFloat64, scale: -10, expected: 0},
+		{value: math.SmallestNonzeroFloat64, scale: -10, expected: -2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Index(tt.value, tt.scale), "value %v, scale %d", tt.value, tt.scale)
+	}
+}
+
+func TestLowerBoundary(t *testing.T) {
+	assert.Equal(t, 1.0, LowerBoundary(0, 0))
+	assert.Equal(t, 0.25, LowerBoundary(-2, 0))
+	assert.Equal(t, 16.0, LowerBoundary(2, -1))
+	assert.InDelta(t, math.Sqrt2, LowerBoundary(1, 1), 1e-12)
+	assert.InDelta(t, 2.0, LowerBoundary(1<<20, 20), 1e-12)
+	for scale := MinScale + 1; scale <= MaxScale; scale++ {
+		for _, index := range []int32{-1, 0, 1} {
+			// The lower boundary of a bucket is the upper boundary of the previous one.
+			assert.Equal(t, index-1, Index(LowerBoundary(index, scale), scale), "index %d, scale %d", index, scale)
+		}
+	}
+}
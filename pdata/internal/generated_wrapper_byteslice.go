@@ -27,8 +27,8 @@ func FillTestByteSlice(tv ByteSlice) {
 }
 
 func GenerateTestByteSlice() ByteSlice {
-	state := StateMutable
+	state := NewState()
 	var orig []byte = nil
 
-	return ByteSlice{&orig, &state}
+	return ByteSlice{&orig, state}
 }
@@ -27,8 +27,8 @@ func FillTestFloat64Slice(tv Float64Slice) {
 }
 
 func GenerateTestFloat64Slice() Float64Slice {
-	state := StateMutable
+	state := NewState()
 	var orig []float64 = nil
 
-	return Float64Slice{&orig, &state}
+	return Float64Slice{&orig, state}
 }
@@ -29,8 +29,8 @@ func NewInstrumentationScope(orig *otlpcommon.InstrumentationScope, state *State
 
 func GenerateTestInstrumentationScope() InstrumentationScope {
 	orig := otlpcommon.InstrumentationScope{}
-	state := StateMutable
-	tv := NewInstrumentationScope(&orig, &state)
+	state := NewState()
+	tv := NewInstrumentationScope(&orig, state)
 	FillTestInstrumentationScope(tv)
 	return tv
 }
@@ -27,8 +27,8 @@ func FillTestInt64Slice(tv Int64Slice) {
 }
 
 func GenerateTestInt64Slice() Int64Slice {
-	state := StateMutable
+	state := NewState()
 	var orig []int64 = nil
 
-	return Int64Slice{&orig, &state}
+	return Int64Slice{&orig, state}
 }
@@ -29,8 +29,8 @@ func NewResource(orig *otlpresource.Resource, state *State) Resource {
 
 func GenerateTestResource() Resource {
 	orig := otlpresource.Resource{}
-	state := StateMutable
-	tv := NewResource(&orig, &state)
+	state := NewState()
+	tv := NewResource(&orig, state)
 	FillTestResource(tv)
 	return tv
 }
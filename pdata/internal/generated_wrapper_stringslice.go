@@ -27,8 +27,8 @@ func FillTestStringSlice(tv StringSlice) {
 }
 
 func GenerateTestStringSlice() StringSlice {
-	state := StateMutable
+	state := NewState()
 	var orig []string = nil
 
-	return StringSlice{&orig, &state}
+	return StringSlice{&orig, state}
 }
@@ -27,8 +27,8 @@ func FillTestUInt64Slice(tv UInt64Slice) {
 }
 
 func GenerateTestUInt64Slice() UInt64Slice {
-	state := StateMutable
+	state := NewState()
 	var orig []uint64 = nil
 
-	return UInt64Slice{&orig, &state}
+	return UInt64Slice{&orig, state}
 }
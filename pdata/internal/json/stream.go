@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package json // import "go.opentelemetry.io/collector/pdata/internal/json"
+
+import (
+	"errors"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// streamBufferSize is the size of the buffer the stream iterators read into, it bounds
+// the memory used to decode a stream independently of its size.
+const streamBufferSize = 64 * 1024
+
+// NewStreamIterator returns an iterator reading the JSON values from r.
+func NewStreamIterator(r io.Reader) *jsoniter.Iterator {
+	return jsoniter.Parse(jsoniter.ConfigFastest, r, streamBufferSize)
+}
+
+// AtStreamEnd returns true if the stream read by the iterator holds no more values.
+func AtStreamEnd(iter *jsoniter.Iterator) bool {
+	return iter.WhatIsNext() == jsoniter.InvalidValue && errors.Is(iter.Error, io.EOF)
+}
+
+// StreamError returns the error the iterator met while reading a value from the stream.
+// Reaching the end of the stream right after the value is not an error, the iterator
+// reports the end of the stream in the middle of a value as a syntax error.
+func StreamError(iter *jsoniter.Iterator) error {
+	if errors.Is(iter.Error, io.EOF) {
+		return nil
+	}
+	return iter.Error
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtStreamEnd(t *testing.T) {
+	iter := NewStreamIterator(strings.NewReader(` {"a": 1} {"a": 2} `))
+	for i := 0; i < 2; i++ {
+		assert.False(t, AtStreamEnd(iter))
+		iter.Skip()
+		assert.NoError(t, StreamError(iter))
+	}
+	assert.True(t, AtStreamEnd(iter))
+}
+
+func TestStreamError(t *testing.T) {
+	iter := NewStreamIterator(strings.NewReader(`{"a": 1}`))
+	iter.Skip()
+	assert.NoError(t, StreamError(iter))
+	assert.True(t, AtStreamEnd(iter))
+	assert.NoError(t, StreamError(iter))
+
+	iter = NewStreamIterator(strings.NewReader(`{"a": 1`))
+	iter.Skip()
+	assert.Error(t, StreamError(iter))
+}
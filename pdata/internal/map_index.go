@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"sync"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+// MapIndexThreshold is the number of entries from which the lookups in a map use an index of its keys.
+// Below it, scanning the entries is as fast as hashing the key.
+const MapIndexThreshold = 32
+
+// mapIndex holds the position of each key of a map. It is only valid as long as the map has the
+// backing array and the length it was built for, which catches the changes made without Map methods.
+//
+// A lookup never modifies an index, it replaces an invalid one with a new index, so that maps can
+// be read concurrently. An index is only updated in place by the methods modifying its map.
+type mapIndex struct {
+	data *otlpcommon.KeyValue
+	len  int
+	// keys is nil if the map holds duplicate keys, in which case lookups scan its entries.
+	keys map[string]int
+}
+
+func newMapIndex(kvs []otlpcommon.KeyValue) *mapIndex {
+	idx := &mapIndex{data: &kvs[0], len: len(kvs), keys: make(map[string]int, len(kvs))}
+	for i := range kvs {
+		if _, ok := idx.keys[kvs[i].Key]; ok {
+			idx.keys = nil
+			break
+		}
+		idx.keys[kvs[i].Key] = i
+	}
+	return idx
+}
+
+// mapIndexTable returns the indexes of the maps of the data, after creating them if create is true.
+func (state *State) mapIndexTable(create bool) *sync.Map {
+	if table := state.mapIndexes.Load(); table != nil || !create {
+		return table
+	}
+	state.mapIndexes.CompareAndSwap(nil, &sync.Map{})
+	return state.mapIndexes.Load()
+}
+
+// mapIndex returns the valid index of the map, after building it if it is missing or invalid,
+// or nil if the map is too small to be indexed.
+func (state *State) mapIndex(orig *[]otlpcommon.KeyValue) *mapIndex {
+	kvs := *orig
+	if len(kvs) < MapIndexThreshold {
+		return nil
+	}
+	table := state.mapIndexTable(true)
+	if idx := state.validMapIndex(orig); idx != nil {
+		return idx
+	}
+	idx := newMapIndex(kvs)
+	table.Store(orig, idx)
+	return idx
+}
+
+// FindMapKey returns the position of the first entry of the map with the key, or -1 if the key is missing.
+func (state *State) FindMapKey(orig *[]otlpcommon.KeyValue, key string) int {
+	if idx := state.mapIndex(orig); idx != nil && idx.keys != nil {
+		if i, ok := idx.keys[key]; ok {
+			return i
+		}
+		return -1
+	}
+	for i := range *orig {
+		if (*orig)[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// AppendMapKeyValue appends the entry, whose key must be missing from the map, and keeps the index of the map up to date.
+func (state *State) AppendMapKeyValue(orig *[]otlpcommon.KeyValue, kv otlpcommon.KeyValue) {
+	idx := state.validMapIndex(orig)
+	*orig = append(*orig, kv)
+	if idx == nil {
+		return
+	}
+	if idx.keys != nil {
+		idx.keys[kv.Key] = len(*orig) - 1
+	}
+	idx.data = &(*orig)[0]
+	idx.len = len(*orig)
+}
+
+// RemoveMapKeyValue removes the entry at position i by moving the last entry in its place,
+// and keeps the index of the map up to date.
+func (state *State) RemoveMapKeyValue(orig *[]otlpcommon.KeyValue, i int) {
+	idx := state.validMapIndex(orig)
+	kvs := *orig
+	key := kvs[i].Key
+	last := len(kvs) - 1
+	kvs[i] = kvs[last]
+	*orig = kvs[:last]
+	if idx == nil {
+		return
+	}
+	if idx.keys == nil || last < MapIndexThreshold {
+		state.ResetMapIndex(orig)
+		return
+	}
+	delete(idx.keys, key)
+	if i < last {
+		idx.keys[kvs[i].Key] = i
+	}
+	idx.len = last
+}
+
+// ResetMapIndex forgets the index of the map, it must be called when the keys of the map are
+// changed in place, without changing its backing array or its length.
+func (state *State) ResetMapIndex(orig *[]otlpcommon.KeyValue) {
+	if table := state.mapIndexTable(false); table != nil {
+		table.Delete(orig)
+	}
+}
+
+// validMapIndex returns the index of the map if it is still valid, without building it.
+func (state *State) validMapIndex(orig *[]otlpcommon.KeyValue) *mapIndex {
+	table := state.mapIndexTable(false)
+	if table == nil {
+		return nil
+	}
+	v, ok := table.Load(orig)
+	if !ok {
+		return nil
+	}
+	idx := v.(*mapIndex)
+	if kvs := *orig; len(kvs) == 0 || idx.data != &kvs[0] || idx.len != len(kvs) {
+		return nil
+	}
+	return idx
+}
+
+// ResetMapIndexes forgets the indexes of all the maps of the data. It must be called when maps of the data
+// may be discarded, or moved to other data, so that their indexes do not keep them in memory.
+func (state *State) ResetMapIndexes() {
+	if state.mapIndexes.Load() != nil {
+		state.mapIndexes.Store(nil)
+	}
+}
+
+// ResetMapIndexesOfValue forgets the indexes of all the maps of the data if the value holds maps,
+// it must be called before the value is discarded.
+func (state *State) ResetMapIndexesOfValue(v *otlpcommon.AnyValue) {
+	if state.mapIndexes.Load() == nil {
+		return
+	}
+	switch v.Value.(type) {
+	case *otlpcommon.AnyValue_KvlistValue, *otlpcommon.AnyValue_ArrayValue:
+		state.mapIndexes.Store(nil)
+	}
+}
+
+// ResetMapIndexesOfValues forgets the indexes of all the maps of the data if one of the values
+// holds maps, it must be called before the values are discarded.
+func (state *State) ResetMapIndexesOfValues(vs []otlpcommon.AnyValue) {
+	for i := 0; i < len(vs) && state.mapIndexes.Load() != nil; i++ {
+		state.ResetMapIndexesOfValue(&vs[i])
+	}
+}
+
+// ResetMapIndexesOfKeyValues forgets the indexes of all the maps of the data if one of the values
+// of the entries holds maps, it must be called before the entries are discarded.
+func (state *State) ResetMapIndexesOfKeyValues(kvs []otlpcommon.KeyValue) {
+	for i := 0; i < len(kvs) && state.mapIndexes.Load() != nil; i++ {
+		state.ResetMapIndexesOfValue(&kvs[i].Value)
+	}
+}
+
+// HasMapIndexes returns true if the data holds indexes of its maps.
+func (state *State) HasMapIndexes() bool {
+	return state.mapIndexes.Load() != nil
+}
@@ -3,20 +3,142 @@
 
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 // State defines an ownership state of pmetric.Metrics, plog.Logs or ptrace.Traces.
-type State int32
+// It must be created with NewState or NewReadOnlyState, and must not be copied.
+type State struct {
+	readOnly bool
+	// mu guards the shared nodes, and the elements of the data holding them: accessors replace the
+	// shared nodes by their clones, which must not race with the concurrent reads of the data.
+	mu sync.RWMutex
+	// hasShared is true as long as shared is not empty, so that the data which does not share nodes
+	// is accessed without locking mu.
+	hasShared atomic.Bool
+	// shared holds the nodes of the data which are still shared with other data,
+	// they must be cloned before being accessed for modification.
+	shared map[any]struct{}
+	// mapIndexes holds the indexes of the keys of the big maps of the data, by map.
+	// It is created by the first lookup, and is safe for concurrent use, so that different maps
+	// of the data can be accessed concurrently, as when they were not indexed. It is dropped when
+	// maps of the data are discarded, so that their indexes do not keep them in memory.
+	mapIndexes atomic.Pointer[sync.Map]
+}
 
-const (
-	// StateMutable indicates that the data is exclusive to the current consumer.
-	StateMutable State = iota
+// NewState returns the state of new data, which is exclusive to the current consumer.
+func NewState() *State {
+	return &State{}
+}
 
-	// StateReadOnly indicates that the data is shared with other consumers.
-	StateReadOnly
-)
+// NewReadOnlyState returns the state of data shared with other consumers.
+func NewReadOnlyState() *State {
+	return &State{readOnly: true}
+}
 
-// AssertMutable panics if the state is not StateMutable.
+// AssertMutable panics if the data is shared with other consumers.
 func (state *State) AssertMutable() {
-	if *state != StateMutable {
+	if state.readOnly {
 		panic("invalid access to shared data")
 	}
 }
+
+// IsReadOnly returns true if the data is shared with other consumers.
+func (state *State) IsReadOnly() bool {
+	return state.readOnly
+}
+
+// MarkReadOnly marks the data as shared with other consumers.
+func (state *State) MarkReadOnly() {
+	state.readOnly = true
+}
+
+// MarkShared records that the node, a pointer to an element of the data, is shared
+// with other data and must be cloned before being accessed. It must only be called
+// with exclusive access to the data, or by the clone function of LoadNode.
+func (state *State) MarkShared(node any) {
+	if state.shared == nil {
+		state.shared = make(map[any]struct{})
+	}
+	state.shared[node] = struct{}{}
+	state.hasShared.Store(true)
+}
+
+// Unshare returns true if the node is shared with other data, in which case the
+// caller must replace it with a clone, and forgets about the node.
+// Read-only data is never modified, so its shared nodes are never cloned.
+func (state *State) Unshare(node any) bool {
+	if state.readOnly || !state.hasShared.Load() {
+		return false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.unshare(node)
+}
+
+func (state *State) unshare(node any) bool {
+	if _, ok := state.shared[node]; !ok {
+		return false
+	}
+	delete(state.shared, node)
+	if len(state.shared) == 0 {
+		// Let the state be equal to the one of data which never shared nodes.
+		state.shared = nil
+		state.hasShared.Store(false)
+	}
+	return true
+}
+
+// InheritShared records the nodes shared in src as shared in the state, it must
+// be called when nodes are moved from the data of src to the data of the state.
+func (state *State) InheritShared(src *State) {
+	if state == src {
+		return
+	}
+	for node := range src.shared {
+		state.MarkShared(node)
+	}
+}
+
+// RLock prevents the shared nodes of the data from being replaced by their clones until RUnlock
+// is called, so that the elements of the data can be read directly, without accessors, while
+// other goroutines read the data with accessors. Accessors must not be called before RUnlock.
+func (state *State) RLock() {
+	state.mu.RLock()
+}
+
+// RUnlock undoes a single RLock call.
+func (state *State) RUnlock() {
+	state.mu.RUnlock()
+}
+
+// LoadNode returns the node held by the element of the data, after replacing it with a clone
+// returned by clone if it is shared with other data, as the returned node may be modified.
+// It is safe to call concurrently with the reads of the data.
+func LoadNode[T any](state *State, elem **T, clone func(*T, *State) *T) *T {
+	if state.readOnly || !state.hasShared.Load() {
+		return *elem
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if _, ok := state.shared[*elem]; ok {
+		node := *elem
+		*elem = clone(node, state)
+		// Forget the node once replaced, as readers do not lock once the data shares no more nodes.
+		state.unshare(node)
+	}
+	return *elem
+}
+
+// ReadNode returns the node held by the element of the data, without cloning it if it is
+// shared with other data, so it must not be modified. It is safe to call concurrently with LoadNode.
+func ReadNode[T any](state *State, elem **T) *T {
+	if !state.hasShared.Load() {
+		return *elem
+	}
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return *elem
+}
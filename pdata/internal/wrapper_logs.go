@@ -21,10 +21,6 @@ func GetLogsState(ms Logs) *State {
 	return ms.state
 }
 
-func SetLogsState(ms Logs, state State) {
-	*ms.state = state
-}
-
 func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State) Logs {
 	return Logs{orig: orig, state: state}
 }
@@ -39,8 +35,8 @@ func LogsToProto(l Logs) otlplogs.LogsData {
 // LogsFromProto internal helper to convert protobuf representation to Logs.
 // This function set exclusive state assuming that it's called only once per Logs.
 func LogsFromProto(orig otlplogs.LogsData) Logs {
-	state := StateMutable
+	state := NewState()
 	return NewLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: orig.ResourceLogs,
-	}, &state)
+	}, state)
 }
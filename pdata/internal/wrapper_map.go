@@ -26,8 +26,8 @@ func NewMap(orig *[]otlpcommon.KeyValue, state *State) Map {
 
 func GenerateTestMap() Map {
 	var orig []otlpcommon.KeyValue
-	state := StateMutable
-	ms := NewMap(&orig, &state)
+	state := NewState()
+	ms := NewMap(&orig, state)
 	FillTestMap(ms)
 	return ms
 }
@@ -21,10 +21,6 @@ func GetMetricsState(ms Metrics) *State {
 	return ms.state
 }
 
-func SetMetricsState(ms Metrics, state State) {
-	*ms.state = state
-}
-
 func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State) Metrics {
 	return Metrics{orig: orig, state: state}
 }
@@ -39,8 +35,8 @@ func MetricsToProto(l Metrics) otlpmetrics.MetricsData {
 // MetricsFromProto internal helper to convert protobuf representation to Metrics.
 // This function set exclusive state assuming that it's called only once per Metrics.
 func MetricsFromProto(orig otlpmetrics.MetricsData) Metrics {
-	state := StateMutable
+	state := NewState()
 	return NewMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: orig.ResourceMetrics,
-	}, &state)
+	}, state)
 }
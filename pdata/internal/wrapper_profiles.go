@@ -21,10 +21,6 @@ func GetProfilesState(ms Profiles) *State {
 	return ms.state
 }
 
-func SetProfilesState(ms Profiles, state State) {
-	*ms.state = state
-}
-
 func NewProfiles(orig *otlpcollectorprofile.ExportProfilesServiceRequest, state *State) Profiles {
 	return Profiles{orig: orig, state: state}
 }
@@ -39,8 +35,8 @@ func ProfilesToProto(l Profiles) otlpprofile.ProfilesData {
 // ProfilesFromProto internal helper to convert protobuf representation to Profiles.
 // This function set exclusive state assuming that it's called only once per Profiles.
 func ProfilesFromProto(orig otlpprofile.ProfilesData) Profiles {
-	state := StateMutable
+	state := NewState()
 	return NewProfiles(&otlpcollectorprofile.ExportProfilesServiceRequest{
 		ResourceProfiles: orig.ResourceProfiles,
-	}, &state)
+	}, state)
 }
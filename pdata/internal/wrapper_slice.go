@@ -26,8 +26,8 @@ func NewSlice(orig *[]otlpcommon.AnyValue, state *State) Slice {
 
 func GenerateTestSlice() Slice {
 	orig := []otlpcommon.AnyValue{}
-	state := StateMutable
-	tv := NewSlice(&orig, &state)
+	state := NewState()
+	tv := NewSlice(&orig, state)
 	FillTestSlice(tv)
 	return tv
 }
@@ -35,7 +35,7 @@ func GenerateTestSlice() Slice {
 func FillTestSlice(tv Slice) {
 	*tv.orig = make([]otlpcommon.AnyValue, 7)
 	for i := 0; i < 7; i++ {
-		state := StateMutable
-		FillTestValue(NewValue(&(*tv.orig)[i], &state))
+		state := NewState()
+		FillTestValue(NewValue(&(*tv.orig)[i], state))
 	}
 }
@@ -21,10 +21,6 @@ func GetTracesState(ms Traces) *State {
 	return ms.state
 }
 
-func SetTracesState(ms Traces, state State) {
-	*ms.state = state
-}
-
 func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State) Traces {
 	return Traces{orig: orig, state: state}
 }
@@ -39,8 +35,8 @@ func TracesToProto(l Traces) otlptrace.TracesData {
 // TracesFromProto internal helper to convert protobuf representation to Traces.
 // This function set exclusive state assuming that it's called only once per Traces.
 func TracesFromProto(orig otlptrace.TracesData) Traces {
-	state := StateMutable
+	state := NewState()
 	return NewTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: orig.ResourceSpans,
-	}, &state)
+	}, state)
 }
@@ -22,8 +22,8 @@ func NewTraceState(orig *string, state *State) TraceState {
 
 func GenerateTestTraceState() TraceState {
 	var orig string
-	state := StateMutable
-	ms := NewTraceState(&orig, &state)
+	state := NewState()
+	ms := NewTraceState(&orig, state)
 	FillTestTraceState(ms)
 	return ms
 }
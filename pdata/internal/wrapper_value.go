@@ -30,8 +30,8 @@ func FillTestValue(dest Value) {
 
 func GenerateTestValue() Value {
 	var orig otlpcommon.AnyValue
-	state := StateMutable
-	ms := NewValue(&orig, &state)
+	state := NewState()
+	ms := NewValue(&orig, state)
 	FillTestValue(ms)
 	return ms
 }
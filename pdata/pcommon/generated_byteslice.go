@@ -28,8 +28,8 @@ func (ms ByteSlice) getState() *internal.State {
 // NewByteSlice creates a new empty ByteSlice.
 func NewByteSlice() ByteSlice {
 	orig := []byte(nil)
-	state := internal.StateMutable
-	return ByteSlice(internal.NewByteSlice(&orig, &state))
+	state := internal.NewState()
+	return ByteSlice(internal.NewByteSlice(&orig, state))
 }
 
 // AsRaw returns a copy of the []byte slice.
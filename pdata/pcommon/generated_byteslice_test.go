@@ -47,8 +47,8 @@ func TestNewByteSlice(t *testing.T) {
 
 func TestByteSliceReadOnly(t *testing.T) {
 	raw := []byte{1, 2, 3}
-	state := internal.StateReadOnly
-	ms := ByteSlice(internal.NewByteSlice(&raw, &state))
+	state := internal.NewReadOnlyState()
+	ms := ByteSlice(internal.NewByteSlice(&raw, state))
 
 	assert.Equal(t, 3, ms.Len())
 	assert.Equal(t, byte(1), ms.At(0))
@@ -28,8 +28,8 @@ func (ms Float64Slice) getState() *internal.State {
 // NewFloat64Slice creates a new empty Float64Slice.
 func NewFloat64Slice() Float64Slice {
 	orig := []float64(nil)
-	state := internal.StateMutable
-	return Float64Slice(internal.NewFloat64Slice(&orig, &state))
+	state := internal.NewState()
+	return Float64Slice(internal.NewFloat64Slice(&orig, state))
 }
 
 // AsRaw returns a copy of the []float64 slice.
@@ -47,8 +47,8 @@ func TestNewFloat64Slice(t *testing.T) {
 
 func TestFloat64SliceReadOnly(t *testing.T) {
 	raw := []float64{1, 2, 3}
-	state := internal.StateReadOnly
-	ms := Float64Slice(internal.NewFloat64Slice(&raw, &state))
+	state := internal.NewReadOnlyState()
+	ms := Float64Slice(internal.NewFloat64Slice(&raw, state))
 
 	assert.Equal(t, 3, ms.Len())
 	assert.Equal(t, float64(1), ms.At(0))
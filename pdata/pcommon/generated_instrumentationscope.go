@@ -29,8 +29,8 @@ func newInstrumentationScope(orig *otlpcommon.InstrumentationScope, state *inter
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewInstrumentationScope() InstrumentationScope {
-	state := internal.StateMutable
-	return newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &state)
+	state := internal.NewState()
+	return newInstrumentationScope(&otlpcommon.InstrumentationScope{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -40,6 +40,9 @@ func (ms InstrumentationScope) MoveTo(dest InstrumentationScope) {
 	dest.getState().AssertMutable()
 	*dest.getOrig() = *ms.getOrig()
 	*ms.getOrig() = otlpcommon.InstrumentationScope{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.getState().ResetMapIndexes()
+	ms.getState().ResetMapIndexes()
 }
 
 func (ms InstrumentationScope) getOrig() *otlpcommon.InstrumentationScope {
@@ -29,8 +29,8 @@ func newResource(orig *otlpresource.Resource, state *internal.State) Resource {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResource() Resource {
-	state := internal.StateMutable
-	return newResource(&otlpresource.Resource{}, &state)
+	state := internal.NewState()
+	return newResource(&otlpresource.Resource{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -40,6 +40,9 @@ func (ms Resource) MoveTo(dest Resource) {
 	dest.getState().AssertMutable()
 	*dest.getOrig() = *ms.getOrig()
 	*ms.getOrig() = otlpresource.Resource{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.getState().ResetMapIndexes()
+	ms.getState().ResetMapIndexes()
 }
 
 func (ms Resource) getOrig() *otlpresource.Resource {
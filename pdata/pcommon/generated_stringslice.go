@@ -28,8 +28,8 @@ func (ms StringSlice) getState() *internal.State {
 // NewStringSlice creates a new empty StringSlice.
 func NewStringSlice() StringSlice {
 	orig := []string(nil)
-	state := internal.StateMutable
-	return StringSlice(internal.NewStringSlice(&orig, &state))
+	state := internal.NewState()
+	return StringSlice(internal.NewStringSlice(&orig, state))
 }
 
 // AsRaw returns a copy of the []string slice.
@@ -47,8 +47,8 @@ func TestNewStringSlice(t *testing.T) {
 
 func TestStringSliceReadOnly(t *testing.T) {
 	raw := []string{"a", "b", "c"}
-	state := internal.StateReadOnly
-	ms := StringSlice(internal.NewStringSlice(&raw, &state))
+	state := internal.NewReadOnlyState()
+	ms := StringSlice(internal.NewStringSlice(&raw, state))
 
 	assert.Equal(t, 3, ms.Len())
 	assert.Equal(t, string("a"), ms.At(0))
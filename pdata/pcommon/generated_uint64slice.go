@@ -28,8 +28,8 @@ func (ms UInt64Slice) getState() *internal.State {
 // NewUInt64Slice creates a new empty UInt64Slice.
 func NewUInt64Slice() UInt64Slice {
 	orig := []uint64(nil)
-	state := internal.StateMutable
-	return UInt64Slice(internal.NewUInt64Slice(&orig, &state))
+	state := internal.NewState()
+	return UInt64Slice(internal.NewUInt64Slice(&orig, state))
 }
 
 // AsRaw returns a copy of the []uint64 slice.
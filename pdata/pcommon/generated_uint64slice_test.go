@@ -47,8 +47,8 @@ func TestNewUInt64Slice(t *testing.T) {
 
 func TestUInt64SliceReadOnly(t *testing.T) {
 	raw := []uint64{1, 2, 3}
-	state := internal.StateReadOnly
-	ms := UInt64Slice(internal.NewUInt64Slice(&raw, &state))
+	state := internal.NewReadOnlyState()
+	ms := UInt64Slice(internal.NewUInt64Slice(&raw, state))
 
 	assert.Equal(t, 3, ms.Len())
 	assert.Equal(t, uint64(1), ms.At(0))
@@ -12,6 +12,11 @@ import (
 
 // Map stores a map of string keys to elements of Value type.
 //
+// The lookups in a map with many entries build a hash index of its keys, which is kept up to date
+// by the methods of Map, so that Get, Remove and the Put methods do not scan all the entries.
+// The index is not part of the data, which is encoded as a list of entries. As without index,
+// a map can be read concurrently, and different maps of the same data can be modified concurrently.
+//
 // Must use NewMap function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type Map internal.Map
@@ -19,8 +24,8 @@ type Map internal.Map
 // NewMap creates a Map with 0 elements.
 func NewMap() Map {
 	orig := []otlpcommon.KeyValue(nil)
-	state := internal.StateMutable
-	return Map(internal.NewMap(&orig, &state))
+	state := internal.NewState()
+	return Map(internal.NewMap(&orig, state))
 }
 
 func (m Map) getOrig() *[]otlpcommon.KeyValue {
@@ -38,6 +43,8 @@ func newMap(orig *[]otlpcommon.KeyValue, state *internal.State) Map {
 // Clear erases any existing entries in this Map instance.
 func (m Map) Clear() {
 	m.getState().AssertMutable()
+	m.getState().ResetMapIndex(m.getOrig())
+	m.getState().ResetMapIndexesOfKeyValues(*m.getOrig())
 	*m.getOrig() = nil
 }
 
@@ -49,6 +56,7 @@ func (m Map) EnsureCapacity(capacity int) {
 	if capacity <= cap(oldOrig) {
 		return
 	}
+	m.getState().ResetMapIndex(m.getOrig())
 	*m.getOrig() = make([]otlpcommon.KeyValue, len(oldOrig), capacity)
 	copy(*m.getOrig(), oldOrig)
 }
@@ -61,11 +69,8 @@ func (m Map) EnsureCapacity(capacity int) {
 // If the key does not exist returns a zero-initialized KeyValue and false.
 // Calling any functions on the returned invalid instance may cause a panic.
 func (m Map) Get(key string) (Value, bool) {
-	for i := range *m.getOrig() {
-		akv := &(*m.getOrig())[i]
-		if akv.Key == key {
-			return newValue(&akv.Value, m.getState()), true
-		}
+	if i := m.getState().FindMapKey(m.getOrig(), key); i >= 0 {
+		return newValue(&(*m.getOrig())[i].Value, m.getState()), true
 	}
 	return newValue(nil, m.getState()), false
 }
@@ -74,15 +79,13 @@ func (m Map) Get(key string) (Value, bool) {
 // was present in the map, otherwise returns false.
 func (m Map) Remove(key string) bool {
 	m.getState().AssertMutable()
-	for i := range *m.getOrig() {
-		akv := &(*m.getOrig())[i]
-		if akv.Key == key {
-			*akv = (*m.getOrig())[len(*m.getOrig())-1]
-			*m.getOrig() = (*m.getOrig())[:len(*m.getOrig())-1]
-			return true
-		}
+	i := m.getState().FindMapKey(m.getOrig(), key)
+	if i < 0 {
+		return false
 	}
-	return false
+	m.getState().ResetMapIndexesOfValue(&(*m.getOrig())[i].Value)
+	m.getState().RemoveMapKeyValue(m.getOrig(), i)
+	return true
 }
 
 // RemoveIf removes the entries for which the function in question returns true
@@ -92,6 +95,7 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 	for i := 0; i < len(*m.getOrig()); i++ {
 		akv := &(*m.getOrig())[i]
 		if f(akv.Key, newValue(&akv.Value, m.getState())) {
+			m.getState().ResetMapIndexesOfValue(&akv.Value)
 			continue
 		}
 		if newLen == i {
@@ -102,6 +106,7 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 		(*m.getOrig())[newLen] = (*m.getOrig())[i]
 		newLen++
 	}
+	m.getState().ResetMapIndex(m.getOrig())
 	*m.getOrig() = (*m.getOrig())[:newLen]
 }
 
@@ -110,10 +115,11 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 func (m Map) PutEmpty(k string) Value {
 	m.getState().AssertMutable()
 	if av, existing := m.Get(k); existing {
+		m.getState().ResetMapIndexesOfValue(av.getOrig())
 		av.getOrig().Value = nil
 		return newValue(av.getOrig(), m.getState())
 	}
-	*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k})
+	m.getState().AppendMapKeyValue(m.getOrig(), otlpcommon.KeyValue{Key: k})
 	return newValue(&(*m.getOrig())[len(*m.getOrig())-1].Value, m.getState())
 }
 
@@ -125,7 +131,7 @@ func (m Map) PutStr(k string, v string) {
 	if av, existing := m.Get(k); existing {
 		av.SetStr(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueString(k, v))
+		m.getState().AppendMapKeyValue(m.getOrig(), newKeyValueString(k, v))
 	}
 }
 
@@ -137,7 +143,7 @@ func (m Map) PutInt(k string, v int64) {
 	if av, existing := m.Get(k); existing {
 		av.SetInt(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueInt(k, v))
+		m.getState().AppendMapKeyValue(m.getOrig(), newKeyValueInt(k, v))
 	}
 }
 
@@ -149,7 +155,7 @@ func (m Map) PutDouble(k string, v float64) {
 	if av, existing := m.Get(k); existing {
 		av.SetDouble(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueDouble(k, v))
+		m.getState().AppendMapKeyValue(m.getOrig(), newKeyValueDouble(k, v))
 	}
 }
 
@@ -161,7 +167,7 @@ func (m Map) PutBool(k string, v bool) {
 	if av, existing := m.Get(k); existing {
 		av.SetBool(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueBool(k, v))
+		m.getState().AppendMapKeyValue(m.getOrig(), newKeyValueBool(k, v))
 	}
 }
 
@@ -170,9 +176,10 @@ func (m Map) PutEmptyBytes(k string) ByteSlice {
 	m.getState().AssertMutable()
 	bv := otlpcommon.AnyValue_BytesValue{}
 	if av, existing := m.Get(k); existing {
+		m.getState().ResetMapIndexesOfValue(av.getOrig())
 		av.getOrig().Value = &bv
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &bv}})
+		m.getState().AppendMapKeyValue(m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &bv}})
 	}
 	return ByteSlice(internal.NewByteSlice(&bv.BytesValue, m.getState()))
 }
@@ -182,9 +189,10 @@ func (m Map) PutEmptyMap(k string) Map {
 	m.getState().AssertMutable()
 	kvl := otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{Values: []otlpcommon.KeyValue(nil)}}
 	if av, existing := m.Get(k); existing {
+		m.getState().ResetMapIndexesOfValue(av.getOrig())
 		av.getOrig().Value = &kvl
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &kvl}})
+		m.getState().AppendMapKeyValue(m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &kvl}})
 	}
 	return Map(internal.NewMap(&kvl.KvlistValue.Values, m.getState()))
 }
@@ -194,9 +202,10 @@ func (m Map) PutEmptySlice(k string) Slice {
 	m.getState().AssertMutable()
 	vl := otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{Values: []otlpcommon.AnyValue(nil)}}
 	if av, existing := m.Get(k); existing {
+		m.getState().ResetMapIndexesOfValue(av.getOrig())
 		av.getOrig().Value = &vl
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &vl}})
+		m.getState().AppendMapKeyValue(m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &vl}})
 	}
 	return Slice(internal.NewSlice(&vl.ArrayValue.Values, m.getState()))
 }
@@ -228,6 +237,8 @@ func (m Map) Range(f func(k string, v Value) bool) {
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
 	dest.getState().AssertMutable()
+	dest.getState().ResetMapIndex(dest.getOrig())
+	dest.getState().ResetMapIndexesOfKeyValues(*dest.getOrig())
 	newLen := len(*m.getOrig())
 	oldCap := cap(*dest.getOrig())
 	if newLen <= oldCap {
@@ -265,6 +276,8 @@ func (m Map) AsRaw() map[string]any {
 // FromRaw overrides this Map instance from a standard go map.
 func (m Map) FromRaw(rawMap map[string]any) error {
 	m.getState().AssertMutable()
+	m.getState().ResetMapIndex(m.getOrig())
+	m.getState().ResetMapIndexesOfKeyValues(*m.getOrig())
 	if len(rawMap) == 0 {
 		*m.getOrig() = nil
 		return nil
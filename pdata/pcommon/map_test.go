@@ -4,6 +4,8 @@
 package pcommon
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,8 +19,8 @@ func TestMap(t *testing.T) {
 
 	val, exist := NewMap().Get("test_key")
 	assert.False(t, exist)
-	state := internal.StateMutable
-	assert.EqualValues(t, newValue(nil, &state), val)
+	state := internal.NewState()
+	assert.EqualValues(t, newValue(nil, state), val)
 
 	putString := NewMap()
 	putString.PutStr("k", "v")
@@ -54,10 +56,10 @@ func TestMap(t *testing.T) {
 }
 
 func TestMapReadOnly(t *testing.T) {
-	state := internal.StateReadOnly
+	state := internal.NewReadOnlyState()
 	m := newMap(&[]otlpcommon.KeyValue{
 		{Key: "k1", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "v1"}}},
-	}, &state)
+	}, state)
 
 	assert.Equal(t, 1, m.Len())
 
@@ -187,8 +189,8 @@ func TestMapWithEmpty(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	state := internal.StateMutable
-	sm := newMap(&origWithNil, &state)
+	state := internal.NewState()
+	sm := newMap(&origWithNil, state)
 	val, exist := sm.Get("test_key")
 	assert.True(t, exist)
 	assert.EqualValues(t, ValueTypeStr, val.Type())
@@ -484,6 +486,170 @@ func TestMap_RemoveIf(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestMap_Index(t *testing.T) {
+	am := NewMap()
+	raw := map[string]any{}
+	for i := 0; i < 2*internal.MapIndexThreshold; i++ {
+		k := "k" + strconv.Itoa(i)
+		am.PutInt(k, int64(i))
+		raw[k] = int64(i)
+	}
+	for i := 0; i < 2*internal.MapIndexThreshold; i += 3 {
+		k := "k" + strconv.Itoa(i)
+		assert.True(t, am.Remove(k))
+		assert.False(t, am.Remove(k))
+		delete(raw, k)
+	}
+	am.PutStr("k1", "updated")
+	raw["k1"] = "updated"
+	am.PutEmpty("new")
+	raw["new"] = nil
+	assert.Equal(t, raw, am.AsRaw())
+	for k, v := range raw {
+		got, ok := am.Get(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, v, got.AsRaw(), k)
+	}
+	_, ok := am.Get("k0")
+	assert.False(t, ok)
+
+	// The keys of the destination are overwritten in place.
+	dest := NewMap()
+	NewMap().CopyTo(dest)
+	dest.EnsureCapacity(am.Len())
+	for i := 0; i < am.Len(); i++ {
+		dest.PutBool("old"+strconv.Itoa(i), true)
+	}
+	_, ok = dest.Get("old0")
+	assert.True(t, ok)
+	am.CopyTo(dest)
+	assert.Equal(t, raw, dest.AsRaw())
+	_, ok = dest.Get("old0")
+	assert.False(t, ok)
+	got, ok := dest.Get("k1")
+	assert.True(t, ok)
+	assert.Equal(t, "updated", got.Str())
+
+	am.RemoveIf(func(k string, _ Value) bool { return k != "new" && k != "k1" })
+	assert.Equal(t, map[string]any{"k1": "updated", "new": nil}, am.AsRaw())
+	am.Clear()
+	_, ok = am.Get("k1")
+	assert.False(t, ok)
+}
+
+func TestMap_IndexDuplicateKeys(t *testing.T) {
+	orig := make([]otlpcommon.KeyValue, internal.MapIndexThreshold)
+	for i := range orig {
+		orig[i].Key = "k" + strconv.Itoa(i)
+	}
+	orig[len(orig)-1].Key = "k0"
+	orig[len(orig)-1].Value.Value = &otlpcommon.AnyValue_IntValue{IntValue: 1}
+	am := newMap(&orig, &internal.State{})
+
+	// The first entry with the key is found, as without index.
+	v, ok := am.Get("k0")
+	assert.True(t, ok)
+	assert.Equal(t, ValueTypeEmpty, v.Type())
+	assert.True(t, am.Remove("k0"))
+	v, ok = am.Get("k0")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), v.Int())
+}
+
+func TestMap_IndexInvalidated(t *testing.T) {
+	orig := make([]otlpcommon.KeyValue, internal.MapIndexThreshold)
+	for i := range orig {
+		orig[i].Key = "k" + strconv.Itoa(i)
+	}
+	am := newMap(&orig, &internal.State{})
+	_, ok := am.Get("k1")
+	assert.True(t, ok)
+
+	// Changes made without Map methods invalidate the index.
+	orig = append(orig, otlpcommon.KeyValue{Key: "new"})
+	_, ok = am.Get("new")
+	assert.True(t, ok)
+}
+
+func TestMap_IndexDroppedWithMap(t *testing.T) {
+	state := internal.NewState()
+	am := newMap(&[]otlpcommon.KeyValue{}, state)
+	nested := am.PutEmptyMap("nested")
+	for i := 0; i < internal.MapIndexThreshold; i++ {
+		nested.PutStr("k"+strconv.Itoa(i), "v")
+	}
+	_, ok := nested.Get("k1")
+	assert.True(t, ok)
+	assert.True(t, state.HasMapIndexes())
+
+	// The primitive values do not hold maps.
+	am.PutStr("str", "v")
+	am.Remove("str")
+	assert.True(t, state.HasMapIndexes())
+
+	// The indexes of the removed map must not keep it in memory.
+	am.Remove("nested")
+	assert.False(t, state.HasMapIndexes())
+}
+
+// TestMap_IndexConcurrent checks, when run with -race, that the maps of the same data can be read
+// concurrently, and written concurrently with the reads of other maps.
+func TestMap_IndexConcurrent(t *testing.T) {
+	state := internal.NewState()
+	maps := make([]Map, 8)
+	for i := range maps {
+		var orig []otlpcommon.KeyValue
+		maps[i] = newMap(&orig, state)
+		for j := 0; j < 2*internal.MapIndexThreshold; j++ {
+			maps[i].PutInt("k"+strconv.Itoa(j), int64(j))
+		}
+		// Invalidate the indexes built by the puts, so that the reads build them.
+		maps[i].RemoveIf(func(string, Value) bool { return false })
+	}
+
+	var wg sync.WaitGroup
+	for i := range maps[1:] {
+		for r := 0; r < 2; r++ {
+			wg.Add(1)
+			go func(m Map) {
+				defer wg.Done()
+				for j := 0; j < 2*internal.MapIndexThreshold; j++ {
+					v, ok := m.Get("k" + strconv.Itoa(j))
+					assert.True(t, ok)
+					assert.Equal(t, int64(j), v.Int())
+				}
+			}(maps[i+1])
+		}
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 2*internal.MapIndexThreshold; j++ {
+			maps[0].PutStr("new"+strconv.Itoa(j), "v")
+			assert.True(t, maps[0].Remove("k"+strconv.Itoa(j)))
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, 2*internal.MapIndexThreshold, maps[0].Len())
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	for _, size := range []int{8, 16, 32, 256} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			am := NewMap()
+			for i := 0; i < size; i++ {
+				am.PutStr("key"+strconv.Itoa(i), "value")
+			}
+			key := "key" + strconv.Itoa(size-1)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				am.Get(key)
+			}
+		})
+	}
+}
+
 func generateTestEmptyMap(t *testing.T) Map {
 	m := NewMap()
 	assert.NoError(t, m.FromRaw(map[string]any{"k": map[string]any(nil)}))
@@ -35,8 +35,8 @@ func (es Slice) getState() *internal.State {
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSlice() Slice {
 	orig := []otlpcommon.AnyValue(nil)
-	state := internal.StateMutable
-	return Slice(internal.NewSlice(&orig, &state))
+	state := internal.NewState()
+	return Slice(internal.NewSlice(&orig, state))
 }
 
 // Len returns the number of elements in the slice.
@@ -61,6 +61,7 @@ func (es Slice) At(ix int) Value {
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es Slice) CopyTo(dest Slice) {
 	dest.getState().AssertMutable()
+	dest.getState().ResetMapIndexesOfValues(*dest.getOrig())
 	srcLen := es.Len()
 	destCap := cap(*dest.getOrig())
 	if srcLen <= destCap {
@@ -117,6 +118,8 @@ func (es Slice) MoveAndAppendTo(dest Slice) {
 	} else {
 		*dest.getOrig() = append(*dest.getOrig(), *es.getOrig()...)
 	}
+	// The maps of the values are moved.
+	es.getState().ResetMapIndexesOfValues(*es.getOrig())
 	*es.getOrig() = nil
 }
 
@@ -127,6 +130,7 @@ func (es Slice) RemoveIf(f func(Value) bool) {
 	newLen := 0
 	for i := 0; i < len(*es.getOrig()); i++ {
 		if f(es.At(i)) {
+			es.getState().ResetMapIndexesOfValue(&(*es.getOrig())[i])
 			continue
 		}
 		if newLen == i {
@@ -152,6 +156,7 @@ func (es Slice) AsRaw() []any {
 // FromRaw copies []any into the Slice.
 func (es Slice) FromRaw(rawSlice []any) error {
 	es.getState().AssertMutable()
+	es.getState().ResetMapIndexesOfValues(*es.getOrig())
 	if len(rawSlice) == 0 {
 		*es.getOrig() = nil
 		return nil
@@ -15,12 +15,12 @@ import (
 func TestSlice(t *testing.T) {
 	es := NewSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSlice(&[]otlpcommon.AnyValue{}, &state)
+	state := internal.NewState()
+	es = newSlice(&[]otlpcommon.AnyValue{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newValue(&otlpcommon.AnyValue{}, &state)
+	emptyVal := newValue(&otlpcommon.AnyValue{}, state)
 	testVal := Value(internal.GenerateTestValue())
 	assert.Equal(t, 7, cap(*es.getOrig()))
 	for i := 0; i < es.Len(); i++ {
@@ -32,8 +32,8 @@ func TestSlice(t *testing.T) {
 }
 
 func TestSliceReadOnly(t *testing.T) {
-	state := internal.StateReadOnly
-	es := newSlice(&[]otlpcommon.AnyValue{{Value: &otlpcommon.AnyValue_IntValue{IntValue: 3}}}, &state)
+	state := internal.NewReadOnlyState()
+	es := newSlice(&[]otlpcommon.AnyValue{{Value: &otlpcommon.AnyValue_IntValue{IntValue: 3}}}, state)
 
 	assert.Equal(t, 1, es.Len())
 	assert.Equal(t, int64(3), es.At(0).Int())
@@ -14,8 +14,8 @@ import (
 type TraceState internal.TraceState
 
 func NewTraceState() TraceState {
-	state := internal.StateMutable
-	return TraceState(internal.NewTraceState(new(string), &state))
+	state := internal.NewState()
+	return TraceState(internal.NewTraceState(new(string), state))
 }
 
 func (ms TraceState) getOrig() *string {
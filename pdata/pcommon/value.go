@@ -71,50 +71,50 @@ type Value internal.Value
 
 // NewValueEmpty creates a new Value with an empty value.
 func NewValueEmpty() Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{}, state)
 }
 
 // NewValueStr creates a new Value with the given string value.
 func NewValueStr(v string) Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}, state)
 }
 
 // NewValueInt creates a new Value with the given int64 value.
 func NewValueInt(v int64) Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}}, state)
 }
 
 // NewValueDouble creates a new Value with the given float64 value.
 func NewValueDouble(v float64) Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}}, state)
 }
 
 // NewValueBool creates a new Value with the given bool value.
 func NewValueBool(v bool) Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}}, state)
 }
 
 // NewValueMap creates a new Value of map type.
 func NewValueMap() Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}}, state)
 }
 
 // NewValueSlice creates a new Value of array type.
 func NewValueSlice() Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}}, state)
 }
 
 // NewValueBytes creates a new empty Value of byte type.
 func NewValueBytes() Value {
-	state := internal.StateMutable
-	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: nil}}, &state)
+	state := internal.NewState()
+	return newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: nil}}, state)
 }
 
 func newValue(orig *otlpcommon.AnyValue, state *internal.State) Value {
@@ -134,6 +134,7 @@ func (v Value) getState() *internal.State {
 func (v Value) FromRaw(iv any) error {
 	switch tv := iv.(type) {
 	case nil:
+		v.getState().ResetMapIndexesOfValue(v.getOrig())
 		v.getOrig().Value = nil
 	case string:
 		v.SetStr(tv)
@@ -262,6 +263,7 @@ func (v Value) Bytes() ByteSlice {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetStr(sv string) {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	v.getOrig().Value = &otlpcommon.AnyValue_StringValue{StringValue: sv}
 }
 
@@ -270,6 +272,7 @@ func (v Value) SetStr(sv string) {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetInt(iv int64) {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	v.getOrig().Value = &otlpcommon.AnyValue_IntValue{IntValue: iv}
 }
 
@@ -278,6 +281,7 @@ func (v Value) SetInt(iv int64) {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetDouble(dv float64) {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	v.getOrig().Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: dv}
 }
 
@@ -286,6 +290,7 @@ func (v Value) SetDouble(dv float64) {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetBool(bv bool) {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	v.getOrig().Value = &otlpcommon.AnyValue_BoolValue{BoolValue: bv}
 }
 
@@ -293,6 +298,7 @@ func (v Value) SetBool(bv bool) {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetEmptyBytes() ByteSlice {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	bv := otlpcommon.AnyValue_BytesValue{BytesValue: nil}
 	v.getOrig().Value = &bv
 	return ByteSlice(internal.NewByteSlice(&bv.BytesValue, v.getState()))
@@ -302,6 +308,7 @@ func (v Value) SetEmptyBytes() ByteSlice {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetEmptyMap() Map {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	kv := &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}
 	v.getOrig().Value = kv
 	return newMap(&kv.KvlistValue.Values, v.getState())
@@ -311,6 +318,7 @@ func (v Value) SetEmptyMap() Map {
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetEmptySlice() Slice {
 	v.getState().AssertMutable()
+	v.getState().ResetMapIndexesOfValue(v.getOrig())
 	av := &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}
 	v.getOrig().Value = av
 	return newSlice(&av.ArrayValue.Values, v.getState())
@@ -321,6 +329,8 @@ func (v Value) SetEmptySlice() Slice {
 func (v Value) CopyTo(dest Value) {
 	dest.getState().AssertMutable()
 	destOrig := dest.getOrig()
+	// The maps of the destination are discarded.
+	dest.getState().ResetMapIndexesOfValue(destOrig)
 	switch ov := v.getOrig().Value.(type) {
 	case *otlpcommon.AnyValue_KvlistValue:
 		kv, ok := destOrig.Value.(*otlpcommon.AnyValue_KvlistValue)
@@ -452,32 +462,32 @@ func (v Value) AsRaw() any {
 
 func newKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	state := internal.StateMutable
-	akv := newValue(&orig.Value, &state)
+	state := internal.NewState()
+	akv := newValue(&orig.Value, state)
 	akv.SetStr(v)
 	return orig
 }
 
 func newKeyValueInt(k string, v int64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	state := internal.StateMutable
-	akv := newValue(&orig.Value, &state)
+	state := internal.NewState()
+	akv := newValue(&orig.Value, state)
 	akv.SetInt(v)
 	return orig
 }
 
 func newKeyValueDouble(k string, v float64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	state := internal.StateMutable
-	akv := newValue(&orig.Value, &state)
+	state := internal.NewState()
+	akv := newValue(&orig.Value, state)
 	akv.SetDouble(v)
 	return orig
 }
 
 func newKeyValueBool(k string, v bool) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	state := internal.StateMutable
-	akv := newValue(&orig.Value, &state)
+	state := internal.NewState()
+	akv := newValue(&orig.Value, state)
 	akv.SetBool(v)
 	return orig
 }
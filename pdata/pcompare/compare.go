@@ -0,0 +1,369 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pcompare compares traces, metrics and logs semantically, and describes their differences.
+// The attributes are compared regardless of their order, and options allow to ignore the timestamps,
+// the values of resource attributes, and the order of the elements of the slices. It is meant to be
+// used in test assertions:
+//
+//	if diff := pcompare.CompareTraces(expected, sink.AllTraces()[0], pcompare.IgnoreOrder()); len(diff) > 0 {
+//		t.Errorf("unexpected traces:\n%s", diff)
+//	}
+package pcompare // import "go.opentelemetry.io/collector/pdata/pcompare"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// CompareTraces compares the actual traces to the expected ones, and returns their differences.
+func CompareTraces(expected, actual ptrace.Traces, opts ...Option) Diff {
+	return compare(internal.GetOrigTraces(internal.Traces(expected)), internal.GetOrigTraces(internal.Traces(actual)), opts)
+}
+
+// CompareMetrics compares the actual metrics to the expected ones, and returns their differences.
+func CompareMetrics(expected, actual pmetric.Metrics, opts ...Option) Diff {
+	return compare(internal.GetOrigMetrics(internal.Metrics(expected)), internal.GetOrigMetrics(internal.Metrics(actual)), opts)
+}
+
+// CompareLogs compares the actual logs to the expected ones, and returns their differences.
+func CompareLogs(expected, actual plog.Logs, opts ...Option) Diff {
+	return compare(internal.GetOrigLogs(internal.Logs(expected)), internal.GetOrigLogs(internal.Logs(actual)), opts)
+}
+
+func compare(expected, actual any, opts []Option) Diff {
+	c := &comparer{}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	c.compareValues("", reflect.ValueOf(expected), reflect.ValueOf(actual))
+	return c.diff
+}
+
+var (
+	keyValuesType = reflect.TypeOf([]otlpcommon.KeyValue(nil))
+	anyValueType  = reflect.TypeOf(otlpcommon.AnyValue{})
+	resourceType  = reflect.TypeOf(otlpresource.Resource{})
+)
+
+// comparer compares the generated OTLP messages by reflection.
+type comparer struct {
+	opts options
+	diff Diff
+}
+
+func (c *comparer) add(path string, expected, actual any) {
+	c.diff = append(c.diff, Difference{Path: path, Expected: expected, Actual: actual})
+}
+
+// equal returns true if there is no difference between the values.
+func (c *comparer) equal(expected, actual reflect.Value) bool {
+	sub := &comparer{opts: c.opts}
+	sub.compareValues("", expected, actual)
+	return len(sub.diff) == 0
+}
+
+func (c *comparer) compareValues(path string, expected, actual reflect.Value) {
+	switch expected.Kind() {
+	case reflect.Ptr:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				c.add(path, message(expected), message(actual))
+			}
+			return
+		}
+		c.compareValues(path, expected.Elem(), actual.Elem())
+	case reflect.Struct:
+		if expected.Type() == anyValueType {
+			expectedValue, actualValue := expected.Interface().(otlpcommon.AnyValue), actual.Interface().(otlpcommon.AnyValue)
+			c.compareAnyValues(path, &expectedValue, &actualValue)
+			return
+		}
+		c.compareStructs(path, expected, actual)
+	case reflect.Slice:
+		if kind := expected.Type().Elem().Kind(); kind == reflect.Struct || kind == reflect.Ptr {
+			c.compareSlices(path, expected, actual)
+			return
+		}
+		if (expected.Len() > 0 || actual.Len() > 0) && !reflect.DeepEqual(expected.Interface(), actual.Interface()) {
+			c.add(path, leaf(expected), leaf(actual))
+		}
+	default:
+		if !equalLeaves(expected, actual) {
+			c.add(path, leaf(expected), leaf(actual))
+		}
+	}
+}
+
+func (c *comparer) compareStructs(path string, expected, actual reflect.Value) {
+	t := expected.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case !f.IsExported() || strings.HasPrefix(f.Name, "XXX_"):
+		case c.opts.ignoreTimestamps && strings.HasSuffix(f.Name, "TimeUnixNano"):
+		case f.Type.Kind() == reflect.Interface:
+			c.compareOneofs(path, f.Tag.Get("protobuf_oneof"), expected.Field(i), actual.Field(i))
+		case f.Type == keyValuesType:
+			var ignored map[string]struct{}
+			if t == resourceType {
+				ignored = c.opts.ignoredResourceAttrKeys
+			}
+			c.compareAttributes(join(path, jsonName(f)), expected.Field(i).Interface().([]otlpcommon.KeyValue),
+				actual.Field(i).Interface().([]otlpcommon.KeyValue), ignored)
+		default:
+			c.compareValues(join(path, jsonName(f)), expected.Field(i), actual.Field(i))
+		}
+	}
+}
+
+// compareOneofs compares the values of a oneof field, whose wrapper structs have a single field named after
+// the chosen value. The differences are located by the name of the value, or by the name of the oneof if
+// the chosen values differ.
+func (c *comparer) compareOneofs(path, name string, expected, actual reflect.Value) {
+	switch {
+	case expected.IsNil() && actual.IsNil():
+	case expected.IsNil():
+		f := oneofValue(actual)
+		c.add(join(path, f.name), nil, leafOrMessage(f.value))
+	case actual.IsNil():
+		f := oneofValue(expected)
+		c.add(join(path, f.name), leafOrMessage(f.value), nil)
+	case expected.Elem().Type() != actual.Elem().Type():
+		c.add(join(path, name), oneofValue(expected).name, oneofValue(actual).name)
+	default:
+		f := oneofValue(expected)
+		c.compareValues(join(path, f.name), f.value, oneofValue(actual).value)
+	}
+}
+
+type namedValue struct {
+	name  string
+	value reflect.Value
+}
+
+func oneofValue(v reflect.Value) namedValue {
+	wrapper := v.Elem().Elem()
+	return namedValue{name: jsonName(wrapper.Type().Field(0)), value: wrapper.Field(0)}
+}
+
+// compareSlices compares the slices of messages, by index, or regardless of the order with IgnoreOrder.
+func (c *comparer) compareSlices(path string, expected, actual reflect.Value) {
+	var missing, unexpected []int
+	if c.opts.ignoreOrder {
+		matched := make([]bool, actual.Len())
+		for i := 0; i < expected.Len(); i++ {
+			j := 0
+			for ; j < actual.Len(); j++ {
+				if !matched[j] && c.equal(expected.Index(i), actual.Index(j)) {
+					matched[j] = true
+					break
+				}
+			}
+			if j == actual.Len() {
+				missing = append(missing, i)
+			}
+		}
+		for j := range matched {
+			if !matched[j] {
+				unexpected = append(unexpected, j)
+			}
+		}
+	} else {
+		for i := 0; i < expected.Len(); i++ {
+			missing = append(missing, i)
+		}
+		for j := 0; j < actual.Len(); j++ {
+			unexpected = append(unexpected, j)
+		}
+	}
+
+	// The elements left without an equal counterpart are compared in their order.
+	n := min(len(missing), len(unexpected))
+	for k := 0; k < n; k++ {
+		c.compareValues(index(path, missing[k]), expected.Index(missing[k]), actual.Index(unexpected[k]))
+	}
+	for _, i := range missing[n:] {
+		c.add(index(path, i), message(expected.Index(i)), nil)
+	}
+	for _, j := range unexpected[n:] {
+		c.add(index(path, j), nil, message(actual.Index(j)))
+	}
+}
+
+// compareAttributes compares the attributes regardless of their order. Only the presence of the attributes
+// with an ignored key is compared.
+func (c *comparer) compareAttributes(path string, expected, actual []otlpcommon.KeyValue, ignored map[string]struct{}) {
+	expectedValues, actualValues := attributeValues(expected), attributeValues(actual)
+	for _, key := range sortedKeys(expectedValues) {
+		keyPath := fmt.Sprintf("%s[%q]", path, key)
+		actualValue, ok := actualValues[key]
+		if !ok {
+			c.add(keyPath, rawValue(expectedValues[key]), nil)
+			continue
+		}
+		if _, ok := ignored[key]; !ok {
+			c.compareAnyValues(keyPath, expectedValues[key], actualValue)
+		}
+	}
+	for _, key := range sortedKeys(actualValues) {
+		if _, ok := expectedValues[key]; !ok {
+			c.add(fmt.Sprintf("%s[%q]", path, key), nil, rawValue(actualValues[key]))
+		}
+	}
+}
+
+func (c *comparer) compareAnyValues(path string, expected, actual *otlpcommon.AnyValue) {
+	switch expectedValue := expected.Value.(type) {
+	case *otlpcommon.AnyValue_KvlistValue:
+		if actualValue, ok := actual.Value.(*otlpcommon.AnyValue_KvlistValue); ok {
+			c.compareAttributes(path, expectedValue.KvlistValue.GetValues(), actualValue.KvlistValue.GetValues(), nil)
+			return
+		}
+	case *otlpcommon.AnyValue_ArrayValue:
+		if actualValue, ok := actual.Value.(*otlpcommon.AnyValue_ArrayValue); ok {
+			expectedValues, actualValues := expectedValue.ArrayValue.GetValues(), actualValue.ArrayValue.GetValues()
+			n := min(len(expectedValues), len(actualValues))
+			for i := 0; i < n; i++ {
+				c.compareAnyValues(index(path, i), &expectedValues[i], &actualValues[i])
+			}
+			for i := n; i < len(expectedValues); i++ {
+				c.add(index(path, i), rawValue(&expectedValues[i]), nil)
+			}
+			for i := n; i < len(actualValues); i++ {
+				c.add(index(path, i), nil, rawValue(&actualValues[i]))
+			}
+			return
+		}
+	}
+	expectedRaw, actualRaw := rawValue(expected), rawValue(actual)
+	if !reflect.DeepEqual(expectedRaw, actualRaw) {
+		c.add(path, expectedRaw, actualRaw)
+	}
+}
+
+// attributeValues returns the values of the attributes by key. As in pcommon.Map, the first
+// attribute with a key hides the next ones.
+func attributeValues(kvs []otlpcommon.KeyValue) map[string]*otlpcommon.AnyValue {
+	values := make(map[string]*otlpcommon.AnyValue, len(kvs))
+	for i := range kvs {
+		if _, ok := values[kvs[i].Key]; !ok {
+			values[kvs[i].Key] = &kvs[i].Value
+		}
+	}
+	return values
+}
+
+func sortedKeys(values map[string]*otlpcommon.AnyValue) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// rawValue returns the value as returned by pcommon.Value.AsRaw, or pcommon.ValueTypeEmpty for an empty value.
+func rawValue(v *otlpcommon.AnyValue) any {
+	state := internal.NewReadOnlyState()
+	value := pcommon.Value(internal.NewValue(v, state))
+	if value.Type() == pcommon.ValueTypeEmpty {
+		return pcommon.ValueTypeEmpty
+	}
+	return value.AsRaw()
+}
+
+// message returns the message to describe a missing or unexpected element, or nil for a nil pointer.
+func message(v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		// The generated messages implement fmt.Stringer with a pointer receiver.
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
+func leafOrMessage(v reflect.Value) any {
+	if kind := v.Kind(); kind == reflect.Ptr || kind == reflect.Struct {
+		return message(v)
+	}
+	return leaf(v)
+}
+
+// leaf returns the value of a scalar field as a basic Go value: the name of enums, and the
+// hexadecimal representation of the trace and span IDs.
+func leaf(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hex.EncodeToString(b)
+		}
+	}
+	return v.Interface()
+}
+
+func equalLeaves(expected, actual reflect.Value) bool {
+	if kind := expected.Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+		e, a := expected.Float(), actual.Float()
+		return e == a || math.IsNaN(e) && math.IsNaN(a)
+	}
+	return expected.Interface() == actual.Interface()
+}
+
+// jsonName returns the lowerCamelCase name of the field in the OTLP/JSON encoding.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return strings.ToLower(f.Name[:1]) + f.Name[1:]
+	}
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcompare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "svc")
+	rs.Resource().Attributes().PutStr("host.name", "host")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+	for _, name := range []string{"first", "second"} {
+		span := ss.Spans().AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(1000)
+		span.SetEndTimestamp(2000)
+		span.Attributes().PutStr("a", name)
+		span.Attributes().PutInt("b", 1)
+		span.Attributes().PutEmptySlice("c").FromRaw([]any{"x", int64(2)})
+	}
+	return td
+}
+
+func TestCompareTracesEqual(t *testing.T) {
+	assert.Empty(t, CompareTraces(newTraces(), newTraces()))
+	assert.Empty(t, CompareTraces(ptrace.NewTraces(), ptrace.NewTraces()))
+}
+
+func TestCompareTracesAttributeOrder(t *testing.T) {
+	actual := newTraces()
+	attrs := actual.ResourceSpans().At(0).Resource().Attributes()
+	attrs.Clear()
+	attrs.PutStr("host.name", "host")
+	attrs.PutStr("service.name", "svc")
+	assert.Empty(t, CompareTraces(newTraces(), actual))
+}
+
+func TestCompareTracesDifferences(t *testing.T) {
+	actual := newTraces()
+	span := actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1)
+	span.SetName("other")
+	span.SetKind(ptrace.SpanKindClient)
+	span.SetTraceID(pcommon.TraceID([16]byte{1}))
+	span.Attributes().PutInt("a", 3)
+	span.Attributes().Remove("b")
+	span.Attributes().PutBool("d", true)
+	span.Attributes().PutEmptySlice("c").FromRaw([]any{"y", int64(2), 3.5})
+
+	assert.Equal(t, Diff{
+		{Path: "resourceSpans[0].scopeSpans[0].spans[1].traceId", Expected: "0102030405060708090a0b0c0d0e0f10", Actual: "01000000000000000000000000000000"},
+		{Path: "resourceSpans[0].scopeSpans[0].spans[1].name", Expected: "second", Actual: "other"},
+		{Path: "resourceSpans[0].scopeSpans[0].spans[1].kind", Expected: "SPAN_KIND_SERVER", Actual: "SPAN_KIND_CLIENT"},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[1].attributes["a"]`, Expected: "second", Actual: int64(3)},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[1].attributes["b"]`, Expected: int64(1)},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[1].attributes["c"][0]`, Expected: "x", Actual: "y"},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[1].attributes["c"][2]`, Actual: 3.5},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[1].attributes["d"]`, Actual: true},
+	}, CompareTraces(newTraces(), actual))
+}
+
+func TestCompareTracesMissingAndUnexpected(t *testing.T) {
+	expected := newTraces()
+	actual := newTraces()
+	actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+		return span.Name() == "second"
+	})
+	diff := CompareTraces(expected, actual)
+	assert.Len(t, diff, 1)
+	assert.Equal(t, "resourceSpans[0].scopeSpans[0].spans[1]", diff[0].Path)
+	assert.NotNil(t, diff[0].Expected)
+	assert.Nil(t, diff[0].Actual)
+	assert.Contains(t, diff[0].String(), "resourceSpans[0].scopeSpans[0].spans[1]: missing ")
+
+	diff = CompareTraces(actual, expected)
+	assert.Len(t, diff, 1)
+	assert.Equal(t, "resourceSpans[0].scopeSpans[0].spans[1]", diff[0].Path)
+	assert.Nil(t, diff[0].Expected)
+	assert.NotNil(t, diff[0].Actual)
+	assert.Contains(t, diff[0].String(), "resourceSpans[0].scopeSpans[0].spans[1]: unexpected ")
+}
+
+func TestCompareTracesIgnoreOrder(t *testing.T) {
+	actual := newTraces()
+	spans := actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	spans.At(0).SetName("second")
+	spans.At(0).Attributes().PutStr("a", "second")
+	spans.At(1).SetName("first")
+	spans.At(1).Attributes().PutStr("a", "first")
+
+	assert.Len(t, CompareTraces(newTraces(), actual), 4)
+	assert.Empty(t, CompareTraces(newTraces(), actual, IgnoreOrder()))
+
+	// The elements without an equal counterpart are compared in their order.
+	spans.At(1).SetName("third")
+	assert.Equal(t, Diff{
+		{Path: "resourceSpans[0].scopeSpans[0].spans[0].name", Expected: "first", Actual: "third"},
+	}, CompareTraces(newTraces(), actual, IgnoreOrder()))
+
+	// The order of the values of slice attributes is not ignored.
+	actual = newTraces()
+	actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutEmptySlice("c").FromRaw([]any{int64(2), "x"})
+	assert.Len(t, CompareTraces(newTraces(), actual, IgnoreOrder()), 2)
+}
+
+func TestCompareTracesIgnoreTimestamps(t *testing.T) {
+	actual := newTraces()
+	span := actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span.SetStartTimestamp(3000)
+	span.SetEndTimestamp(4000)
+	assert.Equal(t, Diff{
+		{Path: "resourceSpans[0].scopeSpans[0].spans[0].startTimeUnixNano", Expected: uint64(1000), Actual: uint64(3000)},
+		{Path: "resourceSpans[0].scopeSpans[0].spans[0].endTimeUnixNano", Expected: uint64(2000), Actual: uint64(4000)},
+	}, CompareTraces(newTraces(), actual))
+	assert.Empty(t, CompareTraces(newTraces(), actual, IgnoreTimestamps()))
+}
+
+func TestCompareTracesIgnoreResourceAttributeValue(t *testing.T) {
+	actual := newTraces()
+	actual.ResourceSpans().At(0).Resource().Attributes().PutStr("host.name", "other")
+	actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("host.name", "other")
+	opt := IgnoreResourceAttributeValue("host.name")
+	assert.Equal(t, Diff{
+		{Path: `resourceSpans[0].scopeSpans[0].spans[0].attributes["host.name"]`, Actual: "other"},
+	}, CompareTraces(newTraces(), actual, opt))
+
+	// The presence of the attribute is still compared.
+	actual.ResourceSpans().At(0).Resource().Attributes().Remove("host.name")
+	assert.Equal(t, Diff{
+		{Path: `resourceSpans[0].resource.attributes["host.name"]`, Expected: "host"},
+		{Path: `resourceSpans[0].scopeSpans[0].spans[0].attributes["host.name"]`, Actual: "other"},
+	}, CompareTraces(newTraces(), actual, opt))
+}
+
+func newMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("sum")
+	dp := m.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetIntValue(1)
+	dp.Attributes().PutStr("k", "v")
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("histogram")
+	hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetSum(5)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{1})
+	return md
+}
+
+func TestCompareMetrics(t *testing.T) {
+	assert.Empty(t, CompareMetrics(newMetrics(), newMetrics()))
+
+	actual := newMetrics()
+	actual.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetDoubleValue(1)
+	hdp := actual.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0)
+	hdp.RemoveSum()
+	hdp.SetMin(0.5)
+	hdp.BucketCounts().FromRaw([]uint64{1, 3})
+	assert.Equal(t, Diff{
+		{Path: "resourceMetrics[0].scopeMetrics[0].metrics[0].sum.dataPoints[0].value", Expected: "asInt", Actual: "asDouble"},
+		{Path: "resourceMetrics[0].scopeMetrics[0].metrics[1].histogram.dataPoints[0].sum", Expected: 5.0},
+		{Path: "resourceMetrics[0].scopeMetrics[0].metrics[1].histogram.dataPoints[0].bucketCounts", Expected: []uint64{1, 2}, Actual: []uint64{1, 3}},
+		{Path: "resourceMetrics[0].scopeMetrics[0].metrics[1].histogram.dataPoints[0].min", Actual: 0.5},
+	}, CompareMetrics(newMetrics(), actual))
+
+	actual = newMetrics()
+	actual.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).SetEmptyGauge()
+	assert.Equal(t, Diff{
+		{Path: "resourceMetrics[0].scopeMetrics[0].metrics[1].data", Expected: "histogram", Actual: "gauge"},
+	}, CompareMetrics(newMetrics(), actual))
+}
+
+func TestCompareLogs(t *testing.T) {
+	newLogs := func() plog.Logs {
+		ld := plog.NewLogs()
+		lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Body().SetEmptyMap().PutStr("message", "hello")
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetObservedTimestamp(1000)
+		return ld
+	}
+	assert.Empty(t, CompareLogs(newLogs(), newLogs()))
+
+	actual := newLogs()
+	lr := actual.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	lr.Body().Map().PutStr("message", "bye")
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetObservedTimestamp(2000)
+	assert.Equal(t, Diff{
+		{Path: "resourceLogs[0].scopeLogs[0].logRecords[0].severityNumber", Expected: "SEVERITY_NUMBER_INFO", Actual: "SEVERITY_NUMBER_WARN"},
+		{Path: `resourceLogs[0].scopeLogs[0].logRecords[0].body["message"]`, Expected: "hello", Actual: "bye"},
+	}, CompareLogs(newLogs(), actual, IgnoreTimestamps()))
+
+	lr.Body().SetStr("hello")
+	assert.Equal(t, Diff{
+		{Path: "resourceLogs[0].scopeLogs[0].logRecords[0].severityNumber", Expected: "SEVERITY_NUMBER_INFO", Actual: "SEVERITY_NUMBER_WARN"},
+		{Path: "resourceLogs[0].scopeLogs[0].logRecords[0].body", Expected: map[string]any{"message": "hello"}, Actual: "hello"},
+	}, CompareLogs(newLogs(), actual, IgnoreTimestamps()))
+}
+
+func TestDiffString(t *testing.T) {
+	diff := Diff{
+		{Path: "a", Expected: "x", Actual: "y"},
+		{Path: "b", Expected: int64(1)},
+		{Path: "c", Actual: pcommon.ValueTypeEmpty},
+	}
+	assert.Equal(t, "a: expected x, actual y\nb: missing 1\nc: unexpected Empty", diff.String())
+	assert.Equal(t, "", Diff(nil).String())
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcompare // import "go.opentelemetry.io/collector/pdata/pcompare"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difference is a difference between the expected and the actual data.
+type Difference struct {
+	// Path locates the difference in the data, e.g. `resourceSpans[0].scopeSpans[0].spans[1].attributes["key"]`.
+	// The field names are the ones of the OTLP/JSON encoding. The indexes are the ones of the expected
+	// elements, except for the unexpected elements which have the index of the actual element.
+	Path string
+	// Expected is the expected value, or nil if the actual element is unexpected.
+	Expected any
+	// Actual is the actual value, or nil if the expected element is missing.
+	Actual any
+}
+
+// String returns a human readable description of the Difference.
+func (d Difference) String() string {
+	switch {
+	case d.Expected == nil:
+		return fmt.Sprintf("%s: unexpected %v", d.Path, d.Actual)
+	case d.Actual == nil:
+		return fmt.Sprintf("%s: missing %v", d.Path, d.Expected)
+	default:
+		return fmt.Sprintf("%s: expected %v, actual %v", d.Path, d.Expected, d.Actual)
+	}
+}
+
+// Diff is the list of the differences between the expected and the actual data. It is empty if the data are equal.
+type Diff []Difference
+
+// String returns a human readable description of the differences, one per line.
+func (d Diff) String() string {
+	var b strings.Builder
+	for i, diff := range d {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(diff.String())
+	}
+	return b.String()
+}
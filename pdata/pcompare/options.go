@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcompare // import "go.opentelemetry.io/collector/pdata/pcompare"
+
+// Option changes how the data is compared.
+type Option func(*options)
+
+type options struct {
+	ignoreTimestamps        bool
+	ignoreOrder             bool
+	ignoredResourceAttrKeys map[string]struct{}
+}
+
+// IgnoreTimestamps ignores the timestamps of spans, span events, data points, exemplars and log records.
+func IgnoreTimestamps() Option {
+	return func(o *options) {
+		o.ignoreTimestamps = true
+	}
+}
+
+// IgnoreResourceAttributeValue ignores the value of the resource attribute with the key, as long as the
+// attribute is present in both the expected and the actual resources.
+func IgnoreResourceAttributeValue(key string) Option {
+	return func(o *options) {
+		if o.ignoredResourceAttrKeys == nil {
+			o.ignoredResourceAttrKeys = make(map[string]struct{})
+		}
+		o.ignoredResourceAttrKeys[key] = struct{}{}
+	}
+}
+
+// IgnoreOrder ignores the order of the elements of the slices of resources, scopes, spans, span events,
+// span links, metrics, data points, exemplars and log records: each expected element is matched with an
+// equal actual element, and the remaining elements are compared in their order. The order of the values
+// of slice attributes is not ignored.
+func IgnoreOrder() Option {
+	return func(o *options) {
+		o.ignoreOrder = true
+	}
+}
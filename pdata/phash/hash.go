@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package phash computes identity hashes of pdata elements. The hashes only depend on the content of the
+// elements, not on the order of the attributes, and are stable across processes: they can be used to
+// identify resources, scopes and metric streams in state shared or persisted by several processes.
+package phash // import "go.opentelemetry.io/collector/pdata/phash"
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/bits"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Hash is a 128 bits identity hash.
+type Hash [16]byte
+
+// String returns the hexadecimal representation of the Hash.
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// The hashes are the 128 bits FNV-1a hashes of an encoding of the elements. Each element is encoded
+// after a byte identifying its kind, variable length elements are prefixed by their length, and a map
+// is encoded as the sum of the hashes of its entries, which does not depend on their order.
+const (
+	kindMap byte = iota + 1
+	kindResource
+	kindScope
+	kindMetric
+	kindMetricStream
+	kindCombined
+)
+
+// Map returns the Hash of the map. Maps with the same entries in a different order have the same Hash.
+func Map(m pcommon.Map) Hash {
+	h := newHasher()
+	h.writeByte(kindMap)
+	h.writeMap(m)
+	return h.sum()
+}
+
+// Resource returns the Hash of the resource, identified by its attributes.
+func Resource(res pcommon.Resource) Hash {
+	h := newHasher()
+	h.writeByte(kindResource)
+	h.writeMap(res.Attributes())
+	return h.sum()
+}
+
+// InstrumentationScope returns the Hash of the scope, identified by its name, version and attributes.
+func InstrumentationScope(scope pcommon.InstrumentationScope) Hash {
+	h := newHasher()
+	h.writeByte(kindScope)
+	h.writeString(scope.Name())
+	h.writeString(scope.Version())
+	h.writeMap(scope.Attributes())
+	return h.sum()
+}
+
+// Metric returns the Hash of the metric, identified by its name, type, unit, aggregation temporality and
+// monotonicity. The metrics without aggregation temporality or monotonicity have the unspecified
+// aggregation temporality, and are not monotonic.
+func Metric(m pmetric.Metric) Hash {
+	temporality := pmetric.AggregationTemporalityUnspecified
+	monotonic := false
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		temporality = m.Sum().AggregationTemporality()
+		monotonic = m.Sum().IsMonotonic()
+	case pmetric.MetricTypeHistogram:
+		temporality = m.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		temporality = m.ExponentialHistogram().AggregationTemporality()
+	}
+	h := newHasher()
+	h.writeByte(kindMetric)
+	h.writeString(m.Name())
+	h.writeByte(byte(m.Type()))
+	h.writeString(m.Unit())
+	h.writeByte(byte(temporality))
+	h.writeBool(monotonic)
+	return h.sum()
+}
+
+// MetricStream returns the Hash of the metric stream of the data points with the attributes, of the metric
+// of the scope of the resource. The hashes of the resource, scope and metric are usually computed once for
+// all the data points of the metric:
+//
+//	resource := phash.Resource(rm.Resource())
+//	scope := phash.InstrumentationScope(sm.Scope())
+//	metric := phash.Metric(m)
+//	for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+//		stream := phash.MetricStream(resource, scope, metric, m.Sum().DataPoints().At(i).Attributes())
+//	}
+func MetricStream(resource, scope, metric Hash, attributes pcommon.Map) Hash {
+	h := newHasher()
+	h.writeByte(kindMetricStream)
+	h.write(resource[:])
+	h.write(scope[:])
+	h.write(metric[:])
+	h.writeMap(attributes)
+	return h.sum()
+}
+
+// Combine returns the Hash of the sequence of hashes, which depends on their order.
+func Combine(hashes ...Hash) Hash {
+	h := newHasher()
+	h.writeByte(kindCombined)
+	h.writeUint64(uint64(len(hashes)))
+	for i := range hashes {
+		h.write(hashes[i][:])
+	}
+	return h.sum()
+}
+
+// hasher computes 128 bits FNV-1a hashes, without the allocations of hash/fnv.
+type hasher struct {
+	hi, lo uint64
+	buf    [binary.MaxVarintLen64]byte
+}
+
+const (
+	offset128Higher = 0x6c62272e07bb0142
+	offset128Lower  = 0x62b821756295c58d
+	prime128Lower   = 0x13b
+	prime128Shift   = 24
+)
+
+func newHasher() hasher {
+	return hasher{hi: offset128Higher, lo: offset128Lower}
+}
+
+func (h *hasher) sum() Hash {
+	var sum Hash
+	binary.BigEndian.PutUint64(sum[:8], h.hi)
+	binary.BigEndian.PutUint64(sum[8:], h.lo)
+	return sum
+}
+
+func (h *hasher) writeByte(b byte) {
+	h.lo ^= uint64(b)
+	// Multiply by the FNV prime 2^88 + 0x13b.
+	hi, lo := bits.Mul64(prime128Lower, h.lo)
+	h.hi = hi + h.lo<<prime128Shift + prime128Lower*h.hi
+	h.lo = lo
+}
+
+func (h *hasher) write(b []byte) {
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+func (h *hasher) writeBool(b bool) {
+	if b {
+		h.writeByte(1)
+	} else {
+		h.writeByte(0)
+	}
+}
+
+func (h *hasher) writeUint64(v uint64) {
+	h.write(binary.AppendUvarint(h.buf[:0], v))
+}
+
+func (h *hasher) writeString(s string) {
+	h.writeUint64(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.writeByte(s[i])
+	}
+}
+
+// writeMap writes the sum of the hashes of the entries of the map, with the number of entries.
+func (h *hasher) writeMap(m pcommon.Map) {
+	*h = h.withKeyValues(*internal.GetOrigMap(internal.Map(m)))
+}
+
+// withKeyValues hashes the raw entries of the map, which unlike pcommon.Map.Range does not allocate. As the
+// recursive functions are assumed to leak their pointer arguments, the hasher is passed and returned by value.
+func (h hasher) withKeyValues(kvs []otlpcommon.KeyValue) hasher {
+	var hi, lo uint64
+	for i := range kvs {
+		entry := newHasher()
+		entry.writeString(kvs[i].Key)
+		entry = entry.withValue(&kvs[i].Value)
+		hi += entry.hi
+		lo += entry.lo
+	}
+	h.writeUint64(uint64(len(kvs)))
+	h.write(binary.BigEndian.AppendUint64(h.buf[:0], hi))
+	h.write(binary.BigEndian.AppendUint64(h.buf[:0], lo))
+	return h
+}
+
+// withValue hashes the value after its pcommon.ValueType.
+func (h hasher) withValue(v *otlpcommon.AnyValue) hasher {
+	switch v := v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		h.writeByte(byte(pcommon.ValueTypeStr))
+		h.writeString(v.StringValue)
+	case *otlpcommon.AnyValue_IntValue:
+		h.writeByte(byte(pcommon.ValueTypeInt))
+		h.write(binary.BigEndian.AppendUint64(h.buf[:0], uint64(v.IntValue)))
+	case *otlpcommon.AnyValue_DoubleValue:
+		h.writeByte(byte(pcommon.ValueTypeDouble))
+		h.write(binary.BigEndian.AppendUint64(h.buf[:0], math.Float64bits(v.DoubleValue)))
+	case *otlpcommon.AnyValue_BoolValue:
+		h.writeByte(byte(pcommon.ValueTypeBool))
+		h.writeBool(v.BoolValue)
+	case *otlpcommon.AnyValue_BytesValue:
+		h.writeByte(byte(pcommon.ValueTypeBytes))
+		h.writeUint64(uint64(len(v.BytesValue)))
+		h.write(v.BytesValue)
+	case *otlpcommon.AnyValue_KvlistValue:
+		h.writeByte(byte(pcommon.ValueTypeMap))
+		if v.KvlistValue == nil {
+			return h.withKeyValues(nil)
+		}
+		return h.withKeyValues(v.KvlistValue.Values)
+	case *otlpcommon.AnyValue_ArrayValue:
+		h.writeByte(byte(pcommon.ValueTypeSlice))
+		if v.ArrayValue == nil {
+			h.writeUint64(0)
+			return h
+		}
+		h.writeUint64(uint64(len(v.ArrayValue.Values)))
+		for i := range v.ArrayValue.Values {
+			h = h.withValue(&v.ArrayValue.Values[i])
+		}
+	default:
+		h.writeByte(byte(pcommon.ValueTypeEmpty))
+	}
+	return h
+}
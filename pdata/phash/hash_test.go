@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package phash
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestHasherIsFNV128a(t *testing.T) {
+	for _, data := range []string{"", "a", "resource", string(make([]byte, 100))} {
+		expected := fnv.New128a()
+		_, _ = expected.Write([]byte(data))
+		h := newHasher()
+		h.write([]byte(data))
+		sum := h.sum()
+		assert.Equal(t, expected.Sum(nil), sum[:])
+	}
+}
+
+func fillMap(m pcommon.Map) {
+	m.PutStr("str", "value")
+	m.PutInt("int", 1)
+	m.PutDouble("double", 1.5)
+	m.PutBool("bool", true)
+	m.PutEmptyBytes("bytes").FromRaw([]byte{1, 2})
+	nested := m.PutEmptyMap("map")
+	nested.PutStr("a", "b")
+	nested.PutInt("c", 2)
+	s := m.PutEmptySlice("slice")
+	s.AppendEmpty().SetStr("x")
+	s.AppendEmpty().SetInt(3)
+	m.PutEmpty("empty")
+}
+
+func TestMap(t *testing.T) {
+	m := pcommon.NewMap()
+	fillMap(m)
+	// The hash is stable across processes.
+	assert.Equal(t, "390fb5324694234d9269c395f4e157f5", Map(m).String())
+
+	// The order of the entries does not matter, including in nested maps.
+	reversed := pcommon.NewMap()
+	raw := m.AsRaw()
+	keys := []string{"empty", "slice", "map", "bytes", "bool", "double", "int", "str"}
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		v.CopyTo(reversed.PutEmpty(k))
+	}
+	nested, _ := reversed.Get("map")
+	nested.Map().Remove("a")
+	nested.Map().PutStr("a", "b")
+	assert.Equal(t, raw, reversed.AsRaw())
+	assert.Equal(t, Map(m), Map(reversed))
+
+	assert.NotEqual(t, Map(m), Map(pcommon.NewMap()))
+}
+
+func TestMapDifferences(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b func(pcommon.Map)
+	}{
+		{
+			name: "value",
+			a:    func(m pcommon.Map) { m.PutStr("k", "a") },
+			b:    func(m pcommon.Map) { m.PutStr("k", "b") },
+		},
+		{
+			name: "key",
+			a:    func(m pcommon.Map) { m.PutStr("a", "v") },
+			b:    func(m pcommon.Map) { m.PutStr("b", "v") },
+		},
+		{
+			name: "type",
+			a:    func(m pcommon.Map) { m.PutStr("k", "1") },
+			b:    func(m pcommon.Map) { m.PutInt("k", 1) },
+		},
+		{
+			name: "key and value boundary",
+			a:    func(m pcommon.Map) { m.PutStr("ab", "c") },
+			b:    func(m pcommon.Map) { m.PutStr("a", "bc") },
+		},
+		{
+			name: "slice order",
+			a: func(m pcommon.Map) {
+				m.PutEmptySlice("k").FromRaw([]any{1, 2})
+			},
+			b: func(m pcommon.Map) {
+				m.PutEmptySlice("k").FromRaw([]any{2, 1})
+			},
+		},
+		{
+			name: "nested map",
+			a: func(m pcommon.Map) {
+				m.PutEmptyMap("k").PutStr("a", "b")
+			},
+			b: func(m pcommon.Map) {
+				m.PutEmptyMap("k").PutStr("a", "c")
+			},
+		},
+		{
+			name: "nested map and entries",
+			a: func(m pcommon.Map) {
+				m.PutEmptyMap("k").PutStr("a", "b")
+			},
+			b: func(m pcommon.Map) {
+				m.PutEmptyMap("k")
+				m.PutStr("a", "b")
+			},
+		},
+		{
+			name: "duplicated entries",
+			a: func(m pcommon.Map) {
+				m.PutStr("a", "v")
+				m.PutStr("b", "v")
+			},
+			b: func(m pcommon.Map) {
+				m.PutStr("a", "v")
+				m.PutStr("b", "v")
+				m.PutStr("c", "v")
+				m.PutStr("d", "v")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := pcommon.NewMap(), pcommon.NewMap()
+			tt.a(a)
+			tt.b(b)
+			assert.NotEqual(t, Map(a), Map(b))
+		})
+	}
+}
+
+func TestResourceAndScope(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "svc")
+	scope := pcommon.NewInstrumentationScope()
+	scope.Attributes().PutStr("service.name", "svc")
+
+	// The kinds of the hashed elements are part of the hashes.
+	assert.NotEqual(t, Map(res.Attributes()), Resource(res))
+	assert.NotEqual(t, Resource(res), InstrumentationScope(scope))
+
+	other := pcommon.NewInstrumentationScope()
+	scope.CopyTo(other)
+	assert.Equal(t, InstrumentationScope(scope), InstrumentationScope(other))
+	other.SetName("name")
+	assert.NotEqual(t, InstrumentationScope(scope), InstrumentationScope(other))
+	scope.CopyTo(other)
+	other.SetVersion("v1")
+	assert.NotEqual(t, InstrumentationScope(scope), InstrumentationScope(other))
+
+	// The dropped attributes count is not part of the identity.
+	res.SetDroppedAttributesCount(1)
+	scope.CopyTo(other)
+	other.SetDroppedAttributesCount(1)
+	assert.Equal(t, InstrumentationScope(scope), InstrumentationScope(other))
+}
+
+func TestMetric(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("metric")
+	m.SetUnit("1")
+	m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hash := Metric(m)
+
+	other := pmetric.NewMetric()
+	m.CopyTo(other)
+	other.SetDescription("description")
+	other.Sum().DataPoints().AppendEmpty().SetIntValue(1)
+	assert.Equal(t, hash, Metric(other))
+
+	changes := []func(pmetric.Metric){
+		func(m pmetric.Metric) { m.SetName("other") },
+		func(m pmetric.Metric) { m.SetUnit("By") },
+		func(m pmetric.Metric) { m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta) },
+		func(m pmetric.Metric) { m.Sum().SetIsMonotonic(true) },
+		func(m pmetric.Metric) { m.SetEmptyGauge() },
+		func(m pmetric.Metric) {
+			m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		},
+		func(m pmetric.Metric) {
+			m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		},
+	}
+	hashes := map[Hash]struct{}{hash: {}}
+	for _, change := range changes {
+		m.CopyTo(other)
+		change(other)
+		hashes[Metric(other)] = struct{}{}
+	}
+	assert.Len(t, hashes, len(changes)+1)
+}
+
+func TestMetricStream(t *testing.T) {
+	res, scope, metric := Hash{1}, Hash{2}, Hash{3}
+	attrs := pcommon.NewMap()
+	attrs.PutStr("k", "v")
+	hash := MetricStream(res, scope, metric, attrs)
+	assert.Equal(t, hash, MetricStream(res, scope, metric, attrs))
+	assert.NotEqual(t, hash, MetricStream(scope, res, metric, attrs))
+	assert.NotEqual(t, hash, MetricStream(res, scope, Hash{4}, attrs))
+	assert.NotEqual(t, hash, MetricStream(res, scope, metric, pcommon.NewMap()))
+}
+
+func TestCombine(t *testing.T) {
+	assert.Equal(t, Combine(Hash{1}, Hash{2}), Combine(Hash{1}, Hash{2}))
+	assert.NotEqual(t, Combine(Hash{1}, Hash{2}), Combine(Hash{2}, Hash{1}))
+	assert.NotEqual(t, Combine(), Combine(Hash{}))
+}
+
+func TestMapAllocations(t *testing.T) {
+	m := pcommon.NewMap()
+	fillMap(m)
+	assert.Zero(t, testing.AllocsPerRun(100, func() { Map(m) }))
+}
+
+func BenchmarkMap(b *testing.B) {
+	m := pcommon.NewMap()
+	fillMap(m)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Map(m)
+	}
+}
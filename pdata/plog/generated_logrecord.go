@@ -34,8 +34,8 @@ func newLogRecord(orig *otlplogs.LogRecord, state *internal.State) LogRecord {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewLogRecord() LogRecord {
-	state := internal.StateMutable
-	return newLogRecord(&otlplogs.LogRecord{}, &state)
+	state := internal.NewState()
+	return newLogRecord(&otlplogs.LogRecord{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -45,6 +45,9 @@ func (ms LogRecord) MoveTo(dest LogRecord) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.LogRecord{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // ObservedTimestamp returns the observedtimestamp associated with this LogRecord.
@@ -33,8 +33,8 @@ func newLogRecordSlice(orig *[]*otlplogs.LogRecord, state *internal.State) LogRe
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewLogRecordSlice() LogRecordSlice {
 	orig := []*otlplogs.LogRecord(nil)
-	state := internal.StateMutable
-	return newLogRecordSlice(&orig, &state)
+	state := internal.NewState()
+	return newLogRecordSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -99,6 +99,8 @@ func (es LogRecordSlice) MoveAndAppendTo(dest LogRecordSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,12 +121,18 @@ func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
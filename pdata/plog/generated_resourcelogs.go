@@ -33,8 +33,8 @@ func newResourceLogs(orig *otlplogs.ResourceLogs, state *internal.State) Resourc
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceLogs() ResourceLogs {
-	state := internal.StateMutable
-	return newResourceLogs(&otlplogs.ResourceLogs{}, &state)
+	state := internal.NewState()
+	return newResourceLogs(&otlplogs.ResourceLogs{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,10 @@ func (ms ResourceLogs) MoveTo(dest ResourceLogs) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ResourceLogs{}
+	dest.state.InheritShared(ms.state)
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Resource returns the resource associated with this ResourceLogs.
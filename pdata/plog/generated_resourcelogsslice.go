@@ -33,8 +33,8 @@ func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs, state *internal.State)
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceLogsSlice() ResourceLogsSlice {
 	orig := []*otlplogs.ResourceLogs(nil)
-	state := internal.StateMutable
-	return newResourceLogsSlice(&orig, &state)
+	state := internal.NewState()
+	return newResourceLogsSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,7 +53,7 @@ func (es ResourceLogsSlice) Len() int {
 //	    ... // Do something with the element
 //	}
 func (es ResourceLogsSlice) At(i int) ResourceLogs {
-	return newResourceLogs((*es.orig)[i], es.state)
+	return newResourceLogs(internal.LoadNode(es.state, &(*es.orig)[i], cloneSharedResourceLogs), es.state)
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -99,6 +99,9 @@ func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	dest.state.InheritShared(es.state)
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,18 +122,28 @@ func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs((*dest.orig)[i], dest.state))
+			if dest.state.Unshare((*dest.orig)[i]) {
+				// The shared element is overridden, so it does not need to be cloned.
+				(*dest.orig)[i] = &otlplogs.ResourceLogs{}
+			}
+			newResourceLogs(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newResourceLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -138,7 +151,7 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	wrappers := make([]*otlplogs.ResourceLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs(wrappers[i], dest.state))
+		newResourceLogs(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newResourceLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
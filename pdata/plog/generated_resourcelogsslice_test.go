@@ -19,8 +19,8 @@ import (
 func TestResourceLogsSlice(t *testing.T) {
 	es := NewResourceLogsSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &state)
+	state := internal.NewState()
+	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceLogs()
@@ -35,8 +35,8 @@ func TestResourceLogsSlice(t *testing.T) {
 }
 
 func TestResourceLogsSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestResourceLogsSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestResourceLogsSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newResourceLogsSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newResourceLogs(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestResourceLogsSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestResourceLogs().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestResourceLogsSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestResourceLogsSlice() ResourceLogsSlice {
 	es := NewResourceLogsSlice()
 	fillTestResourceLogsSlice(es)
@@ -33,8 +33,8 @@ func newScopeLogs(orig *otlplogs.ScopeLogs, state *internal.State) ScopeLogs {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeLogs() ScopeLogs {
-	state := internal.StateMutable
-	return newScopeLogs(&otlplogs.ScopeLogs{}, &state)
+	state := internal.NewState()
+	return newScopeLogs(&otlplogs.ScopeLogs{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ScopeLogs) MoveTo(dest ScopeLogs) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ScopeLogs{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Scope returns the scope associated with this ScopeLogs.
@@ -33,8 +33,8 @@ func newScopeLogsSlice(orig *[]*otlplogs.ScopeLogs, state *internal.State) Scope
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeLogsSlice() ScopeLogsSlice {
 	orig := []*otlplogs.ScopeLogs(nil)
-	state := internal.StateMutable
-	return newScopeLogsSlice(&orig, &state)
+	state := internal.NewState()
+	return newScopeLogsSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,7 +53,7 @@ func (es ScopeLogsSlice) Len() int {
 //	    ... // Do something with the element
 //	}
 func (es ScopeLogsSlice) At(i int) ScopeLogs {
-	return newScopeLogs((*es.orig)[i], es.state)
+	return newScopeLogs(internal.LoadNode(es.state, &(*es.orig)[i], cloneSharedScopeLogs), es.state)
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -99,6 +99,9 @@ func (es ScopeLogsSlice) MoveAndAppendTo(dest ScopeLogsSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	dest.state.InheritShared(es.state)
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,18 +122,28 @@ func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newScopeLogs((*es.orig)[i], es.state).CopyTo(newScopeLogs((*dest.orig)[i], dest.state))
+			if dest.state.Unshare((*dest.orig)[i]) {
+				// The shared element is overridden, so it does not need to be cloned.
+				(*dest.orig)[i] = &otlplogs.ScopeLogs{}
+			}
+			newScopeLogs(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newScopeLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -138,7 +151,7 @@ func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 	wrappers := make([]*otlplogs.ScopeLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newScopeLogs((*es.orig)[i], es.state).CopyTo(newScopeLogs(wrappers[i], dest.state))
+		newScopeLogs(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newScopeLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -19,8 +19,8 @@ import (
 func TestScopeLogsSlice(t *testing.T) {
 	es := NewScopeLogsSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, &state)
+	state := internal.NewState()
+	es = newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewScopeLogs()
@@ -35,8 +35,8 @@ func TestScopeLogsSlice(t *testing.T) {
 }
 
 func TestScopeLogsSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestScopeLogsSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestScopeLogsSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestScopeLogsSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newScopeLogsSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newScopeLogs(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestScopeLogsSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestScopeLogs().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestScopeLogsSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestScopeLogsSlice() ScopeLogsSlice {
 	es := NewScopeLogsSlice()
 	fillTestScopeLogsSlice(es)
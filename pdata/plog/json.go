@@ -6,6 +6,7 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -21,6 +22,8 @@ type JSONMarshaler struct{}
 // MarshalLogs to the OTLP/JSON format.
 func (*JSONMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
 	buf := bytes.Buffer{}
+	ld.getState().RLock()
+	defer ld.getState().RUnlock()
 	pb := internal.LogsToProto(internal.Logs(ld))
 	err := json.Marshal(&buf, &pb)
 	return buf.Bytes(), err
@@ -44,6 +47,50 @@ func (*JSONUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
 	return ld, nil
 }
 
+// JSONDecoder decodes OTLP/JSON formatted logs from a stream one ResourceLogs at a time, so that
+// large payloads can be processed without holding them entirely in memory.
+type JSONDecoder struct {
+	iter *jsoniter.Iterator
+}
+
+// NewJSONDecoder returns a JSONDecoder reading from r.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{iter: json.NewStreamIterator(r)}
+}
+
+// DecodeResourceLogs reads the next OTLP/JSON formatted logs object from the stream, and calls fn with
+// each of its ResourceLogs as soon as it is read. The ResourceLogs is exclusively owned by fn, it can be moved
+// to Logs with ResourceLogs.MoveTo. DecodeResourceLogs returns the first error returned by fn, and io.EOF if the
+// stream holds no more objects.
+func (d *JSONDecoder) DecodeResourceLogs(fn func(ResourceLogs) error) error {
+	if json.AtStreamEnd(d.iter) {
+		return io.EOF
+	}
+	var err error
+	d.iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "resourceLogs", "resource_logs":
+			return iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				rl := NewResourceLogs()
+				rl.unmarshalJsoniter(iter)
+				if iter.Error != nil {
+					return false
+				}
+				otlp.MigrateLogs([]*otlplogs.ResourceLogs{rl.orig})
+				err = fn(rl)
+				return err == nil
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return json.StreamError(d.iter)
+}
+
 func (ms Logs) unmarshalJsoniter(iter *jsoniter.Iterator) {
 	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
 		switch f {
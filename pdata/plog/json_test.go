@@ -4,7 +4,11 @@
 package plog
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -78,6 +82,40 @@ func TestJSONUnmarshalInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestJSONDecoder(t *testing.T) {
+	stream := logsJSON + "\n" + logsJSON + "\n"
+	decoder := NewJSONDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+	for i := 0; i < 2; i++ {
+		var got []ResourceLogs
+		require.NoError(t, decoder.DecodeResourceLogs(func(rl ResourceLogs) error {
+			got = append(got, rl)
+			return nil
+		}))
+		require.Len(t, got, logsOTLP.ResourceLogs().Len())
+		for j := range got {
+			assert.EqualValues(t, logsOTLP.ResourceLogs().At(j), got[j])
+		}
+	}
+	assert.ErrorIs(t, decoder.DecodeResourceLogs(func(ResourceLogs) error {
+		t.Fail()
+		return nil
+	}), io.EOF)
+}
+
+func TestJSONDecoderCallbackError(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(logsJSON))
+	err := errors.New("my error")
+	assert.ErrorIs(t, decoder.DecodeResourceLogs(func(ResourceLogs) error { return err }), err)
+}
+
+func TestJSONDecoderInvalid(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(logsJSON[:len(logsJSON)/2]))
+	assert.Error(t, decoder.DecodeResourceLogs(func(ResourceLogs) error { return nil }))
+
+	decoder = NewJSONDecoder(strings.NewReader(`{"resourceLogs": "extra"}`))
+	assert.Error(t, decoder.DecodeResourceLogs(func(ResourceLogs) error { return nil }))
+}
+
 func TestUnmarshalJsoniterLogsData(t *testing.T) {
 	jsonStr := `{"extra":"", "resourceLogs": []}`
 	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
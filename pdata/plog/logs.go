@@ -6,6 +6,7 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 )
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
@@ -13,8 +14,8 @@ import (
 type Logs internal.Logs
 
 func newLogs(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
-	state := internal.StateMutable
-	return Logs(internal.NewLogs(orig, &state))
+	state := internal.NewState()
+	return Logs(internal.NewLogs(orig, state))
 }
 
 func (ms Logs) getOrig() *otlpcollectorlog.ExportLogsServiceRequest {
@@ -32,7 +33,7 @@ func NewLogs() Logs {
 
 // IsReadOnly returns true if this Logs instance is read-only.
 func (ms Logs) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Logs instance overriding the destination.
@@ -43,13 +44,12 @@ func (ms Logs) CopyTo(dest Logs) {
 // LogRecordCount calculates the total number of log records.
 func (ms Logs) LogRecordCount() int {
 	logCount := 0
-	rss := ms.ResourceLogs()
-	for i := 0; i < rss.Len(); i++ {
-		rs := rss.At(i)
-		ill := rs.ScopeLogs()
-		for i := 0; i < ill.Len(); i++ {
-			logs := ill.At(i)
-			logCount += logs.LogRecords().Len()
+	// Iterate over the origs, accessing shared elements from the slices would clone them.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	for _, rl := range ms.getOrig().ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			logCount += len(sl.LogRecords)
 		}
 	}
 	return logCount
@@ -62,5 +62,46 @@ func (ms Logs) ResourceLogs() ResourceLogsSlice {
 
 // MarkReadOnly marks the Logs as shared so that no further modifications can be done on it.
 func (ms Logs) MarkReadOnly() {
-	internal.SetLogsState(internal.Logs(ms), internal.StateReadOnly)
+	ms.getState().MarkReadOnly()
+}
+
+// CopyOnWrite returns a copy of the Logs which shares its ResourceLogs and ScopeLogs
+// with the current instance. A shared element is cloned the first time it is accessed
+// from the copy, so only the parts of the copy which are accessed are actually copied.
+// The current instance must not be modified as long as the copy is in use.
+func (ms Logs) CopyOnWrite() Logs {
+	// The shared elements of the current instance may be cloned concurrently when it is a copy itself.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	src := ms.getOrig().ResourceLogs
+	dest := newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
+		ResourceLogs: make([]*otlplogs.ResourceLogs, len(src)),
+	})
+	copy(dest.getOrig().ResourceLogs, src)
+	for _, rl := range src {
+		dest.getState().MarkShared(rl)
+	}
+	return dest
+}
+
+// cloneSharedResourceLogs returns a copy of a shared ResourceLogs, which still shares
+// its ScopeLogs.
+func cloneSharedResourceLogs(orig *otlplogs.ResourceLogs, state *internal.State) *otlplogs.ResourceLogs {
+	dest := &otlplogs.ResourceLogs{
+		SchemaUrl: orig.SchemaUrl,
+		ScopeLogs: make([]*otlplogs.ScopeLogs, len(orig.ScopeLogs)),
+	}
+	newResourceLogs(orig, state).Resource().CopyTo(newResourceLogs(dest, state).Resource())
+	copy(dest.ScopeLogs, orig.ScopeLogs)
+	for _, sl := range orig.ScopeLogs {
+		state.MarkShared(sl)
+	}
+	return dest
+}
+
+// cloneSharedScopeLogs returns a copy of a shared ScopeLogs.
+func cloneSharedScopeLogs(orig *otlplogs.ScopeLogs, state *internal.State) *otlplogs.ScopeLogs {
+	dest := &otlplogs.ScopeLogs{}
+	newScopeLogs(orig, state).CopyTo(newScopeLogs(dest, state))
+	return dest
 }
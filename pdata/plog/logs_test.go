@@ -4,6 +4,8 @@
 package plog
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	goproto "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -125,6 +128,68 @@ func TestReadOnlyLogsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestLogsCopyOnWrite(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	expected := NewLogs()
+	logs.CopyTo(expected)
+
+	cow := logs.CopyOnWrite()
+	assert.Equal(t, logs.LogRecordCount(), cow.LogRecordCount())
+	// Counting does not clone the shared elements.
+	assert.Same(t, logs.getOrig().ResourceLogs[0], cow.getOrig().ResourceLogs[0])
+
+	rs := cow.ResourceLogs().At(0)
+	rs.Resource().Attributes().PutStr("k", "v")
+	assert.NotSame(t, logs.getOrig().ResourceLogs[0], cow.getOrig().ResourceLogs[0])
+	// The scopes of a cloned element are still shared until they are accessed.
+	assert.Same(t, logs.getOrig().ResourceLogs[0].ScopeLogs[1], rs.orig.ScopeLogs[1])
+	rs.ScopeLogs().At(0).Scope().SetName("modified")
+	assert.NotSame(t, logs.getOrig().ResourceLogs[0].ScopeLogs[0], rs.orig.ScopeLogs[0])
+	assert.Same(t, logs.getOrig().ResourceLogs[1], cow.getOrig().ResourceLogs[1])
+
+	cow.ResourceLogs().RemoveIf(func(rs ResourceLogs) bool {
+		return rs.ScopeLogs().Len() > 0
+	})
+	assert.Equal(t, 0, cow.ResourceLogs().Len())
+	assert.Equal(t, expected, logs)
+}
+
+func TestLogsCopyOnWriteConcurrentReads(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	expected := NewLogs()
+	logs.CopyTo(expected)
+	cow := logs.CopyOnWrite()
+
+	// The shared elements are cloned by the first goroutine accessing them, without racing with the others.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rs := cow.ResourceLogs()
+			for j := 0; j < rs.Len(); j++ {
+				ss := rs.At(j).ScopeLogs()
+				for k := 0; k < ss.Len(); k++ {
+					assert.Equal(t, ss.At(k).LogRecords().Len(), expected.ResourceLogs().At(j).ScopeLogs().At(k).LogRecords().Len())
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := (&ProtoMarshaler{}).MarshalLogs(cow)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.LogRecordCount(), cow.LogRecordCount())
+			dest := NewLogs()
+			cow.CopyTo(dest)
+			assert.Equal(t, expected, dest)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, expected, cow)
+}
+
 func BenchmarkLogsUsage(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
@@ -171,3 +236,19 @@ func BenchmarkLogsUsage(b *testing.B) {
 		}
 	}
 }
+
+func TestLogsRemoveIfDropsMapIndexes(t *testing.T) {
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	attrs := lrs.AppendEmpty().Attributes()
+	for i := 0; i < 2*internal.MapIndexThreshold; i++ {
+		attrs.PutStr("k"+strconv.Itoa(i), "v")
+	}
+	_, ok := attrs.Get("k1")
+	assert.True(t, ok)
+	assert.True(t, ld.getState().HasMapIndexes())
+
+	// The indexes of the removed log record must not keep it in memory.
+	lrs.RemoveIf(func(LogRecord) bool { return true })
+	assert.False(t, ld.getState().HasMapIndexes())
+}
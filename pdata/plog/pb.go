@@ -13,11 +13,15 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
+	ld.getState().RLock()
+	defer ld.getState().RUnlock()
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) LogsSize(ld Logs) int {
+	ld.getState().RLock()
+	defer ld.getState().RUnlock()
 	pb := internal.LogsToProto(internal.Logs(ld))
 	return pb.Size()
 }
@@ -32,8 +32,8 @@ func newExportPartialSuccess(orig *otlpcollectorlog.ExportLogsPartialSuccess, st
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewExportPartialSuccess() ExportPartialSuccess {
-	state := internal.StateMutable
-	return newExportPartialSuccess(&otlpcollectorlog.ExportLogsPartialSuccess{}, &state)
+	state := internal.NewState()
+	return newExportPartialSuccess(&otlpcollectorlog.ExportLogsPartialSuccess{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms ExportPartialSuccess) MoveTo(dest ExportPartialSuccess) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpcollectorlog.ExportLogsPartialSuccess{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // RejectedLogRecords returns the rejectedlogrecords associated with this ExportPartialSuccess.
@@ -37,8 +37,8 @@ func newExemplar(orig *otlpmetrics.Exemplar, state *internal.State) Exemplar {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewExemplar() Exemplar {
-	state := internal.StateMutable
-	return newExemplar(&otlpmetrics.Exemplar{}, &state)
+	state := internal.NewState()
+	return newExemplar(&otlpmetrics.Exemplar{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -48,6 +48,9 @@ func (ms Exemplar) MoveTo(dest Exemplar) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Exemplar{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Timestamp returns the timestamp associated with this Exemplar.
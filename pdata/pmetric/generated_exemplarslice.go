@@ -31,8 +31,8 @@ func newExemplarSlice(orig *[]otlpmetrics.Exemplar, state *internal.State) Exemp
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewExemplarSlice() ExemplarSlice {
 	orig := []otlpmetrics.Exemplar(nil)
-	state := internal.StateMutable
-	return newExemplarSlice(&orig, &state)
+	state := internal.NewState()
+	return newExemplarSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -76,12 +76,18 @@ func (es ExemplarSlice) EnsureCapacity(newCap int) {
 	newOrig := make([]otlpmetrics.Exemplar, len(*es.orig), newCap)
 	copy(newOrig, *es.orig)
 	*es.orig = newOrig
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 }
 
 // AppendEmpty will append to the end of the slice an empty Exemplar.
 // It returns the newly added Exemplar.
 func (es ExemplarSlice) AppendEmpty() Exemplar {
 	es.state.AssertMutable()
+	if len(*es.orig) == cap(*es.orig) {
+		// The maps of the elements are moved.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = append(*es.orig, otlpmetrics.Exemplar{})
 	return es.At(es.Len() - 1)
 }
@@ -97,6 +103,9 @@ func (es ExemplarSlice) MoveAndAppendTo(dest ExemplarSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
+	dest.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -117,12 +126,18 @@ func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded, and the maps of the others are moved.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
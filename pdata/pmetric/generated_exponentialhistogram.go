@@ -33,8 +33,8 @@ func newExponentialHistogram(orig *otlpmetrics.ExponentialHistogram, state *inte
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewExponentialHistogram() ExponentialHistogram {
-	state := internal.StateMutable
-	return newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &state)
+	state := internal.NewState()
+	return newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ExponentialHistogram) MoveTo(dest ExponentialHistogram) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.ExponentialHistogram{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this ExponentialHistogram.
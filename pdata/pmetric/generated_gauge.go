@@ -32,8 +32,8 @@ func newGauge(orig *otlpmetrics.Gauge, state *internal.State) Gauge {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewGauge() Gauge {
-	state := internal.StateMutable
-	return newGauge(&otlpmetrics.Gauge{}, &state)
+	state := internal.NewState()
+	return newGauge(&otlpmetrics.Gauge{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms Gauge) MoveTo(dest Gauge) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Gauge{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // DataPoints returns the DataPoints associated with this Gauge.
@@ -32,8 +32,8 @@ func newHistogram(orig *otlpmetrics.Histogram, state *internal.State) Histogram
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewHistogram() Histogram {
-	state := internal.StateMutable
-	return newHistogram(&otlpmetrics.Histogram{}, &state)
+	state := internal.NewState()
+	return newHistogram(&otlpmetrics.Histogram{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms Histogram) MoveTo(dest Histogram) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Histogram{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Histogram.
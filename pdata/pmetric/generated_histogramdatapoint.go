@@ -33,8 +33,8 @@ func newHistogramDataPoint(orig *otlpmetrics.HistogramDataPoint, state *internal
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewHistogramDataPoint() HistogramDataPoint {
-	state := internal.StateMutable
-	return newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &state)
+	state := internal.NewState()
+	return newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms HistogramDataPoint) MoveTo(dest HistogramDataPoint) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.HistogramDataPoint{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Attributes returns the Attributes associated with this HistogramDataPoint.
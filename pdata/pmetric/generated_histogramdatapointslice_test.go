@@ -19,8 +19,8 @@ import (
 func TestHistogramDataPointSlice(t *testing.T) {
 	es := NewHistogramDataPointSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, &state)
+	state := internal.NewState()
+	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewHistogramDataPoint()
@@ -35,8 +35,8 @@ func TestHistogramDataPointSlice(t *testing.T) {
 }
 
 func TestHistogramDataPointSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
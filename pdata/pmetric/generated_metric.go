@@ -34,8 +34,8 @@ func newMetric(orig *otlpmetrics.Metric, state *internal.State) Metric {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewMetric() Metric {
-	state := internal.StateMutable
-	return newMetric(&otlpmetrics.Metric{}, &state)
+	state := internal.NewState()
+	return newMetric(&otlpmetrics.Metric{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -45,6 +45,9 @@ func (ms Metric) MoveTo(dest Metric) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Metric{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Name returns the name associated with this Metric.
@@ -124,6 +127,10 @@ func (ms Metric) Gauge() Gauge {
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetEmptyGauge() Gauge {
 	ms.state.AssertMutable()
+	if ms.orig.Data != nil {
+		// The maps of the replaced value are discarded.
+		ms.state.ResetMapIndexes()
+	}
 	val := &otlpmetrics.Gauge{}
 	ms.orig.Data = &otlpmetrics.Metric_Gauge{Gauge: val}
 	return newGauge(val, ms.state)
@@ -150,6 +157,10 @@ func (ms Metric) Sum() Sum {
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetEmptySum() Sum {
 	ms.state.AssertMutable()
+	if ms.orig.Data != nil {
+		// The maps of the replaced value are discarded.
+		ms.state.ResetMapIndexes()
+	}
 	val := &otlpmetrics.Sum{}
 	ms.orig.Data = &otlpmetrics.Metric_Sum{Sum: val}
 	return newSum(val, ms.state)
@@ -176,6 +187,10 @@ func (ms Metric) Histogram() Histogram {
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetEmptyHistogram() Histogram {
 	ms.state.AssertMutable()
+	if ms.orig.Data != nil {
+		// The maps of the replaced value are discarded.
+		ms.state.ResetMapIndexes()
+	}
 	val := &otlpmetrics.Histogram{}
 	ms.orig.Data = &otlpmetrics.Metric_Histogram{Histogram: val}
 	return newHistogram(val, ms.state)
@@ -202,6 +217,10 @@ func (ms Metric) ExponentialHistogram() ExponentialHistogram {
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetEmptyExponentialHistogram() ExponentialHistogram {
 	ms.state.AssertMutable()
+	if ms.orig.Data != nil {
+		// The maps of the replaced value are discarded.
+		ms.state.ResetMapIndexes()
+	}
 	val := &otlpmetrics.ExponentialHistogram{}
 	ms.orig.Data = &otlpmetrics.Metric_ExponentialHistogram{ExponentialHistogram: val}
 	return newExponentialHistogram(val, ms.state)
@@ -228,6 +247,10 @@ func (ms Metric) Summary() Summary {
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetEmptySummary() Summary {
 	ms.state.AssertMutable()
+	if ms.orig.Data != nil {
+		// The maps of the replaced value are discarded.
+		ms.state.ResetMapIndexes()
+	}
 	val := &otlpmetrics.Summary{}
 	ms.orig.Data = &otlpmetrics.Metric_Summary{Summary: val}
 	return newSummary(val, ms.state)
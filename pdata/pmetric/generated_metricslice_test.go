@@ -19,8 +19,8 @@ import (
 func TestMetricSlice(t *testing.T) {
 	es := NewMetricSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newMetricSlice(&[]*otlpmetrics.Metric{}, &state)
+	state := internal.NewState()
+	es = newMetricSlice(&[]*otlpmetrics.Metric{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewMetric()
@@ -35,8 +35,8 @@ func TestMetricSlice(t *testing.T) {
 }
 
 func TestMetricSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newMetricSlice(&[]*otlpmetrics.Metric{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newMetricSlice(&[]*otlpmetrics.Metric{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -19,8 +19,8 @@ import (
 func TestNumberDataPointSlice(t *testing.T) {
 	es := NewNumberDataPointSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, &state)
+	state := internal.NewState()
+	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewNumberDataPoint()
@@ -35,8 +35,8 @@ func TestNumberDataPointSlice(t *testing.T) {
 }
 
 func TestNumberDataPointSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -33,8 +33,8 @@ func newResourceMetrics(orig *otlpmetrics.ResourceMetrics, state *internal.State
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceMetrics() ResourceMetrics {
-	state := internal.StateMutable
-	return newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &state)
+	state := internal.NewState()
+	return newResourceMetrics(&otlpmetrics.ResourceMetrics{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,10 @@ func (ms ResourceMetrics) MoveTo(dest ResourceMetrics) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.ResourceMetrics{}
+	dest.state.InheritShared(ms.state)
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Resource returns the resource associated with this ResourceMetrics.
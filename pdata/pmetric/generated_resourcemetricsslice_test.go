@@ -19,8 +19,8 @@ import (
 func TestResourceMetricsSlice(t *testing.T) {
 	es := NewResourceMetricsSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &state)
+	state := internal.NewState()
+	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceMetrics()
@@ -35,8 +35,8 @@ func TestResourceMetricsSlice(t *testing.T) {
 }
 
 func TestResourceMetricsSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestResourceMetricsSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestResourceMetricsSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newResourceMetricsSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newResourceMetrics(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestResourceMetricsSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestResourceMetrics().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestResourceMetricsSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestResourceMetricsSlice() ResourceMetricsSlice {
 	es := NewResourceMetricsSlice()
 	fillTestResourceMetricsSlice(es)
@@ -33,8 +33,8 @@ func newScopeMetrics(orig *otlpmetrics.ScopeMetrics, state *internal.State) Scop
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeMetrics() ScopeMetrics {
-	state := internal.StateMutable
-	return newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &state)
+	state := internal.NewState()
+	return newScopeMetrics(&otlpmetrics.ScopeMetrics{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ScopeMetrics) MoveTo(dest ScopeMetrics) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.ScopeMetrics{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Scope returns the scope associated with this ScopeMetrics.
@@ -33,8 +33,8 @@ func newScopeMetricsSlice(orig *[]*otlpmetrics.ScopeMetrics, state *internal.Sta
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeMetricsSlice() ScopeMetricsSlice {
 	orig := []*otlpmetrics.ScopeMetrics(nil)
-	state := internal.StateMutable
-	return newScopeMetricsSlice(&orig, &state)
+	state := internal.NewState()
+	return newScopeMetricsSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,7 +53,7 @@ func (es ScopeMetricsSlice) Len() int {
 //	    ... // Do something with the element
 //	}
 func (es ScopeMetricsSlice) At(i int) ScopeMetrics {
-	return newScopeMetrics((*es.orig)[i], es.state)
+	return newScopeMetrics(internal.LoadNode(es.state, &(*es.orig)[i], cloneSharedScopeMetrics), es.state)
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -99,6 +99,9 @@ func (es ScopeMetricsSlice) MoveAndAppendTo(dest ScopeMetricsSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	dest.state.InheritShared(es.state)
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,18 +122,28 @@ func (es ScopeMetricsSlice) RemoveIf(f func(ScopeMetrics) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ScopeMetricsSlice) CopyTo(dest ScopeMetricsSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newScopeMetrics((*es.orig)[i], es.state).CopyTo(newScopeMetrics((*dest.orig)[i], dest.state))
+			if dest.state.Unshare((*dest.orig)[i]) {
+				// The shared element is overridden, so it does not need to be cloned.
+				(*dest.orig)[i] = &otlpmetrics.ScopeMetrics{}
+			}
+			newScopeMetrics(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newScopeMetrics((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -138,7 +151,7 @@ func (es ScopeMetricsSlice) CopyTo(dest ScopeMetricsSlice) {
 	wrappers := make([]*otlpmetrics.ScopeMetrics, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newScopeMetrics((*es.orig)[i], es.state).CopyTo(newScopeMetrics(wrappers[i], dest.state))
+		newScopeMetrics(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newScopeMetrics(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -19,8 +19,8 @@ import (
 func TestScopeMetricsSlice(t *testing.T) {
 	es := NewScopeMetricsSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, &state)
+	state := internal.NewState()
+	es = newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewScopeMetrics()
@@ -35,8 +35,8 @@ func TestScopeMetricsSlice(t *testing.T) {
 }
 
 func TestScopeMetricsSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestScopeMetricsSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestScopeMetricsSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestScopeMetricsSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newScopeMetricsSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newScopeMetrics(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestScopeMetricsSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestScopeMetrics().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestScopeMetricsSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestScopeMetricsSlice() ScopeMetricsSlice {
 	es := NewScopeMetricsSlice()
 	fillTestScopeMetricsSlice(es)
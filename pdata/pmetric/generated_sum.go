@@ -32,8 +32,8 @@ func newSum(orig *otlpmetrics.Sum, state *internal.State) Sum {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSum() Sum {
-	state := internal.StateMutable
-	return newSum(&otlpmetrics.Sum{}, &state)
+	state := internal.NewState()
+	return newSum(&otlpmetrics.Sum{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms Sum) MoveTo(dest Sum) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Sum{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Sum.
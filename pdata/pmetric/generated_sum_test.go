@@ -21,9 +21,9 @@ func TestSum_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewSum(), ms)
 	assert.Equal(t, generateTestSum(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
-	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newSum(&otlpmetrics.Sum{}, sharedState)) })
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, sharedState).MoveTo(dest) })
 }
 
 func TestSum_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestSum_CopyTo(t *testing.T) {
 	orig = generateTestSum()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newSum(&otlpmetrics.Sum{}, sharedState)) })
 }
 
 func TestSum_AggregationTemporality(t *testing.T) {
@@ -51,8 +51,8 @@ func TestSum_IsMonotonic(t *testing.T) {
 	assert.Equal(t, false, ms.IsMonotonic())
 	ms.SetIsMonotonic(true)
 	assert.Equal(t, true, ms.IsMonotonic())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).SetIsMonotonic(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, sharedState).SetIsMonotonic(true) })
 }
 
 func TestSum_DataPoints(t *testing.T) {
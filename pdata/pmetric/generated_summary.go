@@ -32,8 +32,8 @@ func newSummary(orig *otlpmetrics.Summary, state *internal.State) Summary {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSummary() Summary {
-	state := internal.StateMutable
-	return newSummary(&otlpmetrics.Summary{}, &state)
+	state := internal.NewState()
+	return newSummary(&otlpmetrics.Summary{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms Summary) MoveTo(dest Summary) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.Summary{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // DataPoints returns the DataPoints associated with this Summary.
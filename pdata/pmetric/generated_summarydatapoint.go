@@ -33,8 +33,8 @@ func newSummaryDataPoint(orig *otlpmetrics.SummaryDataPoint, state *internal.Sta
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSummaryDataPoint() SummaryDataPoint {
-	state := internal.StateMutable
-	return newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &state)
+	state := internal.NewState()
+	return newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms SummaryDataPoint) MoveTo(dest SummaryDataPoint) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpmetrics.SummaryDataPoint{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Attributes returns the Attributes associated with this SummaryDataPoint.
@@ -19,8 +19,8 @@ import (
 func TestSummaryDataPointSlice(t *testing.T) {
 	es := NewSummaryDataPointSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, &state)
+	state := internal.NewState()
+	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewSummaryDataPoint()
@@ -35,8 +35,8 @@ func TestSummaryDataPointSlice(t *testing.T) {
 }
 
 func TestSummaryDataPointSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -6,6 +6,7 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -23,6 +24,8 @@ type JSONMarshaler struct{}
 // MarshalMetrics to the OTLP/JSON format.
 func (*JSONMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
 	buf := bytes.Buffer{}
+	md.getState().RLock()
+	defer md.getState().RUnlock()
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	err := json.Marshal(&buf, &pb)
 	return buf.Bytes(), err
@@ -44,6 +47,50 @@ func (*JSONUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
 	return md, nil
 }
 
+// JSONDecoder decodes OTLP/JSON formatted metrics from a stream one ResourceMetrics at a time, so that
+// large payloads can be processed without holding them entirely in memory.
+type JSONDecoder struct {
+	iter *jsoniter.Iterator
+}
+
+// NewJSONDecoder returns a JSONDecoder reading from r.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{iter: json.NewStreamIterator(r)}
+}
+
+// DecodeResourceMetrics reads the next OTLP/JSON formatted metrics object from the stream, and calls fn with
+// each of its ResourceMetrics as soon as it is read. The ResourceMetrics is exclusively owned by fn, it can be moved
+// to Metrics with ResourceMetrics.MoveTo. DecodeResourceMetrics returns the first error returned by fn, and io.EOF if the
+// stream holds no more objects.
+func (d *JSONDecoder) DecodeResourceMetrics(fn func(ResourceMetrics) error) error {
+	if json.AtStreamEnd(d.iter) {
+		return io.EOF
+	}
+	var err error
+	d.iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "resourceMetrics", "resource_metrics":
+			return iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				rm := NewResourceMetrics()
+				rm.unmarshalJsoniter(iter)
+				if iter.Error != nil {
+					return false
+				}
+				otlp.MigrateMetrics([]*otlpmetrics.ResourceMetrics{rm.orig})
+				err = fn(rm)
+				return err == nil
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return json.StreamError(d.iter)
+}
+
 func (ms Metrics) unmarshalJsoniter(iter *jsoniter.Iterator) {
 	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
 		switch f {
@@ -4,11 +4,16 @@
 package pmetric
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -41,6 +46,40 @@ func TestMetricsJSON(t *testing.T) {
 	assert.EqualValues(t, metricsOTLP, got)
 }
 
+func TestJSONDecoder(t *testing.T) {
+	stream := metricsJSON + "\n" + metricsJSON + "\n"
+	decoder := NewJSONDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+	for i := 0; i < 2; i++ {
+		var got []ResourceMetrics
+		require.NoError(t, decoder.DecodeResourceMetrics(func(rm ResourceMetrics) error {
+			got = append(got, rm)
+			return nil
+		}))
+		require.Len(t, got, metricsOTLP.ResourceMetrics().Len())
+		for j := range got {
+			assert.EqualValues(t, metricsOTLP.ResourceMetrics().At(j), got[j])
+		}
+	}
+	assert.ErrorIs(t, decoder.DecodeResourceMetrics(func(ResourceMetrics) error {
+		t.Fail()
+		return nil
+	}), io.EOF)
+}
+
+func TestJSONDecoderCallbackError(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(metricsJSON))
+	err := errors.New("my error")
+	assert.ErrorIs(t, decoder.DecodeResourceMetrics(func(ResourceMetrics) error { return err }), err)
+}
+
+func TestJSONDecoderInvalid(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(metricsJSON[:len(metricsJSON)/2]))
+	assert.Error(t, decoder.DecodeResourceMetrics(func(ResourceMetrics) error { return nil }))
+
+	decoder = NewJSONDecoder(strings.NewReader(`{"resourceMetrics": "extra"}`))
+	assert.Error(t, decoder.DecodeResourceMetrics(func(ResourceMetrics) error { return nil }))
+}
+
 func TestMetricsJSON_Marshal(t *testing.T) {
 	encoder := &JSONMarshaler{}
 	jsonBuf, err := encoder.MarshalMetrics(metricsOTLP)
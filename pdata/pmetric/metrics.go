@@ -6,6 +6,7 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
@@ -13,8 +14,8 @@ import (
 type Metrics internal.Metrics
 
 func newMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
-	state := internal.StateMutable
-	return Metrics(internal.NewMetrics(orig, &state))
+	state := internal.NewState()
+	return Metrics(internal.NewMetrics(orig, state))
 }
 
 func (ms Metrics) getOrig() *otlpcollectormetrics.ExportMetricsServiceRequest {
@@ -32,7 +33,7 @@ func NewMetrics() Metrics {
 
 // IsReadOnly returns true if this Metrics instance is read-only.
 func (ms Metrics) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Metrics instance overriding the destination.
@@ -48,13 +49,12 @@ func (ms Metrics) ResourceMetrics() ResourceMetricsSlice {
 // MetricCount calculates the total number of metrics.
 func (ms Metrics) MetricCount() int {
 	metricCount := 0
-	rms := ms.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		rm := rms.At(i)
-		ilms := rm.ScopeMetrics()
-		for j := 0; j < ilms.Len(); j++ {
-			ilm := ilms.At(j)
-			metricCount += ilm.Metrics().Len()
+	// Iterate over the origs, accessing shared elements from the slices would clone them.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	for _, rm := range ms.getOrig().ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			metricCount += len(sm.Metrics)
 		}
 	}
 	return metricCount
@@ -62,15 +62,13 @@ func (ms Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (ms Metrics) DataPointCount() (dataPointCount int) {
-	rms := ms.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		rm := rms.At(i)
-		ilms := rm.ScopeMetrics()
-		for j := 0; j < ilms.Len(); j++ {
-			ilm := ilms.At(j)
-			ms := ilm.Metrics()
-			for k := 0; k < ms.Len(); k++ {
-				m := ms.At(k)
+	// Iterate over the origs, accessing shared elements from the slices would clone them.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	for _, rm := range ms.getOrig().ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, orig := range sm.Metrics {
+				m := newMetric(orig, ms.getState())
 				switch m.Type() {
 				case MetricTypeGauge:
 					dataPointCount += m.Gauge().DataPoints().Len()
@@ -91,5 +89,46 @@ func (ms Metrics) DataPointCount() (dataPointCount int) {
 
 // MarkReadOnly marks the Metrics as shared so that no further modifications can be done on it.
 func (ms Metrics) MarkReadOnly() {
-	internal.SetMetricsState(internal.Metrics(ms), internal.StateReadOnly)
+	ms.getState().MarkReadOnly()
+}
+
+// CopyOnWrite returns a copy of the Metrics which shares its ResourceMetrics and ScopeMetrics
+// with the current instance. A shared element is cloned the first time it is accessed
+// from the copy, so only the parts of the copy which are accessed are actually copied.
+// The current instance must not be modified as long as the copy is in use.
+func (ms Metrics) CopyOnWrite() Metrics {
+	// The shared elements of the current instance may be cloned concurrently when it is a copy itself.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	src := ms.getOrig().ResourceMetrics
+	dest := newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: make([]*otlpmetrics.ResourceMetrics, len(src)),
+	})
+	copy(dest.getOrig().ResourceMetrics, src)
+	for _, rm := range src {
+		dest.getState().MarkShared(rm)
+	}
+	return dest
+}
+
+// cloneSharedResourceMetrics returns a copy of a shared ResourceMetrics, which still shares
+// its ScopeMetrics.
+func cloneSharedResourceMetrics(orig *otlpmetrics.ResourceMetrics, state *internal.State) *otlpmetrics.ResourceMetrics {
+	dest := &otlpmetrics.ResourceMetrics{
+		SchemaUrl:    orig.SchemaUrl,
+		ScopeMetrics: make([]*otlpmetrics.ScopeMetrics, len(orig.ScopeMetrics)),
+	}
+	newResourceMetrics(orig, state).Resource().CopyTo(newResourceMetrics(dest, state).Resource())
+	copy(dest.ScopeMetrics, orig.ScopeMetrics)
+	for _, sm := range orig.ScopeMetrics {
+		state.MarkShared(sm)
+	}
+	return dest
+}
+
+// cloneSharedScopeMetrics returns a copy of a shared ScopeMetrics.
+func cloneSharedScopeMetrics(orig *otlpmetrics.ScopeMetrics, state *internal.State) *otlpmetrics.ScopeMetrics {
+	dest := &otlpmetrics.ScopeMetrics{}
+	newScopeMetrics(orig, state).CopyTo(newScopeMetrics(dest, state))
+	return dest
 }
@@ -4,6 +4,7 @@
 package pmetric
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -646,6 +647,68 @@ func TestReadOnlyMetricsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestMetricsCopyOnWrite(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	expected := NewMetrics()
+	metrics.CopyTo(expected)
+
+	cow := metrics.CopyOnWrite()
+	assert.Equal(t, metrics.DataPointCount(), cow.DataPointCount())
+	// Counting does not clone the shared elements.
+	assert.Same(t, metrics.getOrig().ResourceMetrics[0], cow.getOrig().ResourceMetrics[0])
+
+	rs := cow.ResourceMetrics().At(0)
+	rs.Resource().Attributes().PutStr("k", "v")
+	assert.NotSame(t, metrics.getOrig().ResourceMetrics[0], cow.getOrig().ResourceMetrics[0])
+	// The scopes of a cloned element are still shared until they are accessed.
+	assert.Same(t, metrics.getOrig().ResourceMetrics[0].ScopeMetrics[1], rs.orig.ScopeMetrics[1])
+	rs.ScopeMetrics().At(0).Scope().SetName("modified")
+	assert.NotSame(t, metrics.getOrig().ResourceMetrics[0].ScopeMetrics[0], rs.orig.ScopeMetrics[0])
+	assert.Same(t, metrics.getOrig().ResourceMetrics[1], cow.getOrig().ResourceMetrics[1])
+
+	cow.ResourceMetrics().RemoveIf(func(rs ResourceMetrics) bool {
+		return rs.ScopeMetrics().Len() > 0
+	})
+	assert.Equal(t, 0, cow.ResourceMetrics().Len())
+	assert.Equal(t, expected, metrics)
+}
+
+func TestMetricsCopyOnWriteConcurrentReads(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	expected := NewMetrics()
+	metrics.CopyTo(expected)
+	cow := metrics.CopyOnWrite()
+
+	// The shared elements are cloned by the first goroutine accessing them, without racing with the others.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rs := cow.ResourceMetrics()
+			for j := 0; j < rs.Len(); j++ {
+				ss := rs.At(j).ScopeMetrics()
+				for k := 0; k < ss.Len(); k++ {
+					assert.Equal(t, ss.At(k).Metrics().Len(), expected.ResourceMetrics().At(j).ScopeMetrics().At(k).Metrics().Len())
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := (&ProtoMarshaler{}).MarshalMetrics(cow)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.DataPointCount(), cow.DataPointCount())
+			dest := NewMetrics()
+			cow.CopyTo(dest)
+			assert.Equal(t, expected, dest)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, expected, cow)
+}
+
 func BenchmarkOtlpToFromInternal_PassThrough(b *testing.B) {
 	req := &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
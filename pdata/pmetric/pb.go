@@ -13,11 +13,15 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
+	md.getState().RLock()
+	defer md.getState().RUnlock()
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) MetricsSize(md Metrics) int {
+	md.getState().RLock()
+	defer md.getState().RUnlock()
 	pb := internal.MetricsToProto(internal.Metrics(md))
 	return pb.Size()
 }
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pmetricexphistogram provides functions to merge, downscale and build the
+// data points of exponential histograms.
+package pmetricexphistogram // import "go.opentelemetry.io/collector/pdata/pmetric/pmetricexphistogram"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/exphistogram"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Merge adds the observations of src to dst. The merged data point has the lowest scale of both data
+// points, the highest zero threshold of both data points, the earliest start timestamp and the latest
+// timestamp of both data points. The attributes, flags and exemplars of dst are kept. A src data point
+// with the NoRecordedValue flag is ignored.
+//
+// When the zero thresholds differ, the buckets below the highest zero threshold are counted in the
+// zero bucket. A bucket crossing the zero threshold is kept.
+func Merge(dst, src pmetric.ExponentialHistogramDataPoint) {
+	if src.Flags().NoRecordedValue() {
+		return
+	}
+	scale := min(dst.Scale(), src.Scale())
+	Downscale(dst, scale)
+	positive, negative := readBuckets(dst.Positive()), readBuckets(dst.Negative())
+	srcPositive, srcNegative := readBuckets(src.Positive()), readBuckets(src.Negative())
+	srcPositive.Downscale(src.Scale() - scale)
+	srcNegative.Downscale(src.Scale() - scale)
+	positive.Add(srcPositive)
+	negative.Add(srcNegative)
+
+	zeroThreshold := max(dst.ZeroThreshold(), src.ZeroThreshold())
+	zeroCount := dst.ZeroCount() + src.ZeroCount()
+	zeroCount += removeZeroBuckets(&positive, scale, zeroThreshold)
+	zeroCount += removeZeroBuckets(&negative, scale, zeroThreshold)
+	positive.Trim()
+	negative.Trim()
+	writeBuckets(dst.Positive(), positive)
+	writeBuckets(dst.Negative(), negative)
+	dst.SetZeroThreshold(zeroThreshold)
+	dst.SetZeroCount(zeroCount)
+
+	mergeAggregates(dst, src)
+	dst.SetCount(dst.Count() + src.Count())
+	if src.StartTimestamp() != 0 && (dst.StartTimestamp() == 0 || src.StartTimestamp() < dst.StartTimestamp()) {
+		dst.SetStartTimestamp(src.StartTimestamp())
+	}
+	dst.SetTimestamp(max(dst.Timestamp(), src.Timestamp()))
+	dst.SetFlags(dst.Flags().WithNoRecordedValue(false))
+}
+
+// mergeAggregates merges the sum, min and max of src into dst. An aggregate is only known if it is known
+// for both data points, or if it is known for one of them and the other one has no observations.
+func mergeAggregates(dst, src pmetric.ExponentialHistogramDataPoint) {
+	switch {
+	case src.Count() == 0:
+		return
+	case dst.Count() == 0 || dst.Flags().NoRecordedValue():
+		dst.RemoveSum()
+		dst.RemoveMin()
+		dst.RemoveMax()
+		if src.HasSum() {
+			dst.SetSum(src.Sum())
+		}
+		if src.HasMin() {
+			dst.SetMin(src.Min())
+		}
+		if src.HasMax() {
+			dst.SetMax(src.Max())
+		}
+		return
+	}
+	if dst.HasSum() && src.HasSum() {
+		dst.SetSum(dst.Sum() + src.Sum())
+	} else {
+		dst.RemoveSum()
+	}
+	if dst.HasMin() && src.HasMin() {
+		dst.SetMin(min(dst.Min(), src.Min()))
+	} else {
+		dst.RemoveMin()
+	}
+	if dst.HasMax() && src.HasMax() {
+		dst.SetMax(max(dst.Max(), src.Max()))
+	} else {
+		dst.RemoveMax()
+	}
+}
+
+// removeZeroBuckets removes the buckets of absolute values lower than or equal to the zero threshold,
+// and returns their total count.
+func removeZeroBuckets(b *exphistogram.Buckets, scale int32, zeroThreshold float64) uint64 {
+	var count uint64
+	n := 0
+	for n < len(b.Counts) && exphistogram.LowerBoundary(b.Offset+int32(n)+1, scale) <= zeroThreshold {
+		count += b.Counts[n]
+		n++
+	}
+	b.Offset += int32(n)
+	b.Counts = b.Counts[n:]
+	return count
+}
+
+// Downscale lowers the scale of the data point to the given scale by merging its buckets. It does
+// nothing if the data point already has a lower or equal scale.
+func Downscale(dp pmetric.ExponentialHistogramDataPoint, scale int32) {
+	if scale >= dp.Scale() {
+		return
+	}
+	for _, buckets := range []pmetric.ExponentialHistogramDataPointBuckets{dp.Positive(), dp.Negative()} {
+		b := readBuckets(buckets)
+		b.Downscale(dp.Scale() - scale)
+		writeBuckets(buckets, b)
+	}
+	dp.SetScale(scale)
+}
+
+// DownscaleToMaxBuckets lowers the scale of the data point as little as possible for both its
+// positive and negative buckets to hold at most maxBuckets buckets. The empty buckets at both ends
+// of the positive and negative buckets are removed.
+func DownscaleToMaxBuckets(dp pmetric.ExponentialHistogramDataPoint, maxBuckets int) {
+	positive, negative := readBuckets(dp.Positive()), readBuckets(dp.Negative())
+	positive.Trim()
+	negative.Trim()
+	by := max(positive.ScaleReduction(maxBuckets), negative.ScaleReduction(maxBuckets))
+	by = min(by, dp.Scale()-exphistogram.MinScale)
+	positive.Downscale(by)
+	negative.Downscale(by)
+	writeBuckets(dp.Positive(), positive)
+	writeBuckets(dp.Negative(), negative)
+	dp.SetScale(dp.Scale() - by)
+}
+
+// FromHistogram sets dst to an approximation of the explicit bucket histogram data point src, with at
+// most maxBuckets positive and negative buckets. The observations of each explicit bucket are counted
+// in the exponential bucket of a value representative of the explicit bucket: the middle of its bounds,
+// or the bound of the explicit buckets which are not bounded on one side, constrained by the min and
+// max of src when known. The count, sum, min, max, timestamps, flags, attributes and exemplars are copied.
+func FromHistogram(dst pmetric.ExponentialHistogramDataPoint, src pmetric.HistogramDataPoint, maxBuckets int) {
+	pmetric.NewExponentialHistogramDataPoint().CopyTo(dst)
+	src.Attributes().CopyTo(dst.Attributes())
+	src.Exemplars().CopyTo(dst.Exemplars())
+	dst.SetStartTimestamp(src.StartTimestamp())
+	dst.SetTimestamp(src.Timestamp())
+	dst.SetFlags(src.Flags())
+	dst.SetCount(src.Count())
+	if src.HasSum() {
+		dst.SetSum(src.Sum())
+	}
+	if src.HasMin() {
+		dst.SetMin(src.Min())
+	}
+	if src.HasMax() {
+		dst.SetMax(src.Max())
+	}
+
+	values := make([]float64, src.BucketCounts().Len())
+	for i := range values {
+		values[i] = representativeValue(src, i)
+	}
+	scale := fittingScale(values, src, maxBuckets)
+	var positive, negative exphistogram.Buckets
+	var zeroCount uint64
+	for i, value := range values {
+		count := src.BucketCounts().At(i)
+		switch {
+		case count == 0:
+		case value > 0:
+			positive.Add(exphistogram.Buckets{Offset: exphistogram.Index(value, scale), Counts: []uint64{count}})
+		case value < 0:
+			negative.Add(exphistogram.Buckets{Offset: exphistogram.Index(-value, scale), Counts: []uint64{count}})
+		default:
+			zeroCount += count
+		}
+	}
+	dst.SetScale(scale)
+	dst.SetZeroCount(zeroCount)
+	writeBuckets(dst.Positive(), positive)
+	writeBuckets(dst.Negative(), negative)
+}
+
+// representativeValue returns the value representing the observations of the explicit bucket of the data point.
+func representativeValue(dp pmetric.HistogramDataPoint, i int) float64 {
+	bounds := dp.ExplicitBounds()
+	var value float64
+	switch {
+	case bounds.Len() == 0:
+		// A single bucket holds all the observations.
+		if dp.HasSum() && dp.Count() > 0 {
+			value = dp.Sum() / float64(dp.Count())
+		}
+	case i == 0:
+		value = bounds.At(0)
+		if dp.HasMin() && dp.Min() < value {
+			value = (dp.Min() + value) / 2
+		}
+	case i >= bounds.Len():
+		value = bounds.At(bounds.Len() - 1)
+		if dp.HasMax() && dp.Max() > value {
+			value = (value + dp.Max()) / 2
+		}
+	default:
+		value = (bounds.At(i-1) + bounds.At(i)) / 2
+	}
+	if dp.HasMin() {
+		value = max(value, dp.Min())
+	}
+	if dp.HasMax() {
+		value = min(value, dp.Max())
+	}
+	return value
+}
+
+// fittingScale returns the highest scale at which the exponential buckets of the values of the non-empty
+// explicit buckets of the data point fit in maxBuckets buckets.
+func fittingScale(values []float64, dp pmetric.HistogramDataPoint, maxBuckets int) int32 {
+	var positive, negative []float64
+	for i, value := range values {
+		switch {
+		case dp.BucketCounts().At(i) == 0:
+		case value > 0:
+			positive = append(positive, value)
+		case value < 0:
+			negative = append(negative, -value)
+		}
+	}
+	scale := exphistogram.MaxScale
+	for _, absValues := range [][]float64{positive, negative} {
+		if len(absValues) == 0 {
+			continue
+		}
+		low, high := absValues[0], absValues[0]
+		for _, value := range absValues {
+			low, high = min(low, value), max(high, value)
+		}
+		for scale > exphistogram.MinScale &&
+			exphistogram.ScaleReduction(exphistogram.Index(low, scale), exphistogram.Index(high, scale), maxBuckets) > 0 {
+			scale--
+		}
+	}
+	return scale
+}
+
+func readBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets) exphistogram.Buckets {
+	return exphistogram.Buckets{Offset: buckets.Offset(), Counts: buckets.BucketCounts().AsRaw()}
+}
+
+func writeBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, b exphistogram.Buckets) {
+	buckets.SetOffset(b.Offset)
+	buckets.BucketCounts().FromRaw(b.Counts)
+}
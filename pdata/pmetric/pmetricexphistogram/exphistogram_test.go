@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetricexphistogram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type point struct {
+	start, timestamp pcommon.Timestamp
+	count, zeroCount uint64
+	zeroThreshold    float64
+	scale            int32
+	positiveOffset   int32
+	positive         []uint64
+	negativeOffset   int32
+	negative         []uint64
+}
+
+func newPoint(p point) pmetric.ExponentialHistogramDataPoint {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetStartTimestamp(p.start)
+	dp.SetTimestamp(p.timestamp)
+	dp.SetCount(p.count)
+	dp.SetZeroCount(p.zeroCount)
+	dp.SetZeroThreshold(p.zeroThreshold)
+	dp.SetScale(p.scale)
+	dp.Positive().SetOffset(p.positiveOffset)
+	dp.Positive().BucketCounts().FromRaw(p.positive)
+	dp.Negative().SetOffset(p.negativeOffset)
+	dp.Negative().BucketCounts().FromRaw(p.negative)
+	return dp
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      point
+		src      point
+		expected point
+	}{
+		{
+			name:     "same scale",
+			dst:      point{start: 2, timestamp: 3, count: 4, zeroCount: 1, scale: 1, positiveOffset: 1, positive: []uint64{1, 2}},
+			src:      point{start: 1, timestamp: 4, count: 3, scale: 1, positiveOffset: 2, positive: []uint64{1, 1}, negativeOffset: -1, negative: []uint64{1}},
+			expected: point{start: 1, timestamp: 4, count: 7, zeroCount: 1, scale: 1, positiveOffset: 1, positive: []uint64{1, 3, 1}, negativeOffset: -1, negative: []uint64{1}},
+		},
+		{
+			name:     "lower dst scale",
+			dst:      point{timestamp: 3, count: 1, scale: 0, positiveOffset: 0, positive: []uint64{1}},
+			src:      point{start: 1, timestamp: 2, count: 3, scale: 1, positiveOffset: 1, positive: []uint64{1, 1, 1}},
+			expected: point{start: 1, timestamp: 3, count: 4, scale: 0, positiveOffset: 0, positive: []uint64{2, 2}},
+		},
+		{
+			name:     "lower src scale",
+			dst:      point{count: 3, scale: 2, positiveOffset: -4, positive: []uint64{1, 1, 0, 0, 0, 0, 0, 0, 1}},
+			src:      point{count: 1, scale: 0, positiveOffset: 1, positive: []uint64{1}},
+			expected: point{count: 4, scale: 0, positiveOffset: -1, positive: []uint64{2, 0, 2}},
+		},
+		{
+			name:     "higher src zero threshold",
+			dst:      point{count: 4, zeroCount: 1, scale: 0, positiveOffset: -3, positive: []uint64{1, 1, 1}},
+			src:      point{count: 1, zeroCount: 1, zeroThreshold: 0.5, scale: 0},
+			expected: point{count: 5, zeroCount: 4, zeroThreshold: 0.5, scale: 0, positiveOffset: -1, positive: []uint64{1}},
+		},
+		{
+			name:     "empty",
+			dst:      point{count: 1, scale: 3, positiveOffset: 2, positive: []uint64{1}},
+			src:      point{scale: 5},
+			expected: point{count: 1, scale: 3, positiveOffset: 2, positive: []uint64{1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := newPoint(tt.dst)
+			Merge(dst, newPoint(tt.src))
+			assert.Equal(t, newPoint(tt.expected), dst)
+		})
+	}
+}
+
+func TestMergeAggregates(t *testing.T) {
+	dst := newPoint(point{count: 1})
+	dst.SetSum(1)
+	dst.SetMin(1)
+	dst.SetMax(1)
+	src := newPoint(point{count: 2})
+	src.SetSum(5)
+	src.SetMin(2)
+	src.SetMax(3)
+	Merge(dst, src)
+	assert.Equal(t, 6.0, dst.Sum())
+	assert.Equal(t, 1.0, dst.Min())
+	assert.Equal(t, 3.0, dst.Max())
+
+	src = newPoint(point{count: 1})
+	src.SetSum(1)
+	Merge(dst, src)
+	assert.Equal(t, 7.0, dst.Sum())
+	assert.False(t, dst.HasMin())
+	assert.False(t, dst.HasMax())
+
+	dst = newPoint(point{})
+	dst.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	src = newPoint(point{count: 1})
+	src.SetMin(2)
+	Merge(dst, src)
+	assert.False(t, dst.Flags().NoRecordedValue())
+	assert.False(t, dst.HasSum())
+	assert.Equal(t, 2.0, dst.Min())
+	assert.Equal(t, uint64(1), dst.Count())
+
+	// A data point without recorded value is ignored.
+	src = newPoint(point{count: 1, timestamp: 10})
+	src.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	Merge(dst, src)
+	assert.Equal(t, uint64(1), dst.Count())
+	assert.Equal(t, pcommon.Timestamp(0), dst.Timestamp())
+}
+
+func TestDownscale(t *testing.T) {
+	dp := newPoint(point{count: 6, scale: 2, positiveOffset: -3, positive: []uint64{1, 2, 3}, negativeOffset: 1, negative: []uint64{1, 1}})
+	Downscale(dp, 3)
+	assert.Equal(t, int32(2), dp.Scale())
+	Downscale(dp, 1)
+	assert.Equal(t, newPoint(point{count: 6, scale: 1, positiveOffset: -2, positive: []uint64{1, 5}, negativeOffset: 0, negative: []uint64{1, 1}}), dp)
+}
+
+func TestDownscaleToMaxBuckets(t *testing.T) {
+	dp := newPoint(point{count: 8, scale: 2, positiveOffset: -3, positive: []uint64{0, 1, 2, 3, 0}, negativeOffset: 1, negative: []uint64{1, 1}})
+	DownscaleToMaxBuckets(dp, 3)
+	assert.Equal(t, newPoint(point{count: 8, scale: 2, positiveOffset: -2, positive: []uint64{1, 2, 3}, negativeOffset: 1, negative: []uint64{1, 1}}), dp)
+	DownscaleToMaxBuckets(dp, 2)
+	assert.Equal(t, newPoint(point{count: 8, scale: 1, positiveOffset: -1, positive: []uint64{3, 3}, negativeOffset: 0, negative: []uint64{1, 1}}), dp)
+	DownscaleToMaxBuckets(dp, 1)
+	assert.Equal(t, newPoint(point{count: 8, scale: -10, positiveOffset: -1, positive: []uint64{3, 3}, negativeOffset: 0, negative: []uint64{2}}), dp)
+}
+
+func newHistogram(bounds []float64, counts []uint64) pmetric.HistogramDataPoint {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetStartTimestamp(1)
+	dp.SetTimestamp(2)
+	dp.Attributes().PutStr("key", "value")
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	for _, count := range counts {
+		dp.SetCount(dp.Count() + count)
+	}
+	return dp
+}
+
+func TestFromHistogram(t *testing.T) {
+	src := newHistogram([]float64{0, 1, 2, 4}, []uint64{1, 2, 3, 4, 5})
+	src.SetSum(40)
+	dst := pmetric.NewExponentialHistogramDataPoint()
+	dst.SetZeroThreshold(1)
+	FromHistogram(dst, src, 160)
+
+	// The values representing the buckets are 0, 0.5, 1.5, 3 and 4, of indexes -33, 18, 50 and 63 at scale 5.
+	positive := make([]uint64, 97)
+	positive[0], positive[51], positive[83], positive[96] = 2, 3, 4, 5
+	expected := newPoint(point{start: 1, timestamp: 2, count: 15, zeroCount: 1, scale: 5, positiveOffset: -33, positive: positive})
+	expected.Attributes().PutStr("key", "value")
+	expected.SetSum(40)
+	assert.Equal(t, expected, dst)
+
+	FromHistogram(dst, src, 4)
+	expected = newPoint(point{start: 1, timestamp: 2, count: 15, zeroCount: 1, scale: 0, positiveOffset: -2, positive: []uint64{2, 0, 3, 9}})
+	expected.Attributes().PutStr("key", "value")
+	expected.SetSum(40)
+	assert.Equal(t, expected, dst)
+}
+
+func TestFromHistogramMinMax(t *testing.T) {
+	src := newHistogram([]float64{-1, 1}, []uint64{1, 2, 3})
+	src.SetMin(-3)
+	src.SetMax(2)
+	dst := pmetric.NewExponentialHistogramDataPoint()
+	FromHistogram(dst, src, 2)
+
+	// The values representing the buckets are -2, 0 and 1.5.
+	expected := newPoint(point{start: 1, timestamp: 2, count: 6, zeroCount: 2, scale: 20, positiveOffset: 613377, positive: []uint64{3}, negativeOffset: 1<<20 - 1, negative: []uint64{1}})
+	expected.Attributes().PutStr("key", "value")
+	expected.SetMin(-3)
+	expected.SetMax(2)
+	assert.Equal(t, expected, dst)
+}
+
+func TestFromHistogramSingleBucket(t *testing.T) {
+	src := newHistogram(nil, []uint64{4})
+	src.SetSum(10)
+	dst := pmetric.NewExponentialHistogramDataPoint()
+	FromHistogram(dst, src, 10)
+	assert.Equal(t, uint64(4), dst.Positive().BucketCounts().At(0))
+	assert.Equal(t, int32(20), dst.Scale())
+
+	src = newHistogram(nil, []uint64{0})
+	FromHistogram(dst, src, 10)
+	assert.Equal(t, 0, dst.Positive().BucketCounts().Len())
+	assert.Equal(t, uint64(0), dst.ZeroCount())
+}
@@ -39,12 +39,15 @@ type grpcClient struct {
 }
 
 func (c *grpcClient) Export(ctx context.Context, request ExportRequest, opts ...grpc.CallOption) (ExportResponse, error) {
+	// The request is read while it is sent.
+	request.state.RLock()
+	defer request.state.RUnlock()
 	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
 	if err != nil {
 		return ExportResponse{}, err
 	}
-	state := internal.StateMutable
-	return ExportResponse{orig: rsp, state: &state}, err
+	state := internal.NewState()
+	return ExportResponse{orig: rsp, state: state}, err
 }
 
 func (c *grpcClient) unexported() {}
@@ -84,7 +87,7 @@ type rawMetricsServer struct {
 
 func (s rawMetricsServer) Export(ctx context.Context, request *otlpcollectormetrics.ExportMetricsServiceRequest) (*otlpcollectormetrics.ExportMetricsServiceResponse, error) {
 	otlp.MigrateMetrics(request.ResourceMetrics)
-	state := internal.StateMutable
-	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: &state})
+	state := internal.NewState()
+	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: state})
 	return rsp.orig, err
 }
@@ -23,10 +23,10 @@ type ExportRequest struct {
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
-	state := internal.StateMutable
+	state := internal.NewState()
 	return ExportRequest{
 		orig:  &otlpcollectormetrics.ExportMetricsServiceRequest{},
-		state: &state,
+		state: state,
 	}
 }
 
@@ -42,6 +42,8 @@ func NewExportRequestFromMetrics(md pmetric.Metrics) ExportRequest {
 
 // MarshalProto marshals ExportRequest into proto bytes.
 func (ms ExportRequest) MarshalProto() ([]byte, error) {
+	ms.state.RLock()
+	defer ms.state.RUnlock()
 	return ms.orig.Marshal()
 }
 
@@ -52,6 +54,8 @@ func (ms ExportRequest) UnmarshalProto(data []byte) error {
 
 // MarshalJSON marshals ExportRequest into JSON bytes.
 func (ms ExportRequest) MarshalJSON() ([]byte, error) {
+	ms.state.RLock()
+	defer ms.state.RUnlock()
 	var buf bytes.Buffer
 	if err := json.Marshal(&buf, ms.orig); err != nil {
 		return nil, err
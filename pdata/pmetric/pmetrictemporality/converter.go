@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pmetrictemporality converts the Sum, Histogram and ExponentialHistogram metrics
+// between the cumulative and the delta aggregation temporalities.
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/phash"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Converter converts the aggregation temporality of metrics. It is stateful: it tracks each stream,
+// identified by its resource, scope, metric and data point attributes, to convert its data points
+// relatively to the previous ones of the stream.
+//
+// A stream starts again when its data points cannot follow the previous ones: the start timestamp of
+// a cumulative data point changed, a cumulative value decreased, the buckets of a histogram changed, or
+// delta data points are not contiguous. A data point overlapping the previous ones of its stream is
+// out of order, and dropped. A data point with the NoRecordedValue flag is kept as is, and starts
+// the stream again.
+//
+// A Converter can be used concurrently.
+type Converter struct {
+	mu           sync.Mutex
+	target       pmetric.AggregationTemporality
+	maxStaleness time.Duration
+	now          func() time.Time
+	nextExpiry   time.Time
+	streams      map[phash.Hash]*stream
+}
+
+type stream struct {
+	// state is the last cumulative state of the stream: the one of the last received data point
+	// when converting to delta, and the accumulated one when converting to cumulative. It is one
+	// of *numberState, *histogramState and *expHistogramState, or nil if the stream starts again.
+	state    any
+	lastSeen time.Time
+}
+
+// NewCumulativeToDelta returns a Converter converting the cumulative metrics to delta. The first data
+// point of a stream is converted if it has a start timestamp, the value since the start of the stream
+// being its delta. Otherwise, it is dropped.
+//
+// The streams which received no data point for longer than maxStaleness are forgotten, the next data
+// point of such a stream is its first one. A zero maxStaleness means that the streams are never forgotten.
+func NewCumulativeToDelta(maxStaleness time.Duration) *Converter {
+	return newConverter(pmetric.AggregationTemporalityDelta, maxStaleness)
+}
+
+// NewDeltaToCumulative returns a Converter converting the delta metrics to cumulative, by accumulating
+// the data points of each stream since the start timestamp of its first data point.
+//
+// The streams which received no data point for longer than maxStaleness are forgotten, the next data
+// point of such a stream is its first one. A zero maxStaleness means that the streams are never forgotten.
+func NewDeltaToCumulative(maxStaleness time.Duration) *Converter {
+	return newConverter(pmetric.AggregationTemporalityCumulative, maxStaleness)
+}
+
+func newConverter(target pmetric.AggregationTemporality, maxStaleness time.Duration) *Converter {
+	return &Converter{
+		target:       target,
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+		streams:      make(map[phash.Hash]*stream),
+	}
+}
+
+// ConvertMetrics converts in place the Sum, Histogram and ExponentialHistogram metrics of md which do not
+// have the target aggregation temporality. The metrics left without data points by the conversion are
+// removed, and so are the scopes and resources left without metrics.
+func (c *Converter) ConvertMetrics(md pmetric.Metrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resource := phash.Resource(rm.Resource())
+		removedScopes := false
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scope := phash.InstrumentationScope(sm.Scope())
+			removedMetrics := false
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				removed := c.convertMetric(resource, scope, m, now)
+				removedMetrics = removedMetrics || removed
+				return removed
+			})
+			removed := removedMetrics && sm.Metrics().Len() == 0
+			removedScopes = removedScopes || removed
+			return removed
+		})
+		return removedScopes && rm.ScopeMetrics().Len() == 0
+	})
+
+	c.expire(now)
+}
+
+// convertMetric converts the metric, and returns true if the conversion left it without data points.
+func (c *Converter) convertMetric(resource, scope phash.Hash, m pmetric.Metric, now time.Time) bool {
+	metric := phash.Metric(m)
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		if !c.converts(sum.AggregationTemporality()) {
+			return false
+		}
+		dps := sum.DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			st := c.stream(phash.MetricStream(resource, scope, metric, dp.Attributes()), now)
+			if c.target == pmetric.AggregationTemporalityDelta {
+				return !numberToDelta(st, dp, sum.IsMonotonic())
+			}
+			return !numberToCumulative(st, dp)
+		})
+		sum.SetAggregationTemporality(c.target)
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeHistogram:
+		histogram := m.Histogram()
+		if !c.converts(histogram.AggregationTemporality()) {
+			return false
+		}
+		dps := histogram.DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			st := c.stream(phash.MetricStream(resource, scope, metric, dp.Attributes()), now)
+			if c.target == pmetric.AggregationTemporalityDelta {
+				return !histogramToDelta(st, dp)
+			}
+			return !histogramToCumulative(st, dp)
+		})
+		histogram.SetAggregationTemporality(c.target)
+		return n > 0 && dps.Len() == 0
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := m.ExponentialHistogram()
+		if !c.converts(histogram.AggregationTemporality()) {
+			return false
+		}
+		dps := histogram.DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			st := c.stream(phash.MetricStream(resource, scope, metric, dp.Attributes()), now)
+			if c.target == pmetric.AggregationTemporalityDelta {
+				return !expHistogramToDelta(st, dp)
+			}
+			return !expHistogramToCumulative(st, dp)
+		})
+		histogram.SetAggregationTemporality(c.target)
+		return n > 0 && dps.Len() == 0
+	}
+	return false
+}
+
+// converts returns true if the metrics with the aggregation temporality are converted.
+func (c *Converter) converts(temporality pmetric.AggregationTemporality) bool {
+	return temporality != c.target && temporality != pmetric.AggregationTemporalityUnspecified
+}
+
+// stream returns the stream identified by the hash, seen at the given time.
+func (c *Converter) stream(hash phash.Hash, now time.Time) *stream {
+	st, ok := c.streams[hash]
+	if !ok {
+		st = &stream{}
+		c.streams[hash] = st
+	}
+	st.lastSeen = now
+	return st
+}
+
+// expire forgets the stale streams. As the streams are only stale after maxStaleness,
+// they are only looked for every half maxStaleness.
+func (c *Converter) expire(now time.Time) {
+	if c.maxStaleness <= 0 || now.Before(c.nextExpiry) {
+		return
+	}
+	c.nextExpiry = now.Add(c.maxStaleness / 2)
+	for hash, st := range c.streams {
+		if now.Sub(st.lastSeen) > c.maxStaleness {
+			delete(c.streams, hash)
+		}
+	}
+}
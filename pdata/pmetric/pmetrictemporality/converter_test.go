@@ -0,0 +1,444 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type numberPoint struct {
+	start, timestamp pcommon.Timestamp
+	value            int64
+	noRecordedValue  bool
+}
+
+func newMetric(md pmetric.Metrics) pmetric.Metric {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("metric")
+	return m
+}
+
+func newSum(temporality pmetric.AggregationTemporality, monotonic bool, points ...numberPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	sum := newMetric(md).SetEmptySum()
+	sum.SetAggregationTemporality(temporality)
+	sum.SetIsMonotonic(monotonic)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(p.start)
+		dp.SetTimestamp(p.timestamp)
+		dp.SetIntValue(p.value)
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(p.noRecordedValue))
+	}
+	return md
+}
+
+func TestCumulativeToDeltaSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    numberPoint
+		expected []numberPoint
+	}{
+		{
+			name:     "first with start",
+			input:    numberPoint{start: 1, timestamp: 2, value: 5},
+			expected: []numberPoint{{start: 1, timestamp: 2, value: 5}},
+		},
+		{
+			name:     "increase",
+			input:    numberPoint{start: 1, timestamp: 3, value: 8},
+			expected: []numberPoint{{start: 2, timestamp: 3, value: 3}},
+		},
+		{
+			name:  "duplicate",
+			input: numberPoint{start: 1, timestamp: 3, value: 8},
+		},
+		{
+			name:     "start reset",
+			input:    numberPoint{start: 4, timestamp: 5, value: 2},
+			expected: []numberPoint{{start: 4, timestamp: 5, value: 2}},
+		},
+		{
+			name:     "decrease reset",
+			input:    numberPoint{start: 4, timestamp: 6, value: 1},
+			expected: []numberPoint{{start: 5, timestamp: 6, value: 1}},
+		},
+		{
+			name:     "no recorded value",
+			input:    numberPoint{start: 4, timestamp: 7, noRecordedValue: true},
+			expected: []numberPoint{{start: 4, timestamp: 7, noRecordedValue: true}},
+		},
+		{
+			name:  "first without start",
+			input: numberPoint{timestamp: 8, value: 10},
+		},
+		{
+			name:     "increase without start",
+			input:    numberPoint{timestamp: 9, value: 15},
+			expected: []numberPoint{{start: 8, timestamp: 9, value: 5}},
+		},
+	}
+	c := NewCumulativeToDelta(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newSum(pmetric.AggregationTemporalityCumulative, true, tt.input)
+			c.ConvertMetrics(md)
+			if len(tt.expected) == 0 {
+				// The resource left without data points is removed.
+				assert.Equal(t, 0, md.ResourceMetrics().Len())
+				return
+			}
+			assert.Equal(t, newSum(pmetric.AggregationTemporalityDelta, true, tt.expected...), md)
+		})
+	}
+}
+
+func TestCumulativeToDeltaNonMonotonicSum(t *testing.T) {
+	c := NewCumulativeToDelta(0)
+	md := newSum(pmetric.AggregationTemporalityCumulative, false,
+		numberPoint{start: 1, timestamp: 2, value: 5},
+		numberPoint{start: 1, timestamp: 3, value: 2})
+	c.ConvertMetrics(md)
+	assert.Equal(t, newSum(pmetric.AggregationTemporalityDelta, false,
+		numberPoint{start: 1, timestamp: 2, value: 5},
+		numberPoint{start: 2, timestamp: 3, value: -3}), md)
+}
+
+func TestDeltaToCumulativeSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    numberPoint
+		expected []numberPoint
+	}{
+		{
+			name:     "first",
+			input:    numberPoint{start: 1, timestamp: 2, value: 5},
+			expected: []numberPoint{{start: 1, timestamp: 2, value: 5}},
+		},
+		{
+			name:     "contiguous",
+			input:    numberPoint{start: 2, timestamp: 3, value: 3},
+			expected: []numberPoint{{start: 1, timestamp: 3, value: 8}},
+		},
+		{
+			name:     "without start",
+			input:    numberPoint{timestamp: 4, value: 1},
+			expected: []numberPoint{{start: 1, timestamp: 4, value: 9}},
+		},
+		{
+			name:  "overlap",
+			input: numberPoint{start: 3, timestamp: 5, value: 1},
+		},
+		{
+			name:     "gap",
+			input:    numberPoint{start: 6, timestamp: 7, value: 2},
+			expected: []numberPoint{{start: 6, timestamp: 7, value: 2}},
+		},
+		{
+			name:     "no recorded value",
+			input:    numberPoint{start: 7, timestamp: 8, noRecordedValue: true},
+			expected: []numberPoint{{start: 7, timestamp: 8, noRecordedValue: true}},
+		},
+		{
+			name:     "after no recorded value",
+			input:    numberPoint{start: 8, timestamp: 9, value: 4},
+			expected: []numberPoint{{start: 8, timestamp: 9, value: 4}},
+		},
+	}
+	c := NewDeltaToCumulative(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newSum(pmetric.AggregationTemporalityDelta, true, tt.input)
+			c.ConvertMetrics(md)
+			if len(tt.expected) == 0 {
+				assert.Equal(t, 0, md.ResourceMetrics().Len())
+				return
+			}
+			assert.Equal(t, newSum(pmetric.AggregationTemporalityCumulative, true, tt.expected...), md)
+		})
+	}
+}
+
+func TestDeltaToCumulativeDoubleSum(t *testing.T) {
+	c := NewDeltaToCumulative(0)
+	md := pmetric.NewMetrics()
+	sum := newMetric(md).SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for i := 1; i <= 3; i++ {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(i))
+		dp.SetTimestamp(pcommon.Timestamp(i + 1))
+		dp.SetDoubleValue(1.5)
+	}
+	c.ConvertMetrics(md)
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, pcommon.Timestamp(1), sum.DataPoints().At(i).StartTimestamp())
+		assert.Equal(t, 1.5*float64(i+1), sum.DataPoints().At(i).DoubleValue())
+	}
+}
+
+func TestConvertStreams(t *testing.T) {
+	c := NewDeltaToCumulative(0)
+	md := pmetric.NewMetrics()
+	for _, name := range []string{"a", "b"} {
+		sum := newMetric(md).SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for i, attr := range []string{"x", "y", "x"} {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("attr", attr)
+			dp.Attributes().PutStr("name", name)
+			dp.SetTimestamp(pcommon.Timestamp(i + 1))
+			dp.SetIntValue(1)
+		}
+	}
+	// Another resource.
+	gauge := newMetric(md).SetEmptyGauge()
+	gauge.DataPoints().AppendEmpty().SetIntValue(1)
+	md.ResourceMetrics().At(2).Resource().Attributes().PutStr("other", "resource")
+	sum := md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().AppendEmpty().SetEmptySum()
+	md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().At(1).SetName("metric")
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.DataPoints().AppendEmpty().SetIntValue(1)
+	sum.DataPoints().At(0).SetTimestamp(1)
+	sum.DataPoints().At(0).Attributes().PutStr("attr", "x")
+	sum.DataPoints().At(0).Attributes().PutStr("name", "a")
+	c.ConvertMetrics(md)
+
+	// Points without start timestamps are contiguous, the ones of the same stream are accumulated.
+	for i := 0; i < 2; i++ {
+		dps := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		assert.Equal(t, int64(1), dps.At(0).IntValue())
+		assert.Equal(t, int64(1), dps.At(1).IntValue())
+		assert.Equal(t, int64(2), dps.At(2).IntValue())
+	}
+	assert.Equal(t, int64(1), sum.DataPoints().At(0).IntValue())
+	// The gauge is not converted.
+	assert.Equal(t, int64(1), gauge.DataPoints().At(0).IntValue())
+	assert.Len(t, c.streams, 5)
+}
+
+func TestConvertAttributeIdentity(t *testing.T) {
+	c := NewDeltaToCumulative(0)
+	md := newSum(pmetric.AggregationTemporalityDelta, true, numberPoint{timestamp: 1, value: 1}, numberPoint{timestamp: 2, value: 1})
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	// The order of the attributes does not matter.
+	dps.At(0).Attributes().PutStr("a", "1")
+	dps.At(0).Attributes().PutInt("b", 2)
+	dps.At(1).Attributes().PutInt("b", 2)
+	dps.At(1).Attributes().PutStr("a", "1")
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(2), dps.At(1).IntValue())
+
+	// Attribute values of different types are different.
+	md = newSum(pmetric.AggregationTemporalityDelta, true, numberPoint{timestamp: 3, value: 1}, numberPoint{timestamp: 4, value: 1})
+	dps = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	dps.At(0).Attributes().PutStr("a", "1")
+	dps.At(1).Attributes().PutInt("a", 1)
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(1), dps.At(1).IntValue())
+
+	// Nested values are part of the identity.
+	md = newSum(pmetric.AggregationTemporalityDelta, true, numberPoint{timestamp: 3, value: 1}, numberPoint{timestamp: 4, value: 1})
+	dps = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	dps.At(0).Attributes().PutEmptySlice("a").AppendEmpty().SetEmptyMap().PutBool("b", true)
+	dps.At(1).Attributes().PutEmptySlice("a").AppendEmpty().SetEmptyMap().PutBool("b", false)
+	c.ConvertMetrics(md)
+	assert.Equal(t, int64(1), dps.At(1).IntValue())
+}
+
+func TestConvertMetricsUnchanged(t *testing.T) {
+	c := NewCumulativeToDelta(0)
+	md := newSum(pmetric.AggregationTemporalityDelta, true, numberPoint{start: 1, timestamp: 2, value: 5})
+	gauge := newMetric(md).SetEmptyGauge()
+	gauge.DataPoints().AppendEmpty().SetIntValue(1)
+	summary := newMetric(md).SetEmptySummary()
+	summary.DataPoints().AppendEmpty().SetCount(1)
+	newMetric(md).SetEmptySum()
+	expected := pmetric.NewMetrics()
+	md.CopyTo(expected)
+
+	c.ConvertMetrics(md)
+	assert.Equal(t, expected, md)
+	assert.Empty(t, c.streams)
+}
+
+func TestConvertStaleness(t *testing.T) {
+	c := NewCumulativeToDelta(time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	md := newSum(pmetric.AggregationTemporalityCumulative, true, numberPoint{timestamp: 1, value: 1})
+	c.ConvertMetrics(md)
+	assert.Len(t, c.streams, 1)
+
+	now = now.Add(time.Minute)
+	md = newSum(pmetric.AggregationTemporalityCumulative, true, numberPoint{timestamp: 2, value: 2})
+	c.ConvertMetrics(md)
+	assert.Equal(t, newSum(pmetric.AggregationTemporalityDelta, true, numberPoint{start: 1, timestamp: 2, value: 1}), md)
+
+	now = now.Add(time.Minute + time.Second)
+	c.ConvertMetrics(pmetric.NewMetrics())
+	assert.Empty(t, c.streams)
+
+	// The stream is forgotten, its next data point is its first one.
+	md = newSum(pmetric.AggregationTemporalityCumulative, true, numberPoint{timestamp: 3, value: 3})
+	c.ConvertMetrics(md)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	assert.Len(t, c.streams, 1)
+}
+
+type histogramPoint struct {
+	start, timestamp pcommon.Timestamp
+	count            uint64
+	sum              float64
+	min, max         float64
+	bounds           []float64
+	buckets          []uint64
+}
+
+func newHistogram(temporality pmetric.AggregationTemporality, points ...histogramPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	histogram := newMetric(md).SetEmptyHistogram()
+	histogram.SetAggregationTemporality(temporality)
+	for _, p := range points {
+		dp := histogram.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(p.start)
+		dp.SetTimestamp(p.timestamp)
+		dp.SetCount(p.count)
+		dp.SetSum(p.sum)
+		if p.min != 0 {
+			dp.SetMin(p.min)
+		}
+		if p.max != 0 {
+			dp.SetMax(p.max)
+		}
+		dp.ExplicitBounds().FromRaw(p.bounds)
+		dp.BucketCounts().FromRaw(p.buckets)
+	}
+	return md
+}
+
+func TestCumulativeToDeltaHistogram(t *testing.T) {
+	c := NewCumulativeToDelta(0)
+	md := newHistogram(pmetric.AggregationTemporalityCumulative,
+		histogramPoint{start: 1, timestamp: 2, count: 3, sum: 6, min: 1, max: 3, bounds: []float64{2}, buckets: []uint64{1, 2}},
+		histogramPoint{start: 1, timestamp: 3, count: 5, sum: 11, min: 1, max: 3, bounds: []float64{2}, buckets: []uint64{2, 3}},
+		// Decreasing bucket.
+		histogramPoint{start: 1, timestamp: 4, count: 5, sum: 12, min: 1, max: 4, bounds: []float64{2}, buckets: []uint64{1, 4}},
+		// Changed buckets.
+		histogramPoint{start: 1, timestamp: 5, count: 5, sum: 12, bounds: []float64{3}, buckets: []uint64{3, 2}},
+		histogramPoint{start: 1, timestamp: 6, count: 7, sum: 20, bounds: []float64{3}, buckets: []uint64{3, 4}},
+	)
+	c.ConvertMetrics(md)
+	assert.Equal(t, newHistogram(pmetric.AggregationTemporalityDelta,
+		histogramPoint{start: 1, timestamp: 2, count: 3, sum: 6, min: 1, max: 3, bounds: []float64{2}, buckets: []uint64{1, 2}},
+		histogramPoint{start: 2, timestamp: 3, count: 2, sum: 5, bounds: []float64{2}, buckets: []uint64{1, 1}},
+		histogramPoint{start: 3, timestamp: 4, count: 5, sum: 12, min: 1, max: 4, bounds: []float64{2}, buckets: []uint64{1, 4}},
+		histogramPoint{start: 1, timestamp: 5, count: 5, sum: 12, bounds: []float64{3}, buckets: []uint64{3, 2}},
+		histogramPoint{start: 5, timestamp: 6, count: 2, sum: 8, bounds: []float64{3}, buckets: []uint64{0, 2}},
+	), md)
+}
+
+func TestDeltaToCumulativeHistogram(t *testing.T) {
+	c := NewDeltaToCumulative(0)
+	md := newHistogram(pmetric.AggregationTemporalityDelta,
+		histogramPoint{start: 1, timestamp: 2, count: 3, sum: 6, min: 1, max: 3, bounds: []float64{2}, buckets: []uint64{1, 2}},
+		histogramPoint{start: 2, timestamp: 3, count: 2, sum: 5, min: 0.5, max: 2.5, bounds: []float64{2}, buckets: []uint64{1, 1}},
+		// Overlapping.
+		histogramPoint{start: 2, timestamp: 4, count: 2, sum: 5, bounds: []float64{2}, buckets: []uint64{1, 1}},
+		// Changed buckets.
+		histogramPoint{start: 3, timestamp: 4, count: 1, sum: 1, min: 1, max: 1, bounds: []float64{3}, buckets: []uint64{1, 0}},
+	)
+	c.ConvertMetrics(md)
+	assert.Equal(t, newHistogram(pmetric.AggregationTemporalityCumulative,
+		histogramPoint{start: 1, timestamp: 2, count: 3, sum: 6, min: 1, max: 3, bounds: []float64{2}, buckets: []uint64{1, 2}},
+		histogramPoint{start: 1, timestamp: 3, count: 5, sum: 11, min: 0.5, max: 3, bounds: []float64{2}, buckets: []uint64{2, 3}},
+		histogramPoint{start: 3, timestamp: 4, count: 1, sum: 1, min: 1, max: 1, bounds: []float64{3}, buckets: []uint64{1, 0}},
+	), md)
+
+	// A data point without min or max does not have an accumulated min or max.
+	md = newHistogram(pmetric.AggregationTemporalityDelta,
+		histogramPoint{start: 4, timestamp: 5, count: 1, sum: 1, bounds: []float64{3}, buckets: []uint64{1, 0}})
+	c.ConvertMetrics(md)
+	assert.Equal(t, newHistogram(pmetric.AggregationTemporalityCumulative,
+		histogramPoint{start: 3, timestamp: 5, count: 2, sum: 2, bounds: []float64{3}, buckets: []uint64{2, 0}}), md)
+}
+
+type expHistogramPoint struct {
+	start, timestamp pcommon.Timestamp
+	count, zeroCount uint64
+	scale            int32
+	offset           int32
+	buckets          []uint64
+}
+
+func newExpHistogram(temporality pmetric.AggregationTemporality, points ...expHistogramPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	histogram := newMetric(md).SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(temporality)
+	for _, p := range points {
+		dp := histogram.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(p.start)
+		dp.SetTimestamp(p.timestamp)
+		dp.SetCount(p.count)
+		dp.SetZeroCount(p.zeroCount)
+		dp.SetScale(p.scale)
+		dp.Positive().SetOffset(p.offset)
+		dp.Positive().BucketCounts().FromRaw(p.buckets)
+	}
+	return md
+}
+
+func TestCumulativeToDeltaExpHistogram(t *testing.T) {
+	c := NewCumulativeToDelta(0)
+	md := newExpHistogram(pmetric.AggregationTemporalityCumulative,
+		expHistogramPoint{start: 1, timestamp: 2, count: 4, zeroCount: 1, scale: 1, offset: 2, buckets: []uint64{1, 2}},
+		// Extended buckets.
+		expHistogramPoint{start: 1, timestamp: 3, count: 7, zeroCount: 1, scale: 1, offset: 1, buckets: []uint64{1, 2, 3}},
+		// Downscaled: the buckets 1, 2 and 3 at scale 1 are the buckets 0, 1 and 1 at scale 0.
+		expHistogramPoint{start: 1, timestamp: 4, count: 9, zeroCount: 2, scale: 0, offset: 0, buckets: []uint64{2, 6}},
+		// Missing bucket.
+		expHistogramPoint{start: 1, timestamp: 5, count: 9, zeroCount: 2, scale: 0, offset: 1, buckets: []uint64{7}},
+		// Upscaled.
+		expHistogramPoint{start: 1, timestamp: 6, count: 9, zeroCount: 2, scale: 1, offset: 2, buckets: []uint64{7}},
+	)
+	c.ConvertMetrics(md)
+	assert.Equal(t, newExpHistogram(pmetric.AggregationTemporalityDelta,
+		expHistogramPoint{start: 1, timestamp: 2, count: 4, zeroCount: 1, scale: 1, offset: 2, buckets: []uint64{1, 2}},
+		expHistogramPoint{start: 2, timestamp: 3, count: 3, zeroCount: 0, scale: 1, offset: 1, buckets: []uint64{1, 1, 1}},
+		expHistogramPoint{start: 3, timestamp: 4, count: 2, zeroCount: 1, scale: 0, offset: 0, buckets: []uint64{1, 1}},
+		expHistogramPoint{start: 4, timestamp: 5, count: 9, zeroCount: 2, scale: 0, offset: 1, buckets: []uint64{7}},
+		expHistogramPoint{start: 5, timestamp: 6, count: 9, zeroCount: 2, scale: 1, offset: 2, buckets: []uint64{7}},
+	), md)
+}
+
+func TestDeltaToCumulativeExpHistogram(t *testing.T) {
+	c := NewDeltaToCumulative(0)
+	md := newExpHistogram(pmetric.AggregationTemporalityDelta,
+		expHistogramPoint{start: 1, timestamp: 2, count: 3, zeroCount: 1, scale: 1, offset: 2, buckets: []uint64{1, 1}},
+		expHistogramPoint{start: 2, timestamp: 3, count: 3, scale: 1, offset: -1, buckets: []uint64{1, 0, 0, 0, 0, 1, 1}},
+		// Lower scale: the buckets -1, 2, 3, 4 and 5 at scale 1 are the buckets -1, 1, 1, 2 and 2 at scale 0.
+		expHistogramPoint{start: 3, timestamp: 4, count: 1, scale: 0, offset: 0, buckets: []uint64{1}},
+		// Higher scale: the bucket 7 at scale 2 is the bucket 1 at scale 0.
+		expHistogramPoint{start: 4, timestamp: 5, count: 1, scale: 2, offset: 7, buckets: []uint64{1}},
+	)
+	c.ConvertMetrics(md)
+	assert.Equal(t, newExpHistogram(pmetric.AggregationTemporalityCumulative,
+		expHistogramPoint{start: 1, timestamp: 2, count: 3, zeroCount: 1, scale: 1, offset: 2, buckets: []uint64{1, 1}},
+		expHistogramPoint{start: 1, timestamp: 3, count: 6, zeroCount: 1, scale: 1, offset: -1, buckets: []uint64{1, 0, 0, 1, 1, 1, 1}},
+		expHistogramPoint{start: 1, timestamp: 4, count: 7, zeroCount: 1, scale: 0, offset: -1, buckets: []uint64{1, 1, 2, 2}},
+		expHistogramPoint{start: 1, timestamp: 5, count: 8, zeroCount: 1, scale: 0, offset: -1, buckets: []uint64{1, 1, 3, 2}},
+	), md)
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"go.opentelemetry.io/collector/pdata/internal/exphistogram"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func setBuckets(b *exphistogram.Buckets, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	b.Offset = buckets.Offset()
+	b.Counts = b.Counts[:0]
+	for i := 0; i < buckets.BucketCounts().Len(); i++ {
+		b.Counts = append(b.Counts, buckets.BucketCounts().At(i))
+	}
+}
+
+func writeBuckets(b exphistogram.Buckets, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	buckets.SetOffset(b.Offset)
+	buckets.BucketCounts().FromRaw(b.Counts)
+}
+
+// coversBuckets returns true if the counts of the data point buckets are at least the ones of the buckets at the same scale.
+func coversBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, b exphistogram.Buckets) bool {
+	for i, count := range b.Counts {
+		if count == 0 {
+			continue
+		}
+		j := int(b.Offset + int32(i) - buckets.Offset())
+		if j < 0 || j >= buckets.BucketCounts().Len() || buckets.BucketCounts().At(j) < count {
+			return false
+		}
+	}
+	return true
+}
+
+// subtractBuckets subtracts the counts of the buckets from the covering data point buckets at the same scale.
+func subtractBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, b exphistogram.Buckets) {
+	for i, count := range b.Counts {
+		if count == 0 {
+			continue
+		}
+		j := int(b.Offset + int32(i) - buckets.Offset())
+		buckets.BucketCounts().SetAt(j, buckets.BucketCounts().At(j)-count)
+	}
+}
+
+type expHistogramState struct {
+	start         pcommon.Timestamp
+	timestamp     pcommon.Timestamp
+	count         uint64
+	zeroCount     uint64
+	zeroThreshold float64
+	scale         int32
+	aggregates
+	positive exphistogram.Buckets
+	negative exphistogram.Buckets
+}
+
+func (s *expHistogramState) set(dp pmetric.ExponentialHistogramDataPoint) {
+	s.start = dp.StartTimestamp()
+	s.timestamp = dp.Timestamp()
+	s.count = dp.Count()
+	s.zeroCount = dp.ZeroCount()
+	s.zeroThreshold = dp.ZeroThreshold()
+	s.scale = dp.Scale()
+	s.aggregates.set(dp)
+	setBuckets(&s.positive, dp.Positive())
+	setBuckets(&s.negative, dp.Negative())
+}
+
+func (s *expHistogramState) downscale(scale int32) {
+	s.positive.Downscale(s.scale - scale)
+	s.negative.Downscale(s.scale - scale)
+	s.scale = scale
+}
+
+// follows returns true if the cumulative data point accumulates the counts of the state. The scale of
+// a cumulative histogram can only decrease, the state is downscaled to the one of the data point.
+func (s *expHistogramState) follows(dp pmetric.ExponentialHistogramDataPoint) bool {
+	if dp.Scale() > s.scale || dp.ZeroThreshold() != s.zeroThreshold ||
+		dp.Count() < s.count || dp.ZeroCount() < s.zeroCount {
+		return false
+	}
+	s.downscale(dp.Scale())
+	return coversBuckets(dp.Positive(), s.positive) && coversBuckets(dp.Negative(), s.negative)
+}
+
+// expHistogramToDelta converts the cumulative data point to delta, it returns false if the data point is dropped.
+func expHistogramToDelta(st *stream, dp pmetric.ExponentialHistogramDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	prev, ok := st.state.(*expHistogramState)
+	if ok && dp.Timestamp() <= prev.timestamp {
+		return false
+	}
+	if !ok || dp.StartTimestamp() != prev.start {
+		if !ok {
+			prev = &expHistogramState{}
+			st.state = prev
+		}
+		prev.set(dp)
+		return startsStream(dp.StartTimestamp())
+	}
+
+	last := prev.timestamp
+	if !prev.follows(dp) {
+		// The histogram was reset since the previous data point, its whole
+		// value was accumulated since then.
+		prev.set(dp)
+		dp.SetStartTimestamp(last)
+		return true
+	}
+	cur := &expHistogramState{}
+	cur.set(dp)
+	st.state = cur
+	dp.SetStartTimestamp(last)
+	dp.SetCount(dp.Count() - prev.count)
+	dp.SetZeroCount(dp.ZeroCount() - prev.zeroCount)
+	prev.aggregates.toDelta(dp)
+	subtractBuckets(dp.Positive(), prev.positive)
+	subtractBuckets(dp.Negative(), prev.negative)
+	return true
+}
+
+// expHistogramToCumulative converts the delta data point to cumulative, it returns false if the data point is dropped.
+func expHistogramToCumulative(st *stream, dp pmetric.ExponentialHistogramDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	acc, ok := st.state.(*expHistogramState)
+	if ok && overlaps(dp.StartTimestamp(), dp.Timestamp(), acc.timestamp) {
+		return false
+	}
+	if !ok || dp.ZeroThreshold() != acc.zeroThreshold || isGap(dp.StartTimestamp(), acc.timestamp) {
+		if !ok {
+			acc = &expHistogramState{}
+			st.state = acc
+		}
+		acc.set(dp)
+		return true
+	}
+
+	// The accumulated histogram has the lowest scale of its data points.
+	var delta expHistogramState
+	delta.set(dp)
+	scale := min(acc.scale, delta.scale)
+	acc.downscale(scale)
+	delta.downscale(scale)
+	acc.timestamp = delta.timestamp
+	acc.count += delta.count
+	acc.zeroCount += delta.zeroCount
+	acc.aggregates.add(delta.aggregates)
+	acc.positive.Add(delta.positive)
+	acc.negative.Add(delta.negative)
+
+	dp.SetStartTimestamp(acc.start)
+	dp.SetCount(acc.count)
+	dp.SetZeroCount(acc.zeroCount)
+	dp.SetScale(acc.scale)
+	acc.aggregates.writeTo(dp)
+	writeBuckets(acc.positive, dp.Positive())
+	writeBuckets(acc.negative, dp.Negative())
+	return true
+}
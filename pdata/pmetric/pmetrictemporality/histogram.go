@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// histogramDataPoint is implemented by both pmetric.HistogramDataPoint and pmetric.ExponentialHistogramDataPoint.
+type histogramDataPoint interface {
+	HasSum() bool
+	Sum() float64
+	SetSum(float64)
+	RemoveSum()
+	HasMin() bool
+	Min() float64
+	SetMin(float64)
+	RemoveMin()
+	HasMax() bool
+	Max() float64
+	SetMax(float64)
+	RemoveMax()
+}
+
+// aggregates holds the optional sum, min and max of a histogram data point.
+type aggregates struct {
+	sum, min, max          float64
+	hasSum, hasMin, hasMax bool
+}
+
+func (a *aggregates) set(dp histogramDataPoint) {
+	*a = aggregates{
+		sum:    dp.Sum(),
+		min:    dp.Min(),
+		max:    dp.Max(),
+		hasSum: dp.HasSum(),
+		hasMin: dp.HasMin(),
+		hasMax: dp.HasMax(),
+	}
+}
+
+// add accumulates the aggregates of a following delta data point.
+func (a *aggregates) add(o aggregates) {
+	a.sum += o.sum
+	a.min = min(a.min, o.min)
+	a.max = max(a.max, o.max)
+	a.hasSum = a.hasSum && o.hasSum
+	a.hasMin = a.hasMin && o.hasMin
+	a.hasMax = a.hasMax && o.hasMax
+}
+
+// writeTo sets the aggregates of the data point.
+func (a *aggregates) writeTo(dp histogramDataPoint) {
+	if a.hasSum {
+		dp.SetSum(a.sum)
+	} else {
+		dp.RemoveSum()
+	}
+	if a.hasMin {
+		dp.SetMin(a.min)
+	} else {
+		dp.RemoveMin()
+	}
+	if a.hasMax {
+		dp.SetMax(a.max)
+	} else {
+		dp.RemoveMax()
+	}
+}
+
+// toDelta sets the delta aggregates of the cumulative data point following the previous one. The min
+// and max since the previous data point are unknown.
+func (a *aggregates) toDelta(dp histogramDataPoint) {
+	if a.hasSum && dp.HasSum() {
+		dp.SetSum(dp.Sum() - a.sum)
+	} else {
+		dp.RemoveSum()
+	}
+	dp.RemoveMin()
+	dp.RemoveMax()
+}
+
+type histogramState struct {
+	start     pcommon.Timestamp
+	timestamp pcommon.Timestamp
+	count     uint64
+	aggregates
+	bounds  []float64
+	buckets []uint64
+}
+
+func (s *histogramState) set(dp pmetric.HistogramDataPoint) {
+	s.start = dp.StartTimestamp()
+	s.timestamp = dp.Timestamp()
+	s.count = dp.Count()
+	s.aggregates.set(dp)
+	s.bounds = s.bounds[:0]
+	for i := 0; i < dp.ExplicitBounds().Len(); i++ {
+		s.bounds = append(s.bounds, dp.ExplicitBounds().At(i))
+	}
+	s.buckets = s.buckets[:0]
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		s.buckets = append(s.buckets, dp.BucketCounts().At(i))
+	}
+}
+
+// sameBuckets returns true if the data point has the buckets of the state.
+func (s *histogramState) sameBuckets(dp pmetric.HistogramDataPoint) bool {
+	if dp.ExplicitBounds().Len() != len(s.bounds) || dp.BucketCounts().Len() != len(s.buckets) {
+		return false
+	}
+	for i, bound := range s.bounds {
+		if dp.ExplicitBounds().At(i) != bound {
+			return false
+		}
+	}
+	return true
+}
+
+// follows returns true if the cumulative data point, having the buckets of the state,
+// accumulates the counts of the state.
+func (s *histogramState) follows(dp pmetric.HistogramDataPoint) bool {
+	if dp.Count() < s.count {
+		return false
+	}
+	for i, count := range s.buckets {
+		if dp.BucketCounts().At(i) < count {
+			return false
+		}
+	}
+	return true
+}
+
+// histogramToDelta converts the cumulative data point to delta, it returns false if the data point is dropped.
+func histogramToDelta(st *stream, dp pmetric.HistogramDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	prev, ok := st.state.(*histogramState)
+	if ok && dp.Timestamp() <= prev.timestamp {
+		return false
+	}
+	if !ok || dp.StartTimestamp() != prev.start || !prev.sameBuckets(dp) {
+		if !ok {
+			prev = &histogramState{}
+			st.state = prev
+		}
+		prev.set(dp)
+		return startsStream(dp.StartTimestamp())
+	}
+
+	last := prev.timestamp
+	if !prev.follows(dp) {
+		// The histogram was reset since the previous data point, its whole
+		// value was accumulated since then.
+		prev.set(dp)
+		dp.SetStartTimestamp(last)
+		return true
+	}
+	prevAggregates, prevCount := prev.aggregates, prev.count
+	prev.timestamp = dp.Timestamp()
+	prev.count = dp.Count()
+	prev.aggregates.set(dp)
+	for i, count := range prev.buckets {
+		prev.buckets[i] = dp.BucketCounts().At(i)
+		dp.BucketCounts().SetAt(i, prev.buckets[i]-count)
+	}
+	dp.SetStartTimestamp(last)
+	dp.SetCount(dp.Count() - prevCount)
+	prevAggregates.toDelta(dp)
+	return true
+}
+
+// histogramToCumulative converts the delta data point to cumulative, it returns false if the data point is dropped.
+func histogramToCumulative(st *stream, dp pmetric.HistogramDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	acc, ok := st.state.(*histogramState)
+	if ok && overlaps(dp.StartTimestamp(), dp.Timestamp(), acc.timestamp) {
+		return false
+	}
+	if !ok || !acc.sameBuckets(dp) || isGap(dp.StartTimestamp(), acc.timestamp) {
+		if !ok {
+			acc = &histogramState{}
+			st.state = acc
+		}
+		acc.set(dp)
+		return true
+	}
+
+	var delta aggregates
+	delta.set(dp)
+	acc.timestamp = dp.Timestamp()
+	acc.count += dp.Count()
+	acc.aggregates.add(delta)
+	for i := range acc.buckets {
+		acc.buckets[i] += dp.BucketCounts().At(i)
+	}
+	dp.SetStartTimestamp(acc.start)
+	dp.SetCount(acc.count)
+	acc.aggregates.writeTo(dp)
+	dp.BucketCounts().FromRaw(acc.buckets)
+	return true
+}
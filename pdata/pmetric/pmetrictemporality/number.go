@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetrictemporality // import "go.opentelemetry.io/collector/pdata/pmetric/pmetrictemporality"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type numberState struct {
+	start       pcommon.Timestamp
+	timestamp   pcommon.Timestamp
+	valueType   pmetric.NumberDataPointValueType
+	intValue    int64
+	doubleValue float64
+}
+
+func (s *numberState) set(dp pmetric.NumberDataPoint) {
+	*s = numberState{
+		start:       dp.StartTimestamp(),
+		timestamp:   dp.Timestamp(),
+		valueType:   dp.ValueType(),
+		intValue:    dp.IntValue(),
+		doubleValue: dp.DoubleValue(),
+	}
+}
+
+// numberToDelta converts the cumulative data point to delta, it returns false if the data point is dropped.
+func numberToDelta(st *stream, dp pmetric.NumberDataPoint, monotonic bool) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	prev, ok := st.state.(*numberState)
+	if ok && dp.Timestamp() <= prev.timestamp {
+		return false
+	}
+	if !ok || dp.StartTimestamp() != prev.start || dp.ValueType() != prev.valueType {
+		prev = &numberState{}
+		prev.set(dp)
+		st.state = prev
+		return startsStream(dp.StartTimestamp())
+	}
+
+	last := *prev
+	prev.set(dp)
+	dp.SetStartTimestamp(last.timestamp)
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		// A decreasing monotonic sum was reset since the previous data point,
+		// its whole value was accumulated since then.
+		if delta := dp.IntValue() - last.intValue; !monotonic || delta >= 0 {
+			dp.SetIntValue(delta)
+		}
+	case pmetric.NumberDataPointValueTypeDouble:
+		if delta := dp.DoubleValue() - last.doubleValue; !monotonic || delta >= 0 {
+			dp.SetDoubleValue(delta)
+		}
+	}
+	return true
+}
+
+// numberToCumulative converts the delta data point to cumulative, it returns false if the data point is dropped.
+func numberToCumulative(st *stream, dp pmetric.NumberDataPoint) bool {
+	if dp.Flags().NoRecordedValue() {
+		st.state = nil
+		return true
+	}
+	acc, ok := st.state.(*numberState)
+	if ok && overlaps(dp.StartTimestamp(), dp.Timestamp(), acc.timestamp) {
+		return false
+	}
+	if !ok || dp.ValueType() != acc.valueType || isGap(dp.StartTimestamp(), acc.timestamp) {
+		acc = &numberState{}
+		acc.set(dp)
+		st.state = acc
+		return true
+	}
+
+	acc.timestamp = dp.Timestamp()
+	acc.intValue += dp.IntValue()
+	acc.doubleValue += dp.DoubleValue()
+	dp.SetStartTimestamp(acc.start)
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		dp.SetIntValue(acc.intValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		dp.SetDoubleValue(acc.doubleValue)
+	}
+	return true
+}
+
+// startsStream returns true if the cumulative data point starting a stream is kept, which requires its
+// start timestamp: its value since the start timestamp is then the delta one.
+func startsStream(start pcommon.Timestamp) bool {
+	return start != 0
+}
+
+// overlaps returns true if the delta data point covers a time already accumulated up to the timestamp.
+// A data point without start timestamp is assumed to start at the accumulated timestamp.
+func overlaps(start, timestamp, accumulated pcommon.Timestamp) bool {
+	return timestamp <= accumulated || (start != 0 && start < accumulated)
+}
+
+// isGap returns true if the delta data point does not start at the timestamp accumulated up to, the
+// data of the time between them being unknown.
+func isGap(start, accumulated pcommon.Timestamp) bool {
+	return start > accumulated
+}
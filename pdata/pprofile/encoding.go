@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+// Marshaler marshals pprofile.Profiles into bytes.
+type Marshaler interface {
+	// MarshalProfiles the given pprofile.Profiles into bytes.
+	// If the error is not nil, the returned bytes slice cannot be used.
+	MarshalProfiles(pd Profiles) ([]byte, error)
+}
+
+// Unmarshaler unmarshalls bytes into pprofile.Profiles.
+type Unmarshaler interface {
+	// UnmarshalProfiles the given bytes into pprofile.Profiles.
+	// If the error is not nil, the returned pprofile.Profiles cannot be used.
+	UnmarshalProfiles(buf []byte) (Profiles, error)
+}
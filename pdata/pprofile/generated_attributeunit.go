@@ -32,8 +32,8 @@ func newAttributeUnit(orig *otlpprofiles.AttributeUnit, state *internal.State) A
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewAttributeUnit() AttributeUnit {
-	state := internal.StateMutable
-	return newAttributeUnit(&otlpprofiles.AttributeUnit{}, &state)
+	state := internal.NewState()
+	return newAttributeUnit(&otlpprofiles.AttributeUnit{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms AttributeUnit) MoveTo(dest AttributeUnit) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.AttributeUnit{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // AttributeKey returns the attributekey associated with this AttributeUnit.
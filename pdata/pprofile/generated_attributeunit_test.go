@@ -21,9 +21,9 @@ func TestAttributeUnit_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewAttributeUnit(), ms)
 	assert.Equal(t, generateTestAttributeUnit(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newAttributeUnit(&otlpprofiles.AttributeUnit{}, &sharedState)) })
-	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newAttributeUnit(&otlpprofiles.AttributeUnit{}, sharedState)) })
+	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, sharedState).MoveTo(dest) })
 }
 
 func TestAttributeUnit_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestAttributeUnit_CopyTo(t *testing.T) {
 	orig = generateTestAttributeUnit()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newAttributeUnit(&otlpprofiles.AttributeUnit{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newAttributeUnit(&otlpprofiles.AttributeUnit{}, sharedState)) })
 }
 
 func TestAttributeUnit_AttributeKey(t *testing.T) {
@@ -43,8 +43,8 @@ func TestAttributeUnit_AttributeKey(t *testing.T) {
 	assert.Equal(t, int64(0), ms.AttributeKey())
 	ms.SetAttributeKey(int64(1))
 	assert.Equal(t, int64(1), ms.AttributeKey())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, &sharedState).SetAttributeKey(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, sharedState).SetAttributeKey(int64(1)) })
 }
 
 func TestAttributeUnit_Unit(t *testing.T) {
@@ -52,8 +52,8 @@ func TestAttributeUnit_Unit(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Unit())
 	ms.SetUnit(int64(1))
 	assert.Equal(t, int64(1), ms.Unit())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, &sharedState).SetUnit(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newAttributeUnit(&otlpprofiles.AttributeUnit{}, sharedState).SetUnit(int64(1)) })
 }
 
 func generateTestAttributeUnit() AttributeUnit {
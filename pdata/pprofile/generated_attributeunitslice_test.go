@@ -18,8 +18,8 @@ import (
 func TestAttributeUnitSlice(t *testing.T) {
 	es := NewAttributeUnitSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newAttributeUnitSlice(&[]otlpprofiles.AttributeUnit{}, &state)
+	state := internal.NewState()
+	es = newAttributeUnitSlice(&[]otlpprofiles.AttributeUnit{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewAttributeUnit()
@@ -34,8 +34,8 @@ func TestAttributeUnitSlice(t *testing.T) {
 }
 
 func TestAttributeUnitSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newAttributeUnitSlice(&[]otlpprofiles.AttributeUnit{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newAttributeUnitSlice(&[]otlpprofiles.AttributeUnit{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
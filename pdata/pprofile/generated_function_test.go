@@ -21,9 +21,9 @@ func TestFunction_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewFunction(), ms)
 	assert.Equal(t, generateTestFunction(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newFunction(&otlpprofiles.Function{}, &sharedState)) })
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newFunction(&otlpprofiles.Function{}, sharedState)) })
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).MoveTo(dest) })
 }
 
 func TestFunction_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestFunction_CopyTo(t *testing.T) {
 	orig = generateTestFunction()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newFunction(&otlpprofiles.Function{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newFunction(&otlpprofiles.Function{}, sharedState)) })
 }
 
 func TestFunction_ID(t *testing.T) {
@@ -43,8 +43,8 @@ func TestFunction_ID(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.ID())
 	ms.SetID(uint64(1))
 	assert.Equal(t, uint64(1), ms.ID())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).SetID(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).SetID(uint64(1)) })
 }
 
 func TestFunction_Name(t *testing.T) {
@@ -52,8 +52,8 @@ func TestFunction_Name(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Name())
 	ms.SetName(int64(1))
 	assert.Equal(t, int64(1), ms.Name())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).SetName(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).SetName(int64(1)) })
 }
 
 func TestFunction_SystemName(t *testing.T) {
@@ -61,8 +61,8 @@ func TestFunction_SystemName(t *testing.T) {
 	assert.Equal(t, int64(0), ms.SystemName())
 	ms.SetSystemName(int64(1))
 	assert.Equal(t, int64(1), ms.SystemName())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).SetSystemName(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).SetSystemName(int64(1)) })
 }
 
 func TestFunction_Filename(t *testing.T) {
@@ -70,8 +70,8 @@ func TestFunction_Filename(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Filename())
 	ms.SetFilename(int64(1))
 	assert.Equal(t, int64(1), ms.Filename())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).SetFilename(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).SetFilename(int64(1)) })
 }
 
 func TestFunction_StartLine(t *testing.T) {
@@ -79,8 +79,8 @@ func TestFunction_StartLine(t *testing.T) {
 	assert.Equal(t, int64(0), ms.StartLine())
 	ms.SetStartLine(int64(1))
 	assert.Equal(t, int64(1), ms.StartLine())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, &sharedState).SetStartLine(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newFunction(&otlpprofiles.Function{}, sharedState).SetStartLine(int64(1)) })
 }
 
 func generateTestFunction() Function {
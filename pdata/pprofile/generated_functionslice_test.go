@@ -18,8 +18,8 @@ import (
 func TestFunctionSlice(t *testing.T) {
 	es := NewFunctionSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newFunctionSlice(&[]otlpprofiles.Function{}, &state)
+	state := internal.NewState()
+	es = newFunctionSlice(&[]otlpprofiles.Function{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewFunction()
@@ -34,8 +34,8 @@ func TestFunctionSlice(t *testing.T) {
 }
 
 func TestFunctionSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newFunctionSlice(&[]otlpprofiles.Function{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newFunctionSlice(&[]otlpprofiles.Function{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -32,8 +32,8 @@ func newLabel(orig *otlpprofiles.Label, state *internal.State) Label {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewLabel() Label {
-	state := internal.StateMutable
-	return newLabel(&otlpprofiles.Label{}, &state)
+	state := internal.NewState()
+	return newLabel(&otlpprofiles.Label{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms Label) MoveTo(dest Label) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.Label{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Key returns the key associated with this Label.
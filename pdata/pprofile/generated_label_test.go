@@ -21,9 +21,9 @@ func TestLabel_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewLabel(), ms)
 	assert.Equal(t, generateTestLabel(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newLabel(&otlpprofiles.Label{}, &sharedState)) })
-	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newLabel(&otlpprofiles.Label{}, sharedState)) })
+	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, sharedState).MoveTo(dest) })
 }
 
 func TestLabel_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestLabel_CopyTo(t *testing.T) {
 	orig = generateTestLabel()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newLabel(&otlpprofiles.Label{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newLabel(&otlpprofiles.Label{}, sharedState)) })
 }
 
 func TestLabel_Key(t *testing.T) {
@@ -43,8 +43,8 @@ func TestLabel_Key(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Key())
 	ms.SetKey(int64(1))
 	assert.Equal(t, int64(1), ms.Key())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, &sharedState).SetKey(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, sharedState).SetKey(int64(1)) })
 }
 
 func TestLabel_Str(t *testing.T) {
@@ -52,8 +52,8 @@ func TestLabel_Str(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Str())
 	ms.SetStr(int64(1))
 	assert.Equal(t, int64(1), ms.Str())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, &sharedState).SetStr(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, sharedState).SetStr(int64(1)) })
 }
 
 func TestLabel_Num(t *testing.T) {
@@ -61,8 +61,8 @@ func TestLabel_Num(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Num())
 	ms.SetNum(int64(1))
 	assert.Equal(t, int64(1), ms.Num())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, &sharedState).SetNum(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, sharedState).SetNum(int64(1)) })
 }
 
 func TestLabel_NumUnit(t *testing.T) {
@@ -70,8 +70,8 @@ func TestLabel_NumUnit(t *testing.T) {
 	assert.Equal(t, int64(0), ms.NumUnit())
 	ms.SetNumUnit(int64(1))
 	assert.Equal(t, int64(1), ms.NumUnit())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, &sharedState).SetNumUnit(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLabel(&otlpprofiles.Label{}, sharedState).SetNumUnit(int64(1)) })
 }
 
 func generateTestLabel() Label {
@@ -31,8 +31,8 @@ func newLabelSlice(orig *[]otlpprofiles.Label, state *internal.State) LabelSlice
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewLabelSlice() LabelSlice {
 	orig := []otlpprofiles.Label(nil)
-	state := internal.StateMutable
-	return newLabelSlice(&orig, &state)
+	state := internal.NewState()
+	return newLabelSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -76,12 +76,18 @@ func (es LabelSlice) EnsureCapacity(newCap int) {
 	newOrig := make([]otlpprofiles.Label, len(*es.orig), newCap)
 	copy(newOrig, *es.orig)
 	*es.orig = newOrig
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 }
 
 // AppendEmpty will append to the end of the slice an empty Label.
 // It returns the newly added Label.
 func (es LabelSlice) AppendEmpty() Label {
 	es.state.AssertMutable()
+	if len(*es.orig) == cap(*es.orig) {
+		// The maps of the elements are moved.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = append(*es.orig, otlpprofiles.Label{})
 	return es.At(es.Len() - 1)
 }
@@ -97,6 +103,9 @@ func (es LabelSlice) MoveAndAppendTo(dest LabelSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
+	dest.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -117,12 +126,18 @@ func (es LabelSlice) RemoveIf(f func(Label) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded, and the maps of the others are moved.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es LabelSlice) CopyTo(dest LabelSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
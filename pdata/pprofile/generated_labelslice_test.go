@@ -18,8 +18,8 @@ import (
 func TestLabelSlice(t *testing.T) {
 	es := NewLabelSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newLabelSlice(&[]otlpprofiles.Label{}, &state)
+	state := internal.NewState()
+	es = newLabelSlice(&[]otlpprofiles.Label{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewLabel()
@@ -34,8 +34,8 @@ func TestLabelSlice(t *testing.T) {
 }
 
 func TestLabelSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newLabelSlice(&[]otlpprofiles.Label{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newLabelSlice(&[]otlpprofiles.Label{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
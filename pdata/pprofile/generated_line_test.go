@@ -21,9 +21,9 @@ func TestLine_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewLine(), ms)
 	assert.Equal(t, generateTestLine(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newLine(&otlpprofiles.Line{}, &sharedState)) })
-	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newLine(&otlpprofiles.Line{}, sharedState)) })
+	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, sharedState).MoveTo(dest) })
 }
 
 func TestLine_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestLine_CopyTo(t *testing.T) {
 	orig = generateTestLine()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newLine(&otlpprofiles.Line{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newLine(&otlpprofiles.Line{}, sharedState)) })
 }
 
 func TestLine_FunctionIndex(t *testing.T) {
@@ -43,8 +43,8 @@ func TestLine_FunctionIndex(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.FunctionIndex())
 	ms.SetFunctionIndex(uint64(1))
 	assert.Equal(t, uint64(1), ms.FunctionIndex())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, &sharedState).SetFunctionIndex(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, sharedState).SetFunctionIndex(uint64(1)) })
 }
 
 func TestLine_Line(t *testing.T) {
@@ -52,8 +52,8 @@ func TestLine_Line(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Line())
 	ms.SetLine(int64(1))
 	assert.Equal(t, int64(1), ms.Line())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, &sharedState).SetLine(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, sharedState).SetLine(int64(1)) })
 }
 
 func TestLine_Column(t *testing.T) {
@@ -61,8 +61,8 @@ func TestLine_Column(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Column())
 	ms.SetColumn(int64(1))
 	assert.Equal(t, int64(1), ms.Column())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, &sharedState).SetColumn(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLine(&otlpprofiles.Line{}, sharedState).SetColumn(int64(1)) })
 }
 
 func generateTestLine() Line {
@@ -18,8 +18,8 @@ import (
 func TestLineSlice(t *testing.T) {
 	es := NewLineSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newLineSlice(&[]otlpprofiles.Line{}, &state)
+	state := internal.NewState()
+	es = newLineSlice(&[]otlpprofiles.Line{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewLine()
@@ -34,8 +34,8 @@ func TestLineSlice(t *testing.T) {
 }
 
 func TestLineSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newLineSlice(&[]otlpprofiles.Line{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newLineSlice(&[]otlpprofiles.Line{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
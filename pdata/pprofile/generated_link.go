@@ -34,8 +34,8 @@ func newLink(orig *otlpprofiles.Link, state *internal.State) Link {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewLink() Link {
-	state := internal.StateMutable
-	return newLink(&otlpprofiles.Link{}, &state)
+	state := internal.NewState()
+	return newLink(&otlpprofiles.Link{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -45,6 +45,9 @@ func (ms Link) MoveTo(dest Link) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.Link{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // TraceID returns the traceid associated with this Link.
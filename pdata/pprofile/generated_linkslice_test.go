@@ -18,8 +18,8 @@ import (
 func TestLinkSlice(t *testing.T) {
 	es := NewLinkSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newLinkSlice(&[]otlpprofiles.Link{}, &state)
+	state := internal.NewState()
+	es = newLinkSlice(&[]otlpprofiles.Link{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewLink()
@@ -34,8 +34,8 @@ func TestLinkSlice(t *testing.T) {
 }
 
 func TestLinkSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newLinkSlice(&[]otlpprofiles.Link{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newLinkSlice(&[]otlpprofiles.Link{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -22,9 +22,9 @@ func TestLocation_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewLocation(), ms)
 	assert.Equal(t, generateTestLocation(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newLocation(&otlpprofiles.Location{}, &sharedState)) })
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newLocation(&otlpprofiles.Location{}, sharedState)) })
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).MoveTo(dest) })
 }
 
 func TestLocation_CopyTo(t *testing.T) {
@@ -35,8 +35,8 @@ func TestLocation_CopyTo(t *testing.T) {
 	orig = generateTestLocation()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newLocation(&otlpprofiles.Location{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newLocation(&otlpprofiles.Location{}, sharedState)) })
 }
 
 func TestLocation_ID(t *testing.T) {
@@ -44,8 +44,8 @@ func TestLocation_ID(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.ID())
 	ms.SetID(uint64(1))
 	assert.Equal(t, uint64(1), ms.ID())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).SetID(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).SetID(uint64(1)) })
 }
 
 func TestLocation_MappingIndex(t *testing.T) {
@@ -53,8 +53,8 @@ func TestLocation_MappingIndex(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.MappingIndex())
 	ms.SetMappingIndex(uint64(1))
 	assert.Equal(t, uint64(1), ms.MappingIndex())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).SetMappingIndex(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).SetMappingIndex(uint64(1)) })
 }
 
 func TestLocation_Address(t *testing.T) {
@@ -62,8 +62,8 @@ func TestLocation_Address(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.Address())
 	ms.SetAddress(uint64(1))
 	assert.Equal(t, uint64(1), ms.Address())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).SetAddress(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).SetAddress(uint64(1)) })
 }
 
 func TestLocation_Line(t *testing.T) {
@@ -78,8 +78,8 @@ func TestLocation_IsFolded(t *testing.T) {
 	assert.Equal(t, false, ms.IsFolded())
 	ms.SetIsFolded(true)
 	assert.Equal(t, true, ms.IsFolded())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).SetIsFolded(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).SetIsFolded(true) })
 }
 
 func TestLocation_TypeIndex(t *testing.T) {
@@ -87,8 +87,8 @@ func TestLocation_TypeIndex(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.TypeIndex())
 	ms.SetTypeIndex(uint32(1))
 	assert.Equal(t, uint32(1), ms.TypeIndex())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, &sharedState).SetTypeIndex(uint32(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newLocation(&otlpprofiles.Location{}, sharedState).SetTypeIndex(uint32(1)) })
 }
 
 func TestLocation_Attributes(t *testing.T) {
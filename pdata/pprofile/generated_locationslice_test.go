@@ -18,8 +18,8 @@ import (
 func TestLocationSlice(t *testing.T) {
 	es := NewLocationSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newLocationSlice(&[]otlpprofiles.Location{}, &state)
+	state := internal.NewState()
+	es = newLocationSlice(&[]otlpprofiles.Location{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewLocation()
@@ -34,8 +34,8 @@ func TestLocationSlice(t *testing.T) {
 }
 
 func TestLocationSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newLocationSlice(&[]otlpprofiles.Location{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newLocationSlice(&[]otlpprofiles.Location{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
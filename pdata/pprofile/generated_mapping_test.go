@@ -22,9 +22,9 @@ func TestMapping_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewMapping(), ms)
 	assert.Equal(t, generateTestMapping(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newMapping(&otlpprofiles.Mapping{}, &sharedState)) })
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newMapping(&otlpprofiles.Mapping{}, sharedState)) })
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).MoveTo(dest) })
 }
 
 func TestMapping_CopyTo(t *testing.T) {
@@ -35,8 +35,8 @@ func TestMapping_CopyTo(t *testing.T) {
 	orig = generateTestMapping()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newMapping(&otlpprofiles.Mapping{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newMapping(&otlpprofiles.Mapping{}, sharedState)) })
 }
 
 func TestMapping_ID(t *testing.T) {
@@ -44,8 +44,8 @@ func TestMapping_ID(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.ID())
 	ms.SetID(uint64(1))
 	assert.Equal(t, uint64(1), ms.ID())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetID(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetID(uint64(1)) })
 }
 
 func TestMapping_MemoryStart(t *testing.T) {
@@ -53,8 +53,8 @@ func TestMapping_MemoryStart(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.MemoryStart())
 	ms.SetMemoryStart(uint64(1))
 	assert.Equal(t, uint64(1), ms.MemoryStart())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetMemoryStart(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetMemoryStart(uint64(1)) })
 }
 
 func TestMapping_MemoryLimit(t *testing.T) {
@@ -62,8 +62,8 @@ func TestMapping_MemoryLimit(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.MemoryLimit())
 	ms.SetMemoryLimit(uint64(1))
 	assert.Equal(t, uint64(1), ms.MemoryLimit())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetMemoryLimit(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetMemoryLimit(uint64(1)) })
 }
 
 func TestMapping_FileOffset(t *testing.T) {
@@ -71,8 +71,8 @@ func TestMapping_FileOffset(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.FileOffset())
 	ms.SetFileOffset(uint64(1))
 	assert.Equal(t, uint64(1), ms.FileOffset())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetFileOffset(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetFileOffset(uint64(1)) })
 }
 
 func TestMapping_Filename(t *testing.T) {
@@ -80,8 +80,8 @@ func TestMapping_Filename(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Filename())
 	ms.SetFilename(int64(1))
 	assert.Equal(t, int64(1), ms.Filename())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetFilename(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetFilename(int64(1)) })
 }
 
 func TestMapping_BuildID(t *testing.T) {
@@ -89,8 +89,8 @@ func TestMapping_BuildID(t *testing.T) {
 	assert.Equal(t, int64(0), ms.BuildID())
 	ms.SetBuildID(int64(1))
 	assert.Equal(t, int64(1), ms.BuildID())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetBuildID(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetBuildID(int64(1)) })
 }
 
 func TestMapping_BuildIDKind(t *testing.T) {
@@ -98,8 +98,8 @@ func TestMapping_BuildIDKind(t *testing.T) {
 	assert.Equal(t, otlpprofiles.BuildIdKind(0), ms.BuildIDKind())
 	ms.SetBuildIDKind(otlpprofiles.BuildIdKind(1))
 	assert.Equal(t, otlpprofiles.BuildIdKind(1), ms.BuildIDKind())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetBuildIDKind(otlpprofiles.BuildIdKind(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetBuildIDKind(otlpprofiles.BuildIdKind(1)) })
 }
 
 func TestMapping_Attributes(t *testing.T) {
@@ -114,8 +114,8 @@ func TestMapping_HasFunctions(t *testing.T) {
 	assert.Equal(t, false, ms.HasFunctions())
 	ms.SetHasFunctions(true)
 	assert.Equal(t, true, ms.HasFunctions())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetHasFunctions(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetHasFunctions(true) })
 }
 
 func TestMapping_HasFilenames(t *testing.T) {
@@ -123,8 +123,8 @@ func TestMapping_HasFilenames(t *testing.T) {
 	assert.Equal(t, false, ms.HasFilenames())
 	ms.SetHasFilenames(true)
 	assert.Equal(t, true, ms.HasFilenames())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetHasFilenames(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetHasFilenames(true) })
 }
 
 func TestMapping_HasLineNumbers(t *testing.T) {
@@ -132,8 +132,8 @@ func TestMapping_HasLineNumbers(t *testing.T) {
 	assert.Equal(t, false, ms.HasLineNumbers())
 	ms.SetHasLineNumbers(true)
 	assert.Equal(t, true, ms.HasLineNumbers())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetHasLineNumbers(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetHasLineNumbers(true) })
 }
 
 func TestMapping_HasInlineFrames(t *testing.T) {
@@ -141,8 +141,8 @@ func TestMapping_HasInlineFrames(t *testing.T) {
 	assert.Equal(t, false, ms.HasInlineFrames())
 	ms.SetHasInlineFrames(true)
 	assert.Equal(t, true, ms.HasInlineFrames())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, &sharedState).SetHasInlineFrames(true) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newMapping(&otlpprofiles.Mapping{}, sharedState).SetHasInlineFrames(true) })
 }
 
 func generateTestMapping() Mapping {
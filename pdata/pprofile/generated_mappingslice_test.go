@@ -18,8 +18,8 @@ import (
 func TestMappingSlice(t *testing.T) {
 	es := NewMappingSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newMappingSlice(&[]otlpprofiles.Mapping{}, &state)
+	state := internal.NewState()
+	es = newMappingSlice(&[]otlpprofiles.Mapping{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewMapping()
@@ -34,8 +34,8 @@ func TestMappingSlice(t *testing.T) {
 }
 
 func TestMappingSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newMappingSlice(&[]otlpprofiles.Mapping{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newMappingSlice(&[]otlpprofiles.Mapping{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
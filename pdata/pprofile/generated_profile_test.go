@@ -22,9 +22,9 @@ func TestProfile_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewProfile(), ms)
 	assert.Equal(t, generateTestProfile(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newProfile(&otlpprofiles.Profile{}, &sharedState)) })
-	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newProfile(&otlpprofiles.Profile{}, sharedState)) })
+	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, sharedState).MoveTo(dest) })
 }
 
 func TestProfile_CopyTo(t *testing.T) {
@@ -35,8 +35,8 @@ func TestProfile_CopyTo(t *testing.T) {
 	orig = generateTestProfile()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newProfile(&otlpprofiles.Profile{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newProfile(&otlpprofiles.Profile{}, sharedState)) })
 }
 
 func TestProfile_SampleType(t *testing.T) {
@@ -114,8 +114,8 @@ func TestProfile_DropFrames(t *testing.T) {
 	assert.Equal(t, int64(0), ms.DropFrames())
 	ms.SetDropFrames(int64(1))
 	assert.Equal(t, int64(1), ms.DropFrames())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, &sharedState).SetDropFrames(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, sharedState).SetDropFrames(int64(1)) })
 }
 
 func TestProfile_KeepFrames(t *testing.T) {
@@ -123,8 +123,8 @@ func TestProfile_KeepFrames(t *testing.T) {
 	assert.Equal(t, int64(0), ms.KeepFrames())
 	ms.SetKeepFrames(int64(1))
 	assert.Equal(t, int64(1), ms.KeepFrames())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, &sharedState).SetKeepFrames(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, sharedState).SetKeepFrames(int64(1)) })
 }
 
 func TestProfile_StartTime(t *testing.T) {
@@ -154,8 +154,8 @@ func TestProfile_Period(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Period())
 	ms.SetPeriod(int64(1))
 	assert.Equal(t, int64(1), ms.Period())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, &sharedState).SetPeriod(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, sharedState).SetPeriod(int64(1)) })
 }
 
 func TestProfile_Comment(t *testing.T) {
@@ -170,8 +170,8 @@ func TestProfile_DefaultSampleType(t *testing.T) {
 	assert.Equal(t, int64(0), ms.DefaultSampleType())
 	ms.SetDefaultSampleType(int64(1))
 	assert.Equal(t, int64(1), ms.DefaultSampleType())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, &sharedState).SetDefaultSampleType(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newProfile(&otlpprofiles.Profile{}, sharedState).SetDefaultSampleType(int64(1)) })
 }
 
 func generateTestProfile() Profile {
@@ -33,8 +33,8 @@ func newProfileContainer(orig *otlpprofiles.ProfileContainer, state *internal.St
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewProfileContainer() ProfileContainer {
-	state := internal.StateMutable
-	return newProfileContainer(&otlpprofiles.ProfileContainer{}, &state)
+	state := internal.NewState()
+	return newProfileContainer(&otlpprofiles.ProfileContainer{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ProfileContainer) MoveTo(dest ProfileContainer) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.ProfileContainer{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // ProfileID returns the ProfileId associated with this ProfileContainer.
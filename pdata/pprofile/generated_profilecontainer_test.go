@@ -22,9 +22,9 @@ func TestProfileContainer_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewProfileContainer(), ms)
 	assert.Equal(t, generateTestProfileContainer(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newProfileContainer(&otlpprofiles.ProfileContainer{}, &sharedState)) })
-	assert.Panics(t, func() { newProfileContainer(&otlpprofiles.ProfileContainer{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newProfileContainer(&otlpprofiles.ProfileContainer{}, sharedState)) })
+	assert.Panics(t, func() { newProfileContainer(&otlpprofiles.ProfileContainer{}, sharedState).MoveTo(dest) })
 }
 
 func TestProfileContainer_CopyTo(t *testing.T) {
@@ -35,8 +35,8 @@ func TestProfileContainer_CopyTo(t *testing.T) {
 	orig = generateTestProfileContainer()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newProfileContainer(&otlpprofiles.ProfileContainer{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newProfileContainer(&otlpprofiles.ProfileContainer{}, sharedState)) })
 }
 
 func TestProfileContainer_ProfileID(t *testing.T) {
@@ -74,9 +74,9 @@ func TestProfileContainer_DroppedAttributesCount(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.DroppedAttributesCount())
 	ms.SetDroppedAttributesCount(uint32(17))
 	assert.Equal(t, uint32(17), ms.DroppedAttributesCount())
-	sharedState := internal.StateReadOnly
+	sharedState := internal.NewReadOnlyState()
 	assert.Panics(t, func() {
-		newProfileContainer(&otlpprofiles.ProfileContainer{}, &sharedState).SetDroppedAttributesCount(uint32(17))
+		newProfileContainer(&otlpprofiles.ProfileContainer{}, sharedState).SetDroppedAttributesCount(uint32(17))
 	})
 }
 
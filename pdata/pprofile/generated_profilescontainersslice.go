@@ -33,8 +33,8 @@ func newProfilesContainersSlice(orig *[]*otlpprofiles.ProfileContainer, state *i
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewProfilesContainersSlice() ProfilesContainersSlice {
 	orig := []*otlpprofiles.ProfileContainer(nil)
-	state := internal.StateMutable
-	return newProfilesContainersSlice(&orig, &state)
+	state := internal.NewState()
+	return newProfilesContainersSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -99,6 +99,8 @@ func (es ProfilesContainersSlice) MoveAndAppendTo(dest ProfilesContainersSlice)
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,12 +121,18 @@ func (es ProfilesContainersSlice) RemoveIf(f func(ProfileContainer) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ProfilesContainersSlice) CopyTo(dest ProfilesContainersSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -19,8 +19,8 @@ import (
 func TestProfilesContainersSlice(t *testing.T) {
 	es := NewProfilesContainersSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newProfilesContainersSlice(&[]*otlpprofiles.ProfileContainer{}, &state)
+	state := internal.NewState()
+	es = newProfilesContainersSlice(&[]*otlpprofiles.ProfileContainer{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewProfileContainer()
@@ -35,8 +35,8 @@ func TestProfilesContainersSlice(t *testing.T) {
 }
 
 func TestProfilesContainersSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newProfilesContainersSlice(&[]*otlpprofiles.ProfileContainer{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newProfilesContainersSlice(&[]*otlpprofiles.ProfileContainer{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
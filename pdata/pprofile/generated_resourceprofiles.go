@@ -33,8 +33,8 @@ func newResourceProfiles(orig *otlpprofiles.ResourceProfiles, state *internal.St
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceProfiles() ResourceProfiles {
-	state := internal.StateMutable
-	return newResourceProfiles(&otlpprofiles.ResourceProfiles{}, &state)
+	state := internal.NewState()
+	return newResourceProfiles(&otlpprofiles.ResourceProfiles{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ResourceProfiles) MoveTo(dest ResourceProfiles) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.ResourceProfiles{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Resource returns the resource associated with this ResourceProfiles.
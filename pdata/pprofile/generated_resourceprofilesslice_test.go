@@ -19,8 +19,8 @@ import (
 func TestResourceProfilesSlice(t *testing.T) {
 	es := NewResourceProfilesSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &state)
+	state := internal.NewState()
+	es = newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceProfiles()
@@ -35,8 +35,8 @@ func TestResourceProfilesSlice(t *testing.T) {
 }
 
 func TestResourceProfilesSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
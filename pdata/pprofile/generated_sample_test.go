@@ -22,9 +22,9 @@ func TestSample_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewSample(), ms)
 	assert.Equal(t, generateTestSample(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newSample(&otlpprofiles.Sample{}, &sharedState)) })
-	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newSample(&otlpprofiles.Sample{}, sharedState)) })
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, sharedState).MoveTo(dest) })
 }
 
 func TestSample_CopyTo(t *testing.T) {
@@ -35,8 +35,8 @@ func TestSample_CopyTo(t *testing.T) {
 	orig = generateTestSample()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newSample(&otlpprofiles.Sample{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newSample(&otlpprofiles.Sample{}, sharedState)) })
 }
 
 func TestSample_LocationIndex(t *testing.T) {
@@ -51,8 +51,8 @@ func TestSample_LocationsStartIndex(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.LocationsStartIndex())
 	ms.SetLocationsStartIndex(uint64(1))
 	assert.Equal(t, uint64(1), ms.LocationsStartIndex())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLocationsStartIndex(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, sharedState).SetLocationsStartIndex(uint64(1)) })
 }
 
 func TestSample_LocationsLength(t *testing.T) {
@@ -60,8 +60,8 @@ func TestSample_LocationsLength(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.LocationsLength())
 	ms.SetLocationsLength(uint64(1))
 	assert.Equal(t, uint64(1), ms.LocationsLength())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLocationsLength(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, sharedState).SetLocationsLength(uint64(1)) })
 }
 
 func TestSample_StacktraceIdIndex(t *testing.T) {
@@ -69,8 +69,8 @@ func TestSample_StacktraceIdIndex(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.StacktraceIdIndex())
 	ms.SetStacktraceIdIndex(uint32(1))
 	assert.Equal(t, uint32(1), ms.StacktraceIdIndex())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetStacktraceIdIndex(uint32(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, sharedState).SetStacktraceIdIndex(uint32(1)) })
 }
 
 func TestSample_Value(t *testing.T) {
@@ -99,8 +99,8 @@ func TestSample_Link(t *testing.T) {
 	assert.Equal(t, uint64(0), ms.Link())
 	ms.SetLink(uint64(1))
 	assert.Equal(t, uint64(1), ms.Link())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, &sharedState).SetLink(uint64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSample(&otlpprofiles.Sample{}, sharedState).SetLink(uint64(1)) })
 }
 
 func TestSample_TimestampsUnixNano(t *testing.T) {
@@ -18,8 +18,8 @@ import (
 func TestSampleSlice(t *testing.T) {
 	es := NewSampleSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSampleSlice(&[]otlpprofiles.Sample{}, &state)
+	state := internal.NewState()
+	es = newSampleSlice(&[]otlpprofiles.Sample{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewSample()
@@ -34,8 +34,8 @@ func TestSampleSlice(t *testing.T) {
 }
 
 func TestSampleSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newSampleSlice(&[]otlpprofiles.Sample{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newSampleSlice(&[]otlpprofiles.Sample{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -33,8 +33,8 @@ func newScopeProfiles(orig *otlpprofiles.ScopeProfiles, state *internal.State) S
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeProfiles() ScopeProfiles {
-	state := internal.StateMutable
-	return newScopeProfiles(&otlpprofiles.ScopeProfiles{}, &state)
+	state := internal.NewState()
+	return newScopeProfiles(&otlpprofiles.ScopeProfiles{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ScopeProfiles) MoveTo(dest ScopeProfiles) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpprofiles.ScopeProfiles{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Scope returns the scope associated with this ScopeProfiles.
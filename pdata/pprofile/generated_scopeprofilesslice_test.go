@@ -19,8 +19,8 @@ import (
 func TestScopeProfilesSlice(t *testing.T) {
 	es := NewScopeProfilesSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newScopeProfilesSlice(&[]*otlpprofiles.ScopeProfiles{}, &state)
+	state := internal.NewState()
+	es = newScopeProfilesSlice(&[]*otlpprofiles.ScopeProfiles{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewScopeProfiles()
@@ -35,8 +35,8 @@ func TestScopeProfilesSlice(t *testing.T) {
 }
 
 func TestScopeProfilesSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newScopeProfilesSlice(&[]*otlpprofiles.ScopeProfiles{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newScopeProfilesSlice(&[]*otlpprofiles.ScopeProfiles{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
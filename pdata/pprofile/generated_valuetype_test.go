@@ -21,9 +21,9 @@ func TestValueType_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewValueType(), ms)
 	assert.Equal(t, generateTestValueType(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newValueType(&otlpprofiles.ValueType{}, &sharedState)) })
-	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newValueType(&otlpprofiles.ValueType{}, sharedState)) })
+	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, sharedState).MoveTo(dest) })
 }
 
 func TestValueType_CopyTo(t *testing.T) {
@@ -34,8 +34,8 @@ func TestValueType_CopyTo(t *testing.T) {
 	orig = generateTestValueType()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newValueType(&otlpprofiles.ValueType{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newValueType(&otlpprofiles.ValueType{}, sharedState)) })
 }
 
 func TestValueType_Type(t *testing.T) {
@@ -43,8 +43,8 @@ func TestValueType_Type(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Type())
 	ms.SetType(int64(1))
 	assert.Equal(t, int64(1), ms.Type())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, &sharedState).SetType(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, sharedState).SetType(int64(1)) })
 }
 
 func TestValueType_Unit(t *testing.T) {
@@ -52,8 +52,8 @@ func TestValueType_Unit(t *testing.T) {
 	assert.Equal(t, int64(0), ms.Unit())
 	ms.SetUnit(int64(1))
 	assert.Equal(t, int64(1), ms.Unit())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, &sharedState).SetUnit(int64(1)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newValueType(&otlpprofiles.ValueType{}, sharedState).SetUnit(int64(1)) })
 }
 
 func TestValueType_AggregationTemporality(t *testing.T) {
@@ -61,9 +61,9 @@ func TestValueType_AggregationTemporality(t *testing.T) {
 	assert.Equal(t, otlpprofiles.AggregationTemporality(0), ms.AggregationTemporality())
 	ms.SetAggregationTemporality(otlpprofiles.AggregationTemporality(1))
 	assert.Equal(t, otlpprofiles.AggregationTemporality(1), ms.AggregationTemporality())
-	sharedState := internal.StateReadOnly
+	sharedState := internal.NewReadOnlyState()
 	assert.Panics(t, func() {
-		newValueType(&otlpprofiles.ValueType{}, &sharedState).SetAggregationTemporality(otlpprofiles.AggregationTemporality(1))
+		newValueType(&otlpprofiles.ValueType{}, sharedState).SetAggregationTemporality(otlpprofiles.AggregationTemporality(1))
 	})
 }
 
@@ -18,8 +18,8 @@ import (
 func TestValueTypeSlice(t *testing.T) {
 	es := NewValueTypeSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newValueTypeSlice(&[]otlpprofiles.ValueType{}, &state)
+	state := internal.NewState()
+	es = newValueTypeSlice(&[]otlpprofiles.ValueType{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewValueType()
@@ -34,8 +34,8 @@ func TestValueTypeSlice(t *testing.T) {
 }
 
 func TestValueTypeSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newValueTypeSlice(&[]otlpprofiles.ValueType{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newValueTypeSlice(&[]otlpprofiles.ValueType{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
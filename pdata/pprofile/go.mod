@@ -3,14 +3,18 @@ module go.opentelemetry.io/collector/pdata/pprofile
 go 1.21.0
 
 require (
+	github.com/gogo/protobuf v1.3.2
+	github.com/google/pprof v0.0.0-20240409012703-83162a5b38cd
+	github.com/json-iterator/go v1.1.12
 	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/collector/pdata v1.12.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/kr/text v0.2.0 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/net v0.25.0 // indirect
@@ -0,0 +1,559 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	jsoniter "github.com/json-iterator/go"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+)
+
+// JSONMarshaler marshals pprofile.Profiles to JSON bytes using the OTLP/JSON format.
+type JSONMarshaler struct{}
+
+// MarshalProfiles to the OTLP/JSON format.
+func (*JSONMarshaler) MarshalProfiles(pd Profiles) ([]byte, error) {
+	stream := jsoniter.ConfigFastest.BorrowStream(nil)
+	defer jsoniter.ConfigFastest.ReturnStream(stream)
+	marshalProfilesData(stream, internal.ProfilesToProto(internal.Profiles(pd)))
+	if stream.Error != nil {
+		return nil, stream.Error
+	}
+	return append([]byte(nil), stream.Buffer()...), nil
+}
+
+// The messages down to the profile containers are written here, because the profile IDs are not of a custom
+// type of the generated code: jsonpb would encode them in base64, instead of in hex like the trace and span IDs.
+// The other messages are written by jsonpb, like the other signals.
+
+func marshalProfilesData(stream *jsoniter.Stream, pd otlpprofiles.ProfilesData) {
+	obj := jsonObject{stream: stream}
+	stream.WriteObjectStart()
+	if len(pd.ResourceProfiles) > 0 {
+		obj.field("resourceProfiles")
+		stream.WriteArrayStart()
+		for i, rp := range pd.ResourceProfiles {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			marshalResourceProfiles(stream, rp)
+		}
+		stream.WriteArrayEnd()
+	}
+	stream.WriteObjectEnd()
+}
+
+func marshalResourceProfiles(stream *jsoniter.Stream, rp *otlpprofiles.ResourceProfiles) {
+	obj := jsonObject{stream: stream}
+	stream.WriteObjectStart()
+	obj.field("resource")
+	marshalMessage(stream, &rp.Resource)
+	if len(rp.ScopeProfiles) > 0 {
+		obj.field("scopeProfiles")
+		stream.WriteArrayStart()
+		for i, sp := range rp.ScopeProfiles {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			marshalScopeProfiles(stream, sp)
+		}
+		stream.WriteArrayEnd()
+	}
+	if rp.SchemaUrl != "" {
+		obj.field("schemaUrl")
+		stream.WriteString(rp.SchemaUrl)
+	}
+	stream.WriteObjectEnd()
+}
+
+func marshalScopeProfiles(stream *jsoniter.Stream, sp *otlpprofiles.ScopeProfiles) {
+	obj := jsonObject{stream: stream}
+	stream.WriteObjectStart()
+	obj.field("scope")
+	marshalMessage(stream, &sp.Scope)
+	if len(sp.Profiles) > 0 {
+		obj.field("profiles")
+		stream.WriteArrayStart()
+		for i, pc := range sp.Profiles {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			marshalProfileContainer(stream, pc)
+		}
+		stream.WriteArrayEnd()
+	}
+	if sp.SchemaUrl != "" {
+		obj.field("schemaUrl")
+		stream.WriteString(sp.SchemaUrl)
+	}
+	stream.WriteObjectEnd()
+}
+
+func marshalProfileContainer(stream *jsoniter.Stream, pc *otlpprofiles.ProfileContainer) {
+	obj := jsonObject{stream: stream}
+	stream.WriteObjectStart()
+	if len(pc.ProfileId) > 0 {
+		obj.field("profileId")
+		stream.WriteString(hex.EncodeToString(pc.ProfileId))
+	}
+	// As in jsonpb, the 64 bits integers are written as strings.
+	if pc.StartTimeUnixNano != 0 {
+		obj.field("startTimeUnixNano")
+		stream.WriteString(strconv.FormatUint(pc.StartTimeUnixNano, 10))
+	}
+	if pc.EndTimeUnixNano != 0 {
+		obj.field("endTimeUnixNano")
+		stream.WriteString(strconv.FormatUint(pc.EndTimeUnixNano, 10))
+	}
+	if len(pc.Attributes) > 0 {
+		obj.field("attributes")
+		stream.WriteArrayStart()
+		for i := range pc.Attributes {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			marshalMessage(stream, &pc.Attributes[i])
+		}
+		stream.WriteArrayEnd()
+	}
+	if pc.DroppedAttributesCount != 0 {
+		obj.field("droppedAttributesCount")
+		stream.WriteUint32(pc.DroppedAttributesCount)
+	}
+	if pc.OriginalPayloadFormat != "" {
+		obj.field("originalPayloadFormat")
+		stream.WriteString(pc.OriginalPayloadFormat)
+	}
+	if len(pc.OriginalPayload) > 0 {
+		obj.field("originalPayload")
+		stream.WriteString(base64.StdEncoding.EncodeToString(pc.OriginalPayload))
+	}
+	obj.field("profile")
+	marshalMessage(stream, &pc.Profile)
+	stream.WriteObjectEnd()
+}
+
+func marshalMessage(stream *jsoniter.Stream, pb proto.Message) {
+	if err := json.Marshal(stream, pb); err != nil && stream.Error == nil {
+		stream.Error = err
+	}
+}
+
+// jsonObject separates the fields of a JSON object written to the stream.
+type jsonObject struct {
+	stream *jsoniter.Stream
+	fields int
+}
+
+func (o *jsonObject) field(name string) {
+	if o.fields > 0 {
+		o.stream.WriteMore()
+	}
+	o.fields++
+	o.stream.WriteObjectField(name)
+}
+
+// JSONUnmarshaler unmarshals OTLP/JSON formatted-bytes to pprofile.Profiles.
+type JSONUnmarshaler struct{}
+
+// UnmarshalProfiles from OTLP/JSON format into pprofile.Profiles.
+func (*JSONUnmarshaler) UnmarshalProfiles(buf []byte) (Profiles, error) {
+	iter := jsoniter.ConfigFastest.BorrowIterator(buf)
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	pd := NewProfiles()
+	pd.unmarshalJsoniter(iter)
+	if iter.Error != nil {
+		return Profiles{}, iter.Error
+	}
+	return pd, nil
+}
+
+func (ms Profiles) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "resourceProfiles", "resource_profiles":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.ResourceProfiles().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms ResourceProfiles) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "resource":
+			json.ReadResource(iter, &ms.orig.Resource)
+		case "scopeProfiles", "scope_profiles":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.ScopeProfiles().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "schemaUrl", "schema_url":
+			ms.orig.SchemaUrl = iter.ReadString()
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms ScopeProfiles) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "scope":
+			json.ReadScope(iter, &ms.orig.Scope)
+		case "profiles":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Profiles().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "schemaUrl", "schema_url":
+			ms.orig.SchemaUrl = iter.ReadString()
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms ProfileContainer) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "profileId", "profile_id":
+			id, err := hex.DecodeString(iter.ReadString())
+			if err != nil {
+				iter.ReportError("readProfileContainer.profileId", fmt.Sprintf("parse profile_id:%v", err))
+			}
+			ms.orig.ProfileId = id
+		case "startTimeUnixNano", "start_time_unix_nano":
+			ms.orig.StartTimeUnixNano = json.ReadUint64(iter)
+		case "endTimeUnixNano", "end_time_unix_nano":
+			ms.orig.EndTimeUnixNano = json.ReadUint64(iter)
+		case "attributes":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Attributes = append(ms.orig.Attributes, json.ReadAttribute(iter))
+				return true
+			})
+		case "droppedAttributesCount", "dropped_attributes_count":
+			ms.orig.DroppedAttributesCount = json.ReadUint32(iter)
+		case "originalPayloadFormat", "original_payload_format":
+			ms.orig.OriginalPayloadFormat = iter.ReadString()
+		case "originalPayload", "original_payload":
+			payload, err := base64.StdEncoding.DecodeString(iter.ReadString())
+			if err != nil {
+				iter.ReportError("readProfileContainer.originalPayload", fmt.Sprintf("parse original_payload:%v", err))
+			}
+			ms.orig.OriginalPayload = payload
+		case "profile":
+			ms.Profile().unmarshalJsoniter(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Profile) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "sampleType", "sample_type":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.SampleType().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "sample":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Sample().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "mapping":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Mapping().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "location":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Location().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "locationIndices", "location_indices":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.LocationIndices = append(ms.orig.LocationIndices, json.ReadInt64(iter))
+				return true
+			})
+		case "function":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Function().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "attributeTable", "attribute_table":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.AttributeTable = append(ms.orig.AttributeTable, json.ReadAttribute(iter))
+				return true
+			})
+		case "attributeUnits", "attribute_units":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.AttributeUnits().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "linkTable", "link_table":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.LinkTable().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "stringTable", "string_table":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.StringTable = append(ms.orig.StringTable, iter.ReadString())
+				return true
+			})
+		case "dropFrames", "drop_frames":
+			ms.orig.DropFrames = json.ReadInt64(iter)
+		case "keepFrames", "keep_frames":
+			ms.orig.KeepFrames = json.ReadInt64(iter)
+		case "timeNanos", "time_nanos":
+			ms.orig.TimeNanos = json.ReadInt64(iter)
+		case "durationNanos", "duration_nanos":
+			ms.orig.DurationNanos = json.ReadInt64(iter)
+		case "periodType", "period_type":
+			ms.PeriodType().unmarshalJsoniter(iter)
+		case "period":
+			ms.orig.Period = json.ReadInt64(iter)
+		case "comment":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Comment = append(ms.orig.Comment, json.ReadInt64(iter))
+				return true
+			})
+		case "defaultSampleType", "default_sample_type":
+			ms.orig.DefaultSampleType = json.ReadInt64(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms ValueType) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "type":
+			ms.orig.Type = json.ReadInt64(iter)
+		case "unit":
+			ms.orig.Unit = json.ReadInt64(iter)
+		case "aggregationTemporality", "aggregation_temporality":
+			ms.orig.AggregationTemporality = otlpprofiles.AggregationTemporality(json.ReadEnumValue(iter, otlpprofiles.AggregationTemporality_value))
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Sample) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "locationIndex", "location_index":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.LocationIndex = append(ms.orig.LocationIndex, json.ReadUint64(iter))
+				return true
+			})
+		case "locationsStartIndex", "locations_start_index":
+			ms.orig.LocationsStartIndex = json.ReadUint64(iter)
+		case "locationsLength", "locations_length":
+			ms.orig.LocationsLength = json.ReadUint64(iter)
+		case "stacktraceIdIndex", "stacktrace_id_index":
+			ms.orig.StacktraceIdIndex = json.ReadUint32(iter)
+		case "value":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Value = append(ms.orig.Value, json.ReadInt64(iter))
+				return true
+			})
+		case "label":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Label().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "attributes":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Attributes = append(ms.orig.Attributes, json.ReadUint64(iter))
+				return true
+			})
+		case "link":
+			ms.orig.Link = json.ReadUint64(iter)
+		case "timestampsUnixNano", "timestamps_unix_nano":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.TimestampsUnixNano = append(ms.orig.TimestampsUnixNano, json.ReadUint64(iter))
+				return true
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Label) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "key":
+			ms.orig.Key = json.ReadInt64(iter)
+		case "str":
+			ms.orig.Str = json.ReadInt64(iter)
+		case "num":
+			ms.orig.Num = json.ReadInt64(iter)
+		case "numUnit", "num_unit":
+			ms.orig.NumUnit = json.ReadInt64(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Mapping) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "id":
+			ms.orig.Id = json.ReadUint64(iter)
+		case "memoryStart", "memory_start":
+			ms.orig.MemoryStart = json.ReadUint64(iter)
+		case "memoryLimit", "memory_limit":
+			ms.orig.MemoryLimit = json.ReadUint64(iter)
+		case "fileOffset", "file_offset":
+			ms.orig.FileOffset = json.ReadUint64(iter)
+		case "filename":
+			ms.orig.Filename = json.ReadInt64(iter)
+		case "buildId", "build_id":
+			ms.orig.BuildId = json.ReadInt64(iter)
+		case "buildIdKind", "build_id_kind":
+			ms.orig.BuildIdKind = otlpprofiles.BuildIdKind(json.ReadEnumValue(iter, otlpprofiles.BuildIdKind_value))
+		case "attributes":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Attributes = append(ms.orig.Attributes, json.ReadUint64(iter))
+				return true
+			})
+		case "hasFunctions", "has_functions":
+			ms.orig.HasFunctions = iter.ReadBool()
+		case "hasFilenames", "has_filenames":
+			ms.orig.HasFilenames = iter.ReadBool()
+		case "hasLineNumbers", "has_line_numbers":
+			ms.orig.HasLineNumbers = iter.ReadBool()
+		case "hasInlineFrames", "has_inline_frames":
+			ms.orig.HasInlineFrames = iter.ReadBool()
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Location) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "id":
+			ms.orig.Id = json.ReadUint64(iter)
+		case "mappingIndex", "mapping_index":
+			ms.orig.MappingIndex = json.ReadUint64(iter)
+		case "address":
+			ms.orig.Address = json.ReadUint64(iter)
+		case "line":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.Line().AppendEmpty().unmarshalJsoniter(iter)
+				return true
+			})
+		case "isFolded", "is_folded":
+			ms.orig.IsFolded = iter.ReadBool()
+		case "typeIndex", "type_index":
+			ms.orig.TypeIndex = json.ReadUint32(iter)
+		case "attributes":
+			iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				ms.orig.Attributes = append(ms.orig.Attributes, json.ReadUint64(iter))
+				return true
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Line) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "functionIndex", "function_index":
+			ms.orig.FunctionIndex = json.ReadUint64(iter)
+		case "line":
+			ms.orig.Line = json.ReadInt64(iter)
+		case "column":
+			ms.orig.Column = json.ReadInt64(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Function) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "id":
+			ms.orig.Id = json.ReadUint64(iter)
+		case "name":
+			ms.orig.Name = json.ReadInt64(iter)
+		case "systemName", "system_name":
+			ms.orig.SystemName = json.ReadInt64(iter)
+		case "filename":
+			ms.orig.Filename = json.ReadInt64(iter)
+		case "startLine", "start_line":
+			ms.orig.StartLine = json.ReadInt64(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms AttributeUnit) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "attributeKey", "attribute_key":
+			ms.orig.AttributeKey = json.ReadInt64(iter)
+		case "unit":
+			ms.orig.Unit = json.ReadInt64(iter)
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
+
+func (ms Link) unmarshalJsoniter(iter *jsoniter.Iterator) {
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "traceId", "trace_id":
+			if err := ms.orig.TraceId.UnmarshalJSON([]byte(iter.ReadString())); err != nil {
+				iter.ReportError("readLink.traceId", fmt.Sprintf("parse trace_id:%v", err))
+			}
+		case "spanId", "span_id":
+			if err := ms.orig.SpanId.UnmarshalJSON([]byte(iter.ReadString())); err != nil {
+				iter.ReportError("readLink.spanId", fmt.Sprintf("parse span_id:%v", err))
+			}
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+}
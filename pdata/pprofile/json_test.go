@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile
+
+import (
+	"bytes"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1experimental"
+	"go.opentelemetry.io/collector/pdata/internal/json"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var _ Marshaler = (*JSONMarshaler)(nil)
+var _ Unmarshaler = (*JSONUnmarshaler)(nil)
+
+var profilesOTLP = func() Profiles {
+	pd := NewProfiles()
+	rp := pd.ResourceProfiles().AppendEmpty()
+	rp.SetSchemaUrl("schemaURL")
+	rp.Resource().Attributes().PutStr("host.name", "testHost")
+	sp := rp.ScopeProfiles().AppendEmpty()
+	sp.Scope().SetName("scope name")
+	sp.Scope().SetVersion("scope version")
+	sp.SetSchemaUrl("schemaURL")
+	pc := sp.Profiles().AppendEmpty()
+	pc.ProfileID().FromRaw([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+	pc.SetStartTime(1684617382541971000)
+	pc.SetEndTime(1684623646539558000)
+	pc.Attributes().PutStr("key", "value")
+	pc.SetDroppedAttributesCount(1)
+
+	p := pc.Profile()
+	p.StringTable().FromRaw([]string{"", "samples", "count", "main", "main.go", "thread", "/bin/app"})
+	st := p.SampleType().AppendEmpty()
+	st.SetType(1)
+	st.SetUnit(2)
+	st.SetAggregationTemporality(otlpprofiles.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE)
+	m := p.Mapping().AppendEmpty()
+	m.SetMemoryStart(0x1000)
+	m.SetMemoryLimit(0x2000)
+	m.SetFilename(6)
+	m.SetBuildIDKind(otlpprofiles.BuildIdKind_BUILD_ID_BINARY_HASH)
+	m.SetHasFunctions(true)
+	f := p.Function().AppendEmpty()
+	f.SetName(3)
+	f.SetFilename(4)
+	f.SetStartLine(10)
+	l := p.Location().AppendEmpty()
+	l.SetAddress(0x1100)
+	line := l.Line().AppendEmpty()
+	line.SetLine(12)
+	line.SetColumn(3)
+	p.LocationIndices().FromRaw([]int64{0})
+	s := p.Sample().AppendEmpty()
+	s.SetLocationsLength(1)
+	s.Value().FromRaw([]int64{5})
+	label := s.Label().AppendEmpty()
+	label.SetKey(5)
+	label.SetNum(7)
+	s.Attributes().FromRaw([]uint64{0})
+	s.SetLink(0)
+	s.TimestampsUnixNano().FromRaw([]uint64{1684617382541971000})
+	p.AttributeTable().PutStr("thread.name", "main")
+	au := p.AttributeUnits().AppendEmpty()
+	au.SetAttributeKey(5)
+	au.SetUnit(2)
+	link := p.LinkTable().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}))
+	link.SetSpanID(pcommon.SpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18}))
+	p.SetStartTime(1684617382541971000)
+	p.SetDuration(1000000000)
+	p.PeriodType().SetType(1)
+	p.PeriodType().SetUnit(2)
+	p.SetPeriod(10)
+	p.Comment().FromRaw([]int64{3})
+	p.SetDefaultSampleType(1)
+	return pd
+}()
+
+var profilesJSON = `{"resourceProfiles":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"testHost"}}]},"scopeProfiles":[{"scope":{"name":"scope name","version":"scope version"},"profiles":[{"profileId":"0102030405060708090a0b0c0d0e0f10","startTimeUnixNano":"1684617382541971000","endTimeUnixNano":"1684623646539558000","attributes":[{"key":"key","value":{"stringValue":"value"}}],"droppedAttributesCount":1,"profile":{"sampleType":[{"type":"1","unit":"2","aggregationTemporality":2}],"sample":[{"locationsLength":"1","value":["5"],"label":[{"key":"5","num":"7"}],"attributes":["0"],"timestampsUnixNano":["1684617382541971000"]}],"mapping":[{"memoryStart":"4096","memoryLimit":"8192","filename":"6","buildIdKind":1,"hasFunctions":true}],"location":[{"address":"4352","line":[{"line":"12","column":"3"}]}],"locationIndices":["0"],"function":[{"name":"3","filename":"4","startLine":"10"}],"attributeTable":[{"key":"thread.name","value":{"stringValue":"main"}}],"attributeUnits":[{"attributeKey":"5","unit":"2"}],"linkTable":[{"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"1112131415161718"}],"stringTable":["","samples","count","main","main.go","thread","/bin/app"],"timeNanos":"1684617382541971000","durationNanos":"1000000000","periodType":{"type":"1","unit":"2"},"period":"10","comment":["3"],"defaultSampleType":"1"}}],"schemaUrl":"schemaURL"}],"schemaUrl":"schemaURL"}]}`
+
+func TestJSONUnmarshal(t *testing.T) {
+	decoder := &JSONUnmarshaler{}
+	got, err := decoder.UnmarshalProfiles([]byte(profilesJSON))
+	assert.NoError(t, err)
+	assert.EqualValues(t, profilesOTLP, got)
+}
+
+func TestJSONMarshal(t *testing.T) {
+	encoder := &JSONMarshaler{}
+	jsonBuf, err := encoder.MarshalProfiles(profilesOTLP)
+	assert.NoError(t, err)
+	assert.Equal(t, profilesJSON, string(jsonBuf))
+}
+
+func TestJSONMarshalLikeJSONPB(t *testing.T) {
+	// Without profile IDs, the profiles are marshaled like jsonpb marshals them.
+	pd := NewProfiles()
+	profilesOTLP.CopyTo(pd)
+	pc := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+	pc.orig.ProfileId = nil
+	pc.orig.OriginalPayloadFormat = "pprof"
+	pc.orig.OriginalPayload = []byte("foo")
+	pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+
+	buf := bytes.Buffer{}
+	pb := internal.ProfilesToProto(internal.Profiles(pd))
+	require.NoError(t, json.Marshal(&buf, &pb))
+	jsonBuf, err := (&JSONMarshaler{}).MarshalProfiles(pd)
+	require.NoError(t, err)
+	assert.Equal(t, buf.String(), string(jsonBuf))
+
+	got, err := (&JSONUnmarshaler{}).UnmarshalProfiles(jsonBuf)
+	require.NoError(t, err)
+	assert.EqualValues(t, pd, got)
+}
+
+func TestJSONMarshalEmpty(t *testing.T) {
+	jsonBuf, err := (&JSONMarshaler{}).MarshalProfiles(NewProfiles())
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(jsonBuf))
+}
+
+func TestJSONUnmarshalInvalid(t *testing.T) {
+	jsonStr := `{"extra":"", "resourceProfiles": "extra"}`
+	decoder := &JSONUnmarshaler{}
+	_, err := decoder.UnmarshalProfiles([]byte(jsonStr))
+	assert.Error(t, err)
+}
+
+func TestUnmarshalJsoniterProfileData(t *testing.T) {
+	jsonStr := `{"extra":"", "resourceProfiles": [{"extra":""}]}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	val := NewProfiles()
+	val.unmarshalJsoniter(iter)
+	assert.NoError(t, iter.Error)
+	assert.Equal(t, 1, val.ResourceProfiles().Len())
+}
+
+func TestUnmarshalJsoniterResourceProfiles(t *testing.T) {
+	jsonStr := `{"extra":"", "resource": {}, "scopeProfiles": []}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	val := NewResourceProfiles()
+	val.unmarshalJsoniter(iter)
+	assert.NoError(t, iter.Error)
+	assert.Equal(t, NewResourceProfiles(), val)
+}
+
+func TestUnmarshalJsoniterScopeProfiles(t *testing.T) {
+	jsonStr := `{"extra":"", "scope": {}, "profiles": []}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	val := NewScopeProfiles()
+	val.unmarshalJsoniter(iter)
+	assert.NoError(t, iter.Error)
+	assert.Equal(t, NewScopeProfiles(), val)
+}
+
+func TestUnmarshalJsoniterProfileContainer(t *testing.T) {
+	jsonStr := `{"extra":"", "profile": {"extra":""}}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	val := NewProfileContainer()
+	val.unmarshalJsoniter(iter)
+	assert.NoError(t, iter.Error)
+	assert.Equal(t, NewProfileContainer(), val)
+}
+
+func TestUnmarshalJsoniterProfileSnakeCase(t *testing.T) {
+	jsonStr := `{"profile_id":"0102", "start_time_unix_nano":"1", "profile": {"sample_type":[{"aggregation_temporality":"AGGREGATION_TEMPORALITY_DELTA"}],
+		"location_indices":[2], "string_table":["", "a"], "default_sample_type":"1",
+		"mapping":[{"build_id_kind":"BUILD_ID_BINARY_HASH", "has_inline_frames":true}],
+		"location":[{"mapping_index":"1", "is_folded":true, "line":[{"function_index":"1"}]}],
+		"sample":[{"locations_start_index":"1", "location_index":["3"], "timestamps_unix_nano":["4"]}]}}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	val := NewProfileContainer()
+	val.unmarshalJsoniter(iter)
+	require.NoError(t, iter.Error)
+	assert.Equal(t, []byte{1, 2}, val.ProfileID().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(1), val.StartTime())
+	p := val.Profile()
+	assert.Equal(t, otlpprofiles.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, p.SampleType().At(0).AggregationTemporality())
+	assert.Equal(t, []int64{2}, p.LocationIndices().AsRaw())
+	assert.Equal(t, []string{"", "a"}, p.StringTable().AsRaw())
+	assert.Equal(t, int64(1), p.DefaultSampleType())
+	assert.Equal(t, otlpprofiles.BuildIdKind_BUILD_ID_BINARY_HASH, p.Mapping().At(0).BuildIDKind())
+	assert.True(t, p.Mapping().At(0).HasInlineFrames())
+	assert.Equal(t, uint64(1), p.Location().At(0).MappingIndex())
+	assert.True(t, p.Location().At(0).IsFolded())
+	assert.Equal(t, uint64(1), p.Location().At(0).Line().At(0).FunctionIndex())
+	assert.Equal(t, uint64(1), p.Sample().At(0).LocationsStartIndex())
+	assert.Equal(t, []uint64{3}, p.Sample().At(0).LocationIndex().AsRaw())
+	assert.Equal(t, []uint64{4}, p.Sample().At(0).TimestampsUnixNano().AsRaw())
+}
+
+func TestUnmarshalJsoniterProfileContainerInvalidProfileIDField(t *testing.T) {
+	jsonStr := `{"profile_id":"--"}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	NewProfileContainer().unmarshalJsoniter(iter)
+	if assert.Error(t, iter.Error) {
+		assert.Contains(t, iter.Error.Error(), "parse profile_id")
+	}
+}
+
+func TestUnmarshalJsoniterProfileContainerInvalidOriginalPayloadField(t *testing.T) {
+	jsonStr := `{"originalPayload":"--"}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	NewProfileContainer().unmarshalJsoniter(iter)
+	if assert.Error(t, iter.Error) {
+		assert.Contains(t, iter.Error.Error(), "parse original_payload")
+	}
+}
+
+func TestUnmarshalJsoniterLinkInvalidTraceIDField(t *testing.T) {
+	jsonStr := `{"trace_id":"--"}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	NewLink().unmarshalJsoniter(iter)
+	if assert.Error(t, iter.Error) {
+		assert.Contains(t, iter.Error.Error(), "parse trace_id")
+	}
+}
+
+func TestUnmarshalJsoniterLinkInvalidSpanIDField(t *testing.T) {
+	jsonStr := `{"span_id":"--"}`
+	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
+	defer jsoniter.ConfigFastest.ReturnIterator(iter)
+	NewLink().unmarshalJsoniter(iter)
+	if assert.Error(t, iter.Error) {
+		assert.Contains(t, iter.Error.Error(), "parse span_id")
+	}
+}
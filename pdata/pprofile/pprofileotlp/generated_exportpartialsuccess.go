@@ -32,8 +32,8 @@ func newExportPartialSuccess(orig *otlpcollectorprofile.ExportProfilesPartialSuc
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewExportPartialSuccess() ExportPartialSuccess {
-	state := internal.StateMutable
-	return newExportPartialSuccess(&otlpcollectorprofile.ExportProfilesPartialSuccess{}, &state)
+	state := internal.NewState()
+	return newExportPartialSuccess(&otlpcollectorprofile.ExportProfilesPartialSuccess{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -43,6 +43,9 @@ func (ms ExportPartialSuccess) MoveTo(dest ExportPartialSuccess) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpcollectorprofile.ExportProfilesPartialSuccess{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // RejectedProfiles returns the rejectedprofiles associated with this ExportPartialSuccess.
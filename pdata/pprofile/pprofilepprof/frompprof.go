@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pprofilepprof converts profiles between pprofile.Profiles and the pprof format of
+// github.com/google/pprof/profile, read and written by `go tool pprof` and the Go runtime.
+package pprofilepprof // import "go.opentelemetry.io/collector/pdata/pprofile/pprofilepprof"
+
+import (
+	"sort"
+
+	"github.com/google/pprof/profile"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// FromPprof converts the pprof profile to Profiles holding a single profile, with an empty resource and scope.
+func FromPprof(src *profile.Profile) pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	ContainerFromPprof(pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty(), src)
+	return pd
+}
+
+// ContainerFromPprof sets dst to the conversion of the pprof profile. The profile container starts
+// at the time of the pprof profile, and ends after its duration.
+//
+// The samples reference their locations through Profile.LocationIndices, and their labels are converted to
+// Sample.Label. The IDs of the mappings, locations and functions, which are only used by the pprof encoding,
+// are not kept. A location of an unknown mapping refers to the first mapping, which is the main binary.
+func ContainerFromPprof(dst pprofile.ProfileContainer, src *profile.Profile) {
+	pprofile.NewProfileContainer().CopyTo(dst)
+	dst.SetStartTime(pcommon.Timestamp(src.TimeNanos))
+	dst.SetEndTime(pcommon.Timestamp(src.TimeNanos + src.DurationNanos))
+
+	p := dst.Profile()
+	strs := newStringTable(p.StringTable())
+	for _, st := range src.SampleType {
+		setValueType(p.SampleType().AppendEmpty(), st, strs)
+	}
+	if src.DefaultSampleType != "" {
+		p.SetDefaultSampleType(strs.index(src.DefaultSampleType))
+	}
+
+	mappings := make(map[*profile.Mapping]uint64, len(src.Mapping))
+	for i, m := range src.Mapping {
+		mappings[m] = uint64(i)
+		dm := p.Mapping().AppendEmpty()
+		dm.SetMemoryStart(m.Start)
+		dm.SetMemoryLimit(m.Limit)
+		dm.SetFileOffset(m.Offset)
+		dm.SetFilename(strs.index(m.File))
+		dm.SetBuildID(strs.index(m.BuildID))
+		dm.SetHasFunctions(m.HasFunctions)
+		dm.SetHasFilenames(m.HasFilenames)
+		dm.SetHasLineNumbers(m.HasLineNumbers)
+		dm.SetHasInlineFrames(m.HasInlineFrames)
+	}
+
+	functions := make(map[*profile.Function]uint64, len(src.Function))
+	for i, f := range src.Function {
+		functions[f] = uint64(i)
+		df := p.Function().AppendEmpty()
+		df.SetName(strs.index(f.Name))
+		df.SetSystemName(strs.index(f.SystemName))
+		df.SetFilename(strs.index(f.Filename))
+		df.SetStartLine(f.StartLine)
+	}
+
+	locations := make(map[*profile.Location]int64, len(src.Location))
+	for i, l := range src.Location {
+		locations[l] = int64(i)
+		dl := p.Location().AppendEmpty()
+		dl.SetMappingIndex(mappings[l.Mapping])
+		dl.SetAddress(l.Address)
+		dl.SetIsFolded(l.IsFolded)
+		for _, line := range l.Line {
+			dline := dl.Line().AppendEmpty()
+			dline.SetFunctionIndex(functions[line.Function])
+			dline.SetLine(line.Line)
+			dline.SetColumn(line.Column)
+		}
+	}
+
+	for _, s := range src.Sample {
+		ds := p.Sample().AppendEmpty()
+		ds.SetLocationsStartIndex(uint64(p.LocationIndices().Len()))
+		ds.SetLocationsLength(uint64(len(s.Location)))
+		for _, l := range s.Location {
+			p.LocationIndices().Append(locations[l])
+		}
+		ds.Value().FromRaw(s.Value)
+		setLabels(ds.Label(), s, strs)
+	}
+
+	p.SetDropFrames(strs.index(src.DropFrames))
+	p.SetKeepFrames(strs.index(src.KeepFrames))
+	p.SetStartTime(pcommon.Timestamp(src.TimeNanos))
+	p.SetDuration(pcommon.Timestamp(src.DurationNanos))
+	if src.PeriodType != nil {
+		setValueType(p.PeriodType(), src.PeriodType, strs)
+	}
+	p.SetPeriod(src.Period)
+	for _, comment := range src.Comments {
+		p.Comment().Append(strs.index(comment))
+	}
+}
+
+func setValueType(dst pprofile.ValueType, src *profile.ValueType, strs *stringTable) {
+	dst.SetType(strs.index(src.Type))
+	dst.SetUnit(strs.index(src.Unit))
+}
+
+// setLabels converts the labels of the sample, sorted by key. The numeric labels follow the string labels.
+func setLabels(dst pprofile.LabelSlice, s *profile.Sample, strs *stringTable) {
+	for _, key := range sortedKeys(s.Label) {
+		for _, value := range s.Label[key] {
+			l := dst.AppendEmpty()
+			l.SetKey(strs.index(key))
+			l.SetStr(strs.index(value))
+		}
+	}
+	for _, key := range sortedKeys(s.NumLabel) {
+		units := s.NumUnit[key]
+		for i, num := range s.NumLabel[key] {
+			l := dst.AppendEmpty()
+			l.SetKey(strs.index(key))
+			l.SetNum(num)
+			if i < len(units) && units[i] != "" {
+				l.SetNumUnit(strs.index(units[i]))
+			}
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringTable builds the string table of a profile, whose first string is always the empty string.
+type stringTable struct {
+	strings pcommon.StringSlice
+	indices map[string]int64
+}
+
+func newStringTable(strings pcommon.StringSlice) *stringTable {
+	strings.FromRaw([]string{""})
+	return &stringTable{strings: strings, indices: map[string]int64{"": 0}}
+}
+
+// index returns the index of the string in the table, after adding it if it is missing.
+func (st *stringTable) index(s string) int64 {
+	if i, ok := st.indices[s]; ok {
+		return i
+	}
+	i := int64(st.strings.Len())
+	st.strings.Append(s)
+	st.indices[s] = i
+	return i
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofilepprof
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+func newPprof() *profile.Profile {
+	mapping := &profile.Mapping{ID: 1, Start: 0x1000, Limit: 0x2000, Offset: 0x10, File: "/bin/app", BuildID: "abc", HasFunctions: true}
+	main := &profile.Function{ID: 1, Name: "main.main", SystemName: "main.main", Filename: "main.go", StartLine: 10}
+	work := &profile.Function{ID: 2, Name: "main.work", SystemName: "main.work", Filename: "work.go", StartLine: 20}
+	mainLoc := &profile.Location{ID: 1, Mapping: mapping, Address: 0x1100, Line: []profile.Line{{Function: main, Line: 12, Column: 3}}}
+	workLoc := &profile.Location{ID: 2, Mapping: mapping, Address: 0x1200, Line: []profile.Line{{Function: work, Line: 25}, {Function: main, Line: 13}}}
+	return &profile.Profile{
+		SampleType:        []*profile.ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}},
+		DefaultSampleType: "cpu",
+		Sample: []*profile.Sample{
+			{
+				Location: []*profile.Location{workLoc, mainLoc},
+				Value:    []int64{2, 20000000},
+				Label:    map[string][]string{"thread": {"worker"}},
+				NumLabel: map[string][]int64{"bytes": {512, 1024}},
+				NumUnit:  map[string][]string{"bytes": {"bytes", "bytes"}},
+			},
+			{
+				Location: []*profile.Location{mainLoc},
+				Value:    []int64{1, 10000000},
+			},
+		},
+		Mapping:       []*profile.Mapping{mapping},
+		Location:      []*profile.Location{mainLoc, workLoc},
+		Function:      []*profile.Function{main, work},
+		Comments:      []string{"a comment"},
+		DropFrames:    "runtime\\..*",
+		TimeNanos:     1000000000,
+		DurationNanos: 500000000,
+		PeriodType:    &profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:        10000000,
+	}
+}
+
+func TestFromPprof(t *testing.T) {
+	pd := FromPprof(newPprof())
+	require.Equal(t, 1, pd.ResourceProfiles().Len())
+	pc := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+	assert.Equal(t, pcommon.Timestamp(1000000000), pc.StartTime())
+	assert.Equal(t, pcommon.Timestamp(1500000000), pc.EndTime())
+
+	p := pc.Profile()
+	str := func(i int64) string { return p.StringTable().At(int(i)) }
+	assert.Equal(t, "", p.StringTable().At(0))
+	require.Equal(t, 2, p.SampleType().Len())
+	assert.Equal(t, "cpu", str(p.SampleType().At(1).Type()))
+	assert.Equal(t, "nanoseconds", str(p.SampleType().At(1).Unit()))
+	assert.Equal(t, "cpu", str(p.DefaultSampleType()))
+	assert.Equal(t, "runtime\\..*", str(p.DropFrames()))
+	assert.Equal(t, int64(0), p.KeepFrames())
+	assert.Equal(t, "a comment", str(p.Comment().At(0)))
+	assert.Equal(t, int64(10000000), p.Period())
+
+	require.Equal(t, 1, p.Mapping().Len())
+	assert.Equal(t, "/bin/app", str(p.Mapping().At(0).Filename()))
+	assert.Equal(t, uint64(0x2000), p.Mapping().At(0).MemoryLimit())
+
+	require.Equal(t, 2, p.Location().Len())
+	workLoc := p.Location().At(1)
+	assert.Equal(t, uint64(0), workLoc.MappingIndex())
+	require.Equal(t, 2, workLoc.Line().Len())
+	assert.Equal(t, "main.work", str(p.Function().At(int(workLoc.Line().At(0).FunctionIndex())).Name()))
+	assert.Equal(t, "main.main", str(p.Function().At(int(workLoc.Line().At(1).FunctionIndex())).Name()))
+
+	require.Equal(t, 2, p.Sample().Len())
+	s := p.Sample().At(0)
+	assert.Equal(t, []int64{2, 20000000}, s.Value().AsRaw())
+	assert.Equal(t, []int64{1, 0}, p.LocationIndices().AsRaw()[s.LocationsStartIndex():s.LocationsStartIndex()+s.LocationsLength()])
+	require.Equal(t, 3, s.Label().Len())
+	assert.Equal(t, "thread", str(s.Label().At(0).Key()))
+	assert.Equal(t, "worker", str(s.Label().At(0).Str()))
+	assert.Equal(t, "bytes", str(s.Label().At(2).Key()))
+	assert.Equal(t, int64(1024), s.Label().At(2).Num())
+	assert.Equal(t, "bytes", str(s.Label().At(2).NumUnit()))
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := newPprof()
+	require.NoError(t, src.CheckValid())
+	profiles, err := ToPprof(FromPprof(src))
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.NoError(t, profiles[0].CheckValid())
+	assert.Equal(t, src.String(), profiles[0].String())
+}
+
+func TestRoundTripRuntimeProfile(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, pprof.Lookup("heap").WriteTo(&buf, 0))
+	src, err := profile.Parse(&buf)
+	require.NoError(t, err)
+
+	profiles, err := ToPprof(FromPprof(src))
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.NoError(t, profiles[0].CheckValid())
+	assert.Equal(t, src.String(), profiles[0].String())
+
+	// The converted profile can be written and read by pprof.
+	buf.Reset()
+	require.NoError(t, profiles[0].Write(&buf))
+	parsed, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, src.String(), parsed.String())
+}
+
+func TestContainerToPprofAttributes(t *testing.T) {
+	pc := pprofile.NewProfileContainer()
+	pc.SetStartTime(1000)
+	pc.SetEndTime(3000)
+	p := pc.Profile()
+	p.StringTable().FromRaw([]string{"", "samples", "count", "size", "bytes"})
+	st := p.SampleType().AppendEmpty()
+	st.SetType(1)
+	st.SetUnit(2)
+	p.AttributeTable().PutStr("thread", "main")
+	p.AttributeTable().PutInt("size", 64)
+	p.AttributeTable().PutBool("sampled", true)
+	unit := p.AttributeUnits().AppendEmpty()
+	unit.SetAttributeKey(3)
+	unit.SetUnit(4)
+	p.Location().AppendEmpty().SetAddress(0x10)
+	s := p.Sample().AppendEmpty()
+	s.LocationIndex().FromRaw([]uint64{0})
+	s.Value().FromRaw([]int64{3})
+	s.Attributes().FromRaw([]uint64{0, 1, 2})
+
+	dst, err := ContainerToPprof(pc)
+	require.NoError(t, err)
+	require.NoError(t, dst.CheckValid())
+	assert.Equal(t, int64(1000), dst.TimeNanos)
+	assert.Equal(t, int64(2000), dst.DurationNanos)
+	assert.Empty(t, dst.Mapping)
+	require.Len(t, dst.Sample, 1)
+	require.Len(t, dst.Sample[0].Location, 1)
+	assert.Nil(t, dst.Sample[0].Location[0].Mapping)
+	assert.Equal(t, uint64(0x10), dst.Sample[0].Location[0].Address)
+	assert.Equal(t, map[string][]string{"thread": {"main"}, "sampled": {"true"}}, dst.Sample[0].Label)
+	assert.Equal(t, map[string][]int64{"size": {64}}, dst.Sample[0].NumLabel)
+	assert.Equal(t, map[string][]string{"size": {"bytes"}}, dst.Sample[0].NumUnit)
+}
+
+func TestContainerToPprofInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p pprofile.Profile)
+		err    string
+	}{
+		{
+			name:   "string",
+			modify: func(p pprofile.Profile) { p.SetDropFrames(100) },
+			err:    "invalid string table index 100",
+		},
+		{
+			name:   "mapping",
+			modify: func(p pprofile.Profile) { p.Location().At(0).SetMappingIndex(5) },
+			err:    "invalid mapping table index 5",
+		},
+		{
+			name:   "function",
+			modify: func(p pprofile.Profile) { p.Location().At(0).Line().At(0).SetFunctionIndex(5) },
+			err:    "invalid function table index 5",
+		},
+		{
+			name:   "location",
+			modify: func(p pprofile.Profile) { p.LocationIndices().SetAt(0, 5) },
+			err:    "invalid location table index 5",
+		},
+		{
+			name:   "location indices",
+			modify: func(p pprofile.Profile) { p.Sample().At(0).SetLocationsLength(10) },
+			err:    "invalid location indices index 3",
+		},
+		{
+			name:   "attribute",
+			modify: func(p pprofile.Profile) { p.Sample().At(0).Attributes().Append(3) },
+			err:    "invalid attribute table index 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := FromPprof(newPprof())
+			tt.modify(pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).Profile())
+			_, err := ToPprof(pd)
+			assert.EqualError(t, err, "resource profiles 0, scope profiles 0, profile 0: "+tt.err)
+		})
+	}
+}
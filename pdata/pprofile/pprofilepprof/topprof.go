@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofilepprof // import "go.opentelemetry.io/collector/pdata/pprofile/pprofilepprof"
+
+import (
+	"fmt"
+
+	"github.com/google/pprof/profile"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// ToPprof converts each profile of pd to a pprof profile, in the order of the resources and scopes.
+func ToPprof(pd pprofile.Profiles) ([]*profile.Profile, error) {
+	var profiles []*profile.Profile
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		sps := pd.ResourceProfiles().At(i).ScopeProfiles()
+		for j := 0; j < sps.Len(); j++ {
+			pcs := sps.At(j).Profiles()
+			for k := 0; k < pcs.Len(); k++ {
+				p, err := ContainerToPprof(pcs.At(k))
+				if err != nil {
+					return nil, fmt.Errorf("resource profiles %d, scope profiles %d, profile %d: %w", i, j, k, err)
+				}
+				profiles = append(profiles, p)
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// ContainerToPprof converts the profile of the container to a pprof profile. The time and duration of the
+// profile default to the ones of the container.
+//
+// The locations of a sample are the ones referenced by its Sample.LocationsStartIndex and
+// Sample.LocationsLength, or else by its deprecated Sample.LocationIndex. Its Sample.Label and
+// Sample.Attributes are converted to labels: the integer attributes are numeric labels, with the unit
+// of their key in Profile.AttributeUnits, and the other attributes are string labels.
+//
+// The IDs of the pprof mappings, locations and functions are their index in the profile, starting at 1.
+// It returns an error if the profile references a missing element of its tables.
+func ContainerToPprof(src pprofile.ProfileContainer) (*profile.Profile, error) {
+	p := src.Profile()
+	c := &toPprof{strings: p.StringTable()}
+	dst := &profile.Profile{
+		DefaultSampleType: c.string(p.DefaultSampleType()),
+		DropFrames:        c.string(p.DropFrames()),
+		KeepFrames:        c.string(p.KeepFrames()),
+		TimeNanos:         int64(p.StartTime()),
+		DurationNanos:     int64(p.Duration()),
+		Period:            p.Period(),
+	}
+	if dst.TimeNanos == 0 {
+		dst.TimeNanos = int64(src.StartTime())
+	}
+	if dst.DurationNanos == 0 && src.EndTime() > src.StartTime() {
+		dst.DurationNanos = int64(src.EndTime() - src.StartTime())
+	}
+	for i := 0; i < p.SampleType().Len(); i++ {
+		dst.SampleType = append(dst.SampleType, c.valueType(p.SampleType().At(i)))
+	}
+	if pt := p.PeriodType(); pt.Type() != 0 || pt.Unit() != 0 {
+		dst.PeriodType = c.valueType(pt)
+	}
+	for i := 0; i < p.Comment().Len(); i++ {
+		dst.Comments = append(dst.Comments, c.string(p.Comment().At(i)))
+	}
+
+	for i := 0; i < p.Mapping().Len(); i++ {
+		m := p.Mapping().At(i)
+		dst.Mapping = append(dst.Mapping, &profile.Mapping{
+			ID:              uint64(i + 1),
+			Start:           m.MemoryStart(),
+			Limit:           m.MemoryLimit(),
+			Offset:          m.FileOffset(),
+			File:            c.string(m.Filename()),
+			BuildID:         c.string(m.BuildID()),
+			HasFunctions:    m.HasFunctions(),
+			HasFilenames:    m.HasFilenames(),
+			HasLineNumbers:  m.HasLineNumbers(),
+			HasInlineFrames: m.HasInlineFrames(),
+		})
+	}
+
+	for i := 0; i < p.Function().Len(); i++ {
+		f := p.Function().At(i)
+		dst.Function = append(dst.Function, &profile.Function{
+			ID:         uint64(i + 1),
+			Name:       c.string(f.Name()),
+			SystemName: c.string(f.SystemName()),
+			Filename:   c.string(f.Filename()),
+			StartLine:  f.StartLine(),
+		})
+	}
+
+	for i := 0; i < p.Location().Len(); i++ {
+		l := p.Location().At(i)
+		dl := &profile.Location{
+			ID:       uint64(i + 1),
+			Address:  l.Address(),
+			IsFolded: l.IsFolded(),
+		}
+		// Without mappings, the zero mapping index stands for an unknown mapping.
+		if l.MappingIndex() != 0 || len(dst.Mapping) > 0 {
+			dl.Mapping = element(c, dst.Mapping, l.MappingIndex(), "mapping")
+		}
+		for j := 0; j < l.Line().Len(); j++ {
+			line := l.Line().At(j)
+			dl.Line = append(dl.Line, profile.Line{
+				Function: element(c, dst.Function, line.FunctionIndex(), "function"),
+				Line:     line.Line(),
+				Column:   line.Column(),
+			})
+		}
+		dst.Location = append(dst.Location, dl)
+	}
+
+	attributes := attributeTable(p.AttributeTable())
+	units := make(map[string]string, p.AttributeUnits().Len())
+	for i := 0; i < p.AttributeUnits().Len(); i++ {
+		unit := p.AttributeUnits().At(i)
+		units[c.string(unit.AttributeKey())] = c.string(unit.Unit())
+	}
+	for i := 0; i < p.Sample().Len(); i++ {
+		s := p.Sample().At(i)
+		ds := &profile.Sample{Value: s.Value().AsRaw()}
+		if s.LocationsLength() > 0 {
+			for j := s.LocationsStartIndex(); j < s.LocationsStartIndex()+s.LocationsLength(); j++ {
+				if j >= uint64(p.LocationIndices().Len()) {
+					c.fail(fmt.Errorf("invalid location indices index %d", j))
+					break
+				}
+				ds.Location = append(ds.Location, element(c, dst.Location, uint64(p.LocationIndices().At(int(j))), "location"))
+			}
+		} else {
+			for j := 0; j < s.LocationIndex().Len(); j++ {
+				ds.Location = append(ds.Location, element(c, dst.Location, s.LocationIndex().At(j), "location"))
+			}
+		}
+		for j := 0; j < s.Label().Len(); j++ {
+			l := s.Label().At(j)
+			if l.Str() != 0 {
+				addLabel(ds, c.string(l.Key()), c.string(l.Str()))
+			} else if l.Num() != 0 || l.NumUnit() != 0 {
+				addNumLabel(ds, c.string(l.Key()), l.Num(), c.string(l.NumUnit()))
+			}
+		}
+		for j := 0; j < s.Attributes().Len(); j++ {
+			attr := element(c, attributes, s.Attributes().At(j), "attribute")
+			if c.err != nil {
+				break
+			}
+			if attr.value.Type() == pcommon.ValueTypeInt {
+				addNumLabel(ds, attr.key, attr.value.Int(), units[attr.key])
+			} else {
+				addLabel(ds, attr.key, attr.value.AsString())
+			}
+		}
+		dst.Sample = append(dst.Sample, ds)
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	return dst, nil
+}
+
+// toPprof converts the elements of a profile, and keeps the first error met.
+type toPprof struct {
+	strings pcommon.StringSlice
+	err     error
+}
+
+func (c *toPprof) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (c *toPprof) string(i int64) string {
+	if i < 0 || i >= int64(c.strings.Len()) {
+		c.fail(fmt.Errorf("invalid string table index %d", i))
+		return ""
+	}
+	return c.strings.At(int(i))
+}
+
+func (c *toPprof) valueType(vt pprofile.ValueType) *profile.ValueType {
+	return &profile.ValueType{Type: c.string(vt.Type()), Unit: c.string(vt.Unit())}
+}
+
+// element returns the element of the table at the index, or the zero value if the index is invalid.
+func element[T any](c *toPprof, table []T, i uint64, name string) T {
+	if i >= uint64(len(table)) {
+		c.fail(fmt.Errorf("invalid %s table index %d", name, i))
+		var zero T
+		return zero
+	}
+	return table[i]
+}
+
+type attribute struct {
+	key   string
+	value pcommon.Value
+}
+
+// attributeTable returns the attributes of the table in their order, which is the one of their indices.
+func attributeTable(m pcommon.Map) []attribute {
+	attributes := make([]attribute, 0, m.Len())
+	m.Range(func(k string, v pcommon.Value) bool {
+		attributes = append(attributes, attribute{key: k, value: v})
+		return true
+	})
+	return attributes
+}
+
+func addLabel(s *profile.Sample, key, value string) {
+	if s.Label == nil {
+		s.Label = make(map[string][]string)
+	}
+	s.Label[key] = append(s.Label[key], value)
+}
+
+// addNumLabel adds the numeric label, with its unit if not empty. As in the pprof decoder, the units of a key
+// are aligned with its values once one of them is not empty.
+func addNumLabel(s *profile.Sample, key string, value int64, unit string) {
+	if s.NumLabel == nil {
+		s.NumLabel = make(map[string][]int64)
+	}
+	values := s.NumLabel[key]
+	if unit != "" || len(s.NumUnit[key]) > 0 {
+		if s.NumUnit == nil {
+			s.NumUnit = make(map[string][]string)
+		}
+		units := s.NumUnit[key]
+		for len(units) < len(values) {
+			units = append(units, "")
+		}
+		s.NumUnit[key] = append(units, unit)
+	}
+	s.NumLabel[key] = append(values, value)
+}
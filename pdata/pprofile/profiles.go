@@ -13,8 +13,8 @@ import (
 type Profiles internal.Profiles
 
 func newProfiles(orig *otlpcollectorprofile.ExportProfilesServiceRequest) Profiles {
-	state := internal.StateMutable
-	return Profiles(internal.NewProfiles(orig, &state))
+	state := internal.NewState()
+	return Profiles(internal.NewProfiles(orig, state))
 }
 
 func (ms Profiles) getOrig() *otlpcollectorprofile.ExportProfilesServiceRequest {
@@ -32,7 +32,7 @@ func NewProfiles() Profiles {
 
 // IsReadOnly returns true if this ResourceProfiles instance is read-only.
 func (ms Profiles) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Profiles instance overriding the destination.
@@ -47,5 +47,5 @@ func (ms Profiles) ResourceProfiles() ResourceProfilesSlice {
 
 // MarkReadOnly marks the ResourceProfiles as shared so that no further modifications can be done on it.
 func (ms Profiles) MarkReadOnly() {
-	internal.SetProfilesState(internal.Profiles(ms), internal.StateReadOnly)
+	ms.getState().MarkReadOnly()
 }
@@ -33,8 +33,8 @@ func newResourceSpans(orig *otlptrace.ResourceSpans, state *internal.State) Reso
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceSpans() ResourceSpans {
-	state := internal.StateMutable
-	return newResourceSpans(&otlptrace.ResourceSpans{}, &state)
+	state := internal.NewState()
+	return newResourceSpans(&otlptrace.ResourceSpans{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,10 @@ func (ms ResourceSpans) MoveTo(dest ResourceSpans) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ResourceSpans{}
+	dest.state.InheritShared(ms.state)
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Resource returns the resource associated with this ResourceSpans.
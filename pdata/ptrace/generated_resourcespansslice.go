@@ -33,8 +33,8 @@ func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *internal.Sta
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceSpansSlice() ResourceSpansSlice {
 	orig := []*otlptrace.ResourceSpans(nil)
-	state := internal.StateMutable
-	return newResourceSpansSlice(&orig, &state)
+	state := internal.NewState()
+	return newResourceSpansSlice(&orig, state)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,7 +53,7 @@ func (es ResourceSpansSlice) Len() int {
 //	    ... // Do something with the element
 //	}
 func (es ResourceSpansSlice) At(i int) ResourceSpans {
-	return newResourceSpans((*es.orig)[i], es.state)
+	return newResourceSpans(internal.LoadNode(es.state, &(*es.orig)[i], cloneSharedResourceSpans), es.state)
 }
 
 // EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
@@ -99,6 +99,9 @@ func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
 	} else {
 		*dest.orig = append(*dest.orig, *es.orig...)
 	}
+	dest.state.InheritShared(es.state)
+	// The maps of the elements are moved.
+	es.state.ResetMapIndexes()
 	*es.orig = nil
 }
 
@@ -119,18 +122,28 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 		(*es.orig)[newLen] = (*es.orig)[i]
 		newLen++
 	}
+	if newLen < len(*es.orig) {
+		// The maps of the removed elements are discarded.
+		es.state.ResetMapIndexes()
+	}
 	*es.orig = (*es.orig)[:newLen]
 }
 
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	dest.state.AssertMutable()
+	// The maps of the elements of the destination are discarded.
+	dest.state.ResetMapIndexes()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans((*dest.orig)[i], dest.state))
+			if dest.state.Unshare((*dest.orig)[i]) {
+				// The shared element is overridden, so it does not need to be cloned.
+				(*dest.orig)[i] = &otlptrace.ResourceSpans{}
+			}
+			newResourceSpans(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newResourceSpans((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -138,7 +151,7 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	wrappers := make([]*otlptrace.ResourceSpans, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans(wrappers[i], dest.state))
+		newResourceSpans(internal.ReadNode(es.state, &(*es.orig)[i]), es.state).CopyTo(newResourceSpans(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -19,8 +19,8 @@ import (
 func TestResourceSpansSlice(t *testing.T) {
 	es := NewResourceSpansSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &state)
+	state := internal.NewState()
+	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceSpans()
@@ -35,8 +35,8 @@ func TestResourceSpansSlice(t *testing.T) {
 }
 
 func TestResourceSpansSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestResourceSpansSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestResourceSpansSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newResourceSpansSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newResourceSpans(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestResourceSpansSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestResourceSpans().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestResourceSpansSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestResourceSpansSlice() ResourceSpansSlice {
 	es := NewResourceSpansSlice()
 	fillTestResourceSpansSlice(es)
@@ -33,8 +33,8 @@ func newScopeSpans(orig *otlptrace.ScopeSpans, state *internal.State) ScopeSpans
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeSpans() ScopeSpans {
-	state := internal.StateMutable
-	return newScopeSpans(&otlptrace.ScopeSpans{}, &state)
+	state := internal.NewState()
+	return newScopeSpans(&otlptrace.ScopeSpans{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -44,6 +44,9 @@ func (ms ScopeSpans) MoveTo(dest ScopeSpans) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ScopeSpans{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Scope returns the scope associated with this ScopeSpans.
@@ -19,8 +19,8 @@ import (
 func TestScopeSpansSlice(t *testing.T) {
 	es := NewScopeSpansSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, &state)
+	state := internal.NewState()
+	es = newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewScopeSpans()
@@ -35,8 +35,8 @@ func TestScopeSpansSlice(t *testing.T) {
 }
 
 func TestScopeSpansSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -139,6 +139,39 @@ func TestScopeSpansSlice_Sort(t *testing.T) {
 	}
 }
 
+func TestScopeSpansSlice_CopyOnWrite(t *testing.T) {
+	es := generateTestScopeSpansSlice()
+	shared := (*es.orig)[0]
+	es.state.MarkShared(shared)
+
+	// Read-only data is never cloned.
+	readOnlyState := internal.NewReadOnlyState()
+	readOnlyState.MarkShared(shared)
+	assert.Same(t, shared, newScopeSpansSlice(es.orig, readOnlyState).At(0).orig)
+
+	el := es.At(0)
+	assert.NotSame(t, shared, el.orig)
+	assert.Same(t, el.orig, (*es.orig)[0])
+	assert.Equal(t, newScopeSpans(shared, es.state), el)
+	assert.Same(t, el.orig, es.At(0).orig)
+
+	// Shared elements overridden by CopyTo are replaced.
+	dest := generateTestScopeSpansSlice()
+	shared = (*dest.orig)[1]
+	dest.state.MarkShared(shared)
+	es.CopyTo(dest)
+	assert.NotSame(t, shared, (*dest.orig)[1])
+	assert.Equal(t, generateTestScopeSpans().orig, shared)
+
+	// Shared elements remain shared when moved.
+	src := generateTestScopeSpansSlice()
+	shared = (*src.orig)[2]
+	src.state.MarkShared(shared)
+	destLen := dest.Len()
+	src.MoveAndAppendTo(dest)
+	assert.NotSame(t, shared, dest.At(destLen+2).orig)
+}
+
 func generateTestScopeSpansSlice() ScopeSpansSlice {
 	es := NewScopeSpansSlice()
 	fillTestScopeSpansSlice(es)
@@ -35,8 +35,8 @@ func newSpan(orig *otlptrace.Span, state *internal.State) Span {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpan() Span {
-	state := internal.StateMutable
-	return newSpan(&otlptrace.Span{}, &state)
+	state := internal.NewState()
+	return newSpan(&otlptrace.Span{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -46,6 +46,9 @@ func (ms Span) MoveTo(dest Span) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // TraceID returns the traceid associated with this Span.
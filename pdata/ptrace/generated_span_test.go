@@ -23,9 +23,9 @@ func TestSpan_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.Equal(t, NewSpan(), ms)
 	assert.Equal(t, generateTestSpan(), dest)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.MoveTo(newSpan(&otlptrace.Span{}, &sharedState)) })
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).MoveTo(dest) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.MoveTo(newSpan(&otlptrace.Span{}, sharedState)) })
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).MoveTo(dest) })
 }
 
 func TestSpan_CopyTo(t *testing.T) {
@@ -36,8 +36,8 @@ func TestSpan_CopyTo(t *testing.T) {
 	orig = generateTestSpan()
 	orig.CopyTo(ms)
 	assert.Equal(t, orig, ms)
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { ms.CopyTo(newSpan(&otlptrace.Span{}, &sharedState)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { ms.CopyTo(newSpan(&otlptrace.Span{}, sharedState)) })
 }
 
 func TestSpan_TraceID(t *testing.T) {
@@ -75,8 +75,8 @@ func TestSpan_Name(t *testing.T) {
 	assert.Equal(t, "", ms.Name())
 	ms.SetName("test_name")
 	assert.Equal(t, "test_name", ms.Name())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetName("test_name") })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).SetName("test_name") })
 }
 
 func TestSpan_Flags(t *testing.T) {
@@ -84,8 +84,8 @@ func TestSpan_Flags(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.Flags())
 	ms.SetFlags(uint32(0xf))
 	assert.Equal(t, uint32(0xf), ms.Flags())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetFlags(uint32(0xf)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).SetFlags(uint32(0xf)) })
 }
 
 func TestSpan_Kind(t *testing.T) {
@@ -124,8 +124,8 @@ func TestSpan_DroppedAttributesCount(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.DroppedAttributesCount())
 	ms.SetDroppedAttributesCount(uint32(17))
 	assert.Equal(t, uint32(17), ms.DroppedAttributesCount())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetDroppedAttributesCount(uint32(17)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).SetDroppedAttributesCount(uint32(17)) })
 }
 
 func TestSpan_Events(t *testing.T) {
@@ -140,8 +140,8 @@ func TestSpan_DroppedEventsCount(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.DroppedEventsCount())
 	ms.SetDroppedEventsCount(uint32(17))
 	assert.Equal(t, uint32(17), ms.DroppedEventsCount())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetDroppedEventsCount(uint32(17)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).SetDroppedEventsCount(uint32(17)) })
 }
 
 func TestSpan_Links(t *testing.T) {
@@ -156,8 +156,8 @@ func TestSpan_DroppedLinksCount(t *testing.T) {
 	assert.Equal(t, uint32(0), ms.DroppedLinksCount())
 	ms.SetDroppedLinksCount(uint32(17))
 	assert.Equal(t, uint32(17), ms.DroppedLinksCount())
-	sharedState := internal.StateReadOnly
-	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetDroppedLinksCount(uint32(17)) })
+	sharedState := internal.NewReadOnlyState()
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, sharedState).SetDroppedLinksCount(uint32(17)) })
 }
 
 func TestSpan_Status(t *testing.T) {
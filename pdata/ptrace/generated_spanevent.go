@@ -34,8 +34,8 @@ func newSpanEvent(orig *otlptrace.Span_Event, state *internal.State) SpanEvent {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpanEvent() SpanEvent {
-	state := internal.StateMutable
-	return newSpanEvent(&otlptrace.Span_Event{}, &state)
+	state := internal.NewState()
+	return newSpanEvent(&otlptrace.Span_Event{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -45,6 +45,9 @@ func (ms SpanEvent) MoveTo(dest SpanEvent) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span_Event{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // Timestamp returns the timestamp associated with this SpanEvent.
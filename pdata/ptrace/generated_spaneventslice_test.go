@@ -19,8 +19,8 @@ import (
 func TestSpanEventSlice(t *testing.T) {
 	es := NewSpanEventSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSpanEventSlice(&[]*otlptrace.Span_Event{}, &state)
+	state := internal.NewState()
+	es = newSpanEventSlice(&[]*otlptrace.Span_Event{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewSpanEvent()
@@ -35,8 +35,8 @@ func TestSpanEventSlice(t *testing.T) {
 }
 
 func TestSpanEventSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newSpanEventSlice(&[]*otlptrace.Span_Event{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newSpanEventSlice(&[]*otlptrace.Span_Event{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -36,8 +36,8 @@ func newSpanLink(orig *otlptrace.Span_Link, state *internal.State) SpanLink {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpanLink() SpanLink {
-	state := internal.StateMutable
-	return newSpanLink(&otlptrace.Span_Link{}, &state)
+	state := internal.NewState()
+	return newSpanLink(&otlptrace.Span_Link{}, state)
 }
 
 // MoveTo moves all properties from the current struct overriding the destination and
@@ -47,6 +47,9 @@ func (ms SpanLink) MoveTo(dest SpanLink) {
 	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span_Link{}
+	// The maps of the destination are discarded, and the maps of the current instance are moved.
+	dest.state.ResetMapIndexes()
+	ms.state.ResetMapIndexes()
 }
 
 // TraceID returns the traceid associated with this SpanLink.
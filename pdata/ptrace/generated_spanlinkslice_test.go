@@ -19,8 +19,8 @@ import (
 func TestSpanLinkSlice(t *testing.T) {
 	es := NewSpanLinkSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{}, &state)
+	state := internal.NewState()
+	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewSpanLink()
@@ -35,8 +35,8 @@ func TestSpanLinkSlice(t *testing.T) {
 }
 
 func TestSpanLinkSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newSpanLinkSlice(&[]*otlptrace.Span_Link{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newSpanLinkSlice(&[]*otlptrace.Span_Link{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -19,8 +19,8 @@ import (
 func TestSpanSlice(t *testing.T) {
 	es := NewSpanSlice()
 	assert.Equal(t, 0, es.Len())
-	state := internal.StateMutable
-	es = newSpanSlice(&[]*otlptrace.Span{}, &state)
+	state := internal.NewState()
+	es = newSpanSlice(&[]*otlptrace.Span{}, state)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewSpan()
@@ -35,8 +35,8 @@ func TestSpanSlice(t *testing.T) {
 }
 
 func TestSpanSliceReadOnly(t *testing.T) {
-	sharedState := internal.StateReadOnly
-	es := newSpanSlice(&[]*otlptrace.Span{}, &sharedState)
+	sharedState := internal.NewReadOnlyState()
+	es := newSpanSlice(&[]*otlptrace.Span{}, sharedState)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
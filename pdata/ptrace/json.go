@@ -6,6 +6,7 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	jsoniter "github.com/json-iterator/go"
 
@@ -21,6 +22,8 @@ type JSONMarshaler struct{}
 // MarshalTraces to the OTLP/JSON format.
 func (*JSONMarshaler) MarshalTraces(td Traces) ([]byte, error) {
 	buf := bytes.Buffer{}
+	td.getState().RLock()
+	defer td.getState().RUnlock()
 	pb := internal.TracesToProto(internal.Traces(td))
 	err := json.Marshal(&buf, &pb)
 	return buf.Bytes(), err
@@ -42,6 +45,50 @@ func (*JSONUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
 	return td, nil
 }
 
+// JSONDecoder decodes OTLP/JSON formatted traces from a stream one ResourceSpans at a time, so that
+// large payloads can be processed without holding them entirely in memory.
+type JSONDecoder struct {
+	iter *jsoniter.Iterator
+}
+
+// NewJSONDecoder returns a JSONDecoder reading from r.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{iter: json.NewStreamIterator(r)}
+}
+
+// DecodeResourceSpans reads the next OTLP/JSON formatted traces object from the stream, and calls fn with
+// each of its ResourceSpans as soon as it is read. The ResourceSpans is exclusively owned by fn, it can be moved
+// to Traces with ResourceSpans.MoveTo. DecodeResourceSpans returns the first error returned by fn, and io.EOF if the
+// stream holds no more objects.
+func (d *JSONDecoder) DecodeResourceSpans(fn func(ResourceSpans) error) error {
+	if json.AtStreamEnd(d.iter) {
+		return io.EOF
+	}
+	var err error
+	d.iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
+		switch f {
+		case "resourceSpans", "resource_spans":
+			return iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+				rs := NewResourceSpans()
+				rs.unmarshalJsoniter(iter)
+				if iter.Error != nil {
+					return false
+				}
+				otlp.MigrateTraces([]*otlptrace.ResourceSpans{rs.orig})
+				err = fn(rs)
+				return err == nil
+			})
+		default:
+			iter.Skip()
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return json.StreamError(d.iter)
+}
+
 func (ms Traces) unmarshalJsoniter(iter *jsoniter.Iterator) {
 	iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
 		switch f {
@@ -4,10 +4,15 @@
 package ptrace
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
@@ -111,6 +116,40 @@ func TestJSONUnmarshalInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestJSONDecoder(t *testing.T) {
+	stream := tracesJSON + "\n" + tracesJSON + "\n"
+	decoder := NewJSONDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+	for i := 0; i < 2; i++ {
+		var got []ResourceSpans
+		require.NoError(t, decoder.DecodeResourceSpans(func(rs ResourceSpans) error {
+			got = append(got, rs)
+			return nil
+		}))
+		require.Len(t, got, tracesOTLP.ResourceSpans().Len())
+		for j := range got {
+			assert.EqualValues(t, tracesOTLP.ResourceSpans().At(j), got[j])
+		}
+	}
+	assert.ErrorIs(t, decoder.DecodeResourceSpans(func(ResourceSpans) error {
+		t.Fail()
+		return nil
+	}), io.EOF)
+}
+
+func TestJSONDecoderCallbackError(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(tracesJSON))
+	err := errors.New("my error")
+	assert.ErrorIs(t, decoder.DecodeResourceSpans(func(ResourceSpans) error { return err }), err)
+}
+
+func TestJSONDecoderInvalid(t *testing.T) {
+	decoder := NewJSONDecoder(strings.NewReader(tracesJSON[:len(tracesJSON)/2]))
+	assert.Error(t, decoder.DecodeResourceSpans(func(ResourceSpans) error { return nil }))
+
+	decoder = NewJSONDecoder(strings.NewReader(`{"resourceSpans": "extra"}`))
+	assert.Error(t, decoder.DecodeResourceSpans(func(ResourceSpans) error { return nil }))
+}
+
 func TestUnmarshalJsoniterTraceData(t *testing.T) {
 	jsonStr := `{"extra":"", "resourceSpans": [{"extra":""}]}`
 	iter := jsoniter.ConfigFastest.BorrowIterator([]byte(jsonStr))
@@ -13,11 +13,15 @@ var _ MarshalSizer = (*ProtoMarshaler)(nil)
 type ProtoMarshaler struct{}
 
 func (e *ProtoMarshaler) MarshalTraces(td Traces) ([]byte, error) {
+	td.getState().RLock()
+	defer td.getState().RUnlock()
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Marshal()
 }
 
 func (e *ProtoMarshaler) TracesSize(td Traces) int {
+	td.getState().RLock()
+	defer td.getState().RUnlock()
 	pb := internal.TracesToProto(internal.Traces(td))
 	return pb.Size()
 }
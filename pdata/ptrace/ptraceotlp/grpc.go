@@ -40,12 +40,15 @@ type grpcClient struct {
 
 // Export implements the Client interface.
 func (c *grpcClient) Export(ctx context.Context, request ExportRequest, opts ...grpc.CallOption) (ExportResponse, error) {
+	// The request is read while it is sent.
+	request.state.RLock()
+	defer request.state.RUnlock()
 	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
 	if err != nil {
 		return ExportResponse{}, err
 	}
-	state := internal.StateMutable
-	return ExportResponse{orig: rsp, state: &state}, err
+	state := internal.NewState()
+	return ExportResponse{orig: rsp, state: state}, err
 }
 
 func (c *grpcClient) unexported() {}
@@ -85,7 +88,7 @@ type rawTracesServer struct {
 
 func (s rawTracesServer) Export(ctx context.Context, request *otlpcollectortrace.ExportTraceServiceRequest) (*otlpcollectortrace.ExportTraceServiceResponse, error) {
 	otlp.MigrateTraces(request.ResourceSpans)
-	state := internal.StateMutable
-	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: &state})
+	state := internal.NewState()
+	rsp, err := s.srv.Export(ctx, ExportRequest{orig: request, state: state})
 	return rsp.orig, err
 }
@@ -21,10 +21,10 @@ type ExportResponse struct {
 
 // NewExportResponse returns an empty ExportResponse.
 func NewExportResponse() ExportResponse {
-	state := internal.StateMutable
+	state := internal.NewState()
 	return ExportResponse{
 		orig:  &otlpcollectortrace.ExportTraceServiceResponse{},
-		state: &state,
+		state: state,
 	}
 }
 
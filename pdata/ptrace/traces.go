@@ -6,6 +6,7 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 )
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
@@ -13,8 +14,8 @@ import (
 type Traces internal.Traces
 
 func newTraces(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
-	state := internal.StateMutable
-	return Traces(internal.NewTraces(orig, &state))
+	state := internal.NewState()
+	return Traces(internal.NewTraces(orig, state))
 }
 
 func (ms Traces) getOrig() *otlpcollectortrace.ExportTraceServiceRequest {
@@ -32,7 +33,7 @@ func NewTraces() Traces {
 
 // IsReadOnly returns true if this Traces instance is read-only.
 func (ms Traces) IsReadOnly() bool {
-	return *ms.getState() == internal.StateReadOnly
+	return ms.getState().IsReadOnly()
 }
 
 // CopyTo copies the Traces instance overriding the destination.
@@ -43,12 +44,12 @@ func (ms Traces) CopyTo(dest Traces) {
 // SpanCount calculates the total number of spans.
 func (ms Traces) SpanCount() int {
 	spanCount := 0
-	rss := ms.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
-		rs := rss.At(i)
-		ilss := rs.ScopeSpans()
-		for j := 0; j < ilss.Len(); j++ {
-			spanCount += ilss.At(j).Spans().Len()
+	// Iterate over the origs, accessing shared elements from the slices would clone them.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	for _, rs := range ms.getOrig().ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			spanCount += len(ss.Spans)
 		}
 	}
 	return spanCount
@@ -61,5 +62,46 @@ func (ms Traces) ResourceSpans() ResourceSpansSlice {
 
 // MarkReadOnly marks the Traces as shared so that no further modifications can be done on it.
 func (ms Traces) MarkReadOnly() {
-	internal.SetTracesState(internal.Traces(ms), internal.StateReadOnly)
+	ms.getState().MarkReadOnly()
+}
+
+// CopyOnWrite returns a copy of the Traces which shares its ResourceSpans and ScopeSpans
+// with the current instance. A shared element is cloned the first time it is accessed
+// from the copy, so only the parts of the copy which are accessed are actually copied.
+// The current instance must not be modified as long as the copy is in use.
+func (ms Traces) CopyOnWrite() Traces {
+	// The shared elements of the current instance may be cloned concurrently when it is a copy itself.
+	ms.getState().RLock()
+	defer ms.getState().RUnlock()
+	src := ms.getOrig().ResourceSpans
+	dest := newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: make([]*otlptrace.ResourceSpans, len(src)),
+	})
+	copy(dest.getOrig().ResourceSpans, src)
+	for _, rs := range src {
+		dest.getState().MarkShared(rs)
+	}
+	return dest
+}
+
+// cloneSharedResourceSpans returns a copy of a shared ResourceSpans, which still shares
+// its ScopeSpans.
+func cloneSharedResourceSpans(orig *otlptrace.ResourceSpans, state *internal.State) *otlptrace.ResourceSpans {
+	dest := &otlptrace.ResourceSpans{
+		SchemaUrl:  orig.SchemaUrl,
+		ScopeSpans: make([]*otlptrace.ScopeSpans, len(orig.ScopeSpans)),
+	}
+	newResourceSpans(orig, state).Resource().CopyTo(newResourceSpans(dest, state).Resource())
+	copy(dest.ScopeSpans, orig.ScopeSpans)
+	for _, ss := range orig.ScopeSpans {
+		state.MarkShared(ss)
+	}
+	return dest
+}
+
+// cloneSharedScopeSpans returns a copy of a shared ScopeSpans.
+func cloneSharedScopeSpans(orig *otlptrace.ScopeSpans, state *internal.State) *otlptrace.ScopeSpans {
+	dest := &otlptrace.ScopeSpans{}
+	newScopeSpans(orig, state).CopyTo(newScopeSpans(dest, state))
+	return dest
 }
@@ -4,6 +4,7 @@
 package ptrace
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -126,6 +127,68 @@ func TestReadOnlyTracesInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestTracesCopyOnWrite(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	expected := NewTraces()
+	traces.CopyTo(expected)
+
+	cow := traces.CopyOnWrite()
+	assert.Equal(t, traces.SpanCount(), cow.SpanCount())
+	// Counting does not clone the shared elements.
+	assert.Same(t, traces.getOrig().ResourceSpans[0], cow.getOrig().ResourceSpans[0])
+
+	rs := cow.ResourceSpans().At(0)
+	rs.Resource().Attributes().PutStr("k", "v")
+	assert.NotSame(t, traces.getOrig().ResourceSpans[0], cow.getOrig().ResourceSpans[0])
+	// The scopes of a cloned element are still shared until they are accessed.
+	assert.Same(t, traces.getOrig().ResourceSpans[0].ScopeSpans[1], rs.orig.ScopeSpans[1])
+	rs.ScopeSpans().At(0).Scope().SetName("modified")
+	assert.NotSame(t, traces.getOrig().ResourceSpans[0].ScopeSpans[0], rs.orig.ScopeSpans[0])
+	assert.Same(t, traces.getOrig().ResourceSpans[1], cow.getOrig().ResourceSpans[1])
+
+	cow.ResourceSpans().RemoveIf(func(rs ResourceSpans) bool {
+		return rs.ScopeSpans().Len() > 0
+	})
+	assert.Equal(t, 0, cow.ResourceSpans().Len())
+	assert.Equal(t, expected, traces)
+}
+
+func TestTracesCopyOnWriteConcurrentReads(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	expected := NewTraces()
+	traces.CopyTo(expected)
+	cow := traces.CopyOnWrite()
+
+	// The shared elements are cloned by the first goroutine accessing them, without racing with the others.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			rs := cow.ResourceSpans()
+			for j := 0; j < rs.Len(); j++ {
+				ss := rs.At(j).ScopeSpans()
+				for k := 0; k < ss.Len(); k++ {
+					assert.Equal(t, ss.At(k).Spans().Len(), expected.ResourceSpans().At(j).ScopeSpans().At(k).Spans().Len())
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := (&ProtoMarshaler{}).MarshalTraces(cow)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.SpanCount(), cow.SpanCount())
+			dest := NewTraces()
+			cow.CopyTo(dest)
+			assert.Equal(t, expected, dest)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, expected, cow)
+}
+
 func BenchmarkTracesUsage(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
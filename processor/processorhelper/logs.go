@@ -41,12 +41,14 @@ func NewLogsProcessor(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	sr := newStatusReporter(set.TelemetrySettings, bs.reportStatus)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		ld, err = logsFunc(ctx, ld)
 		span.AddEvent("End processing.", eventOptions)
+		reportProcessed(sr, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
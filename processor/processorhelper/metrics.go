@@ -41,12 +41,14 @@ func NewMetricsProcessor(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	sr := newStatusReporter(set.TelemetrySettings, bs.reportStatus)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		md, err = metricsFunc(ctx, md)
 		span.AddEvent("End processing.", eventOptions)
+		reportProcessed(sr, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
@@ -48,10 +48,22 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 	}
 }
 
+// WithStatusReporting makes the processor report a recoverable error status when the
+// processing function returns an error, and report the processor back to OK once the
+// processing function succeeds again. Permanent errors, see consumererror.NewPermanent,
+// are specific to the data being processed and do not change the status.
+// The status is not reported by default.
+func WithStatusReporting() Option {
+	return func(o *baseSettings) {
+		o.reportStatus = true
+	}
+}
+
 type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
 	consumerOptions []consumer.Option
+	reportStatus    bool
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
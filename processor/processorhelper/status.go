@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/statusreporter"
+)
+
+// newStatusReporter returns the reporter of the processor status, or nil if the status reporting is not enabled.
+func newStatusReporter(set component.TelemetrySettings, enabled bool) *statusreporter.Reporter {
+	if !enabled {
+		return nil
+	}
+	return statusreporter.New(set)
+}
+
+// reportProcessed reports the outcome of processing data to the reporter, if not nil.
+// Permanent errors are ignored, as those are specific to the data being processed.
+func reportProcessed(sr *statusreporter.Reporter, err error) {
+	if sr == nil {
+		return
+	}
+	switch {
+	case err == nil || errors.Is(err, ErrSkipProcessingData):
+		sr.OK()
+	case !consumererror.IsPermanent(err):
+		sr.Recoverable(err)
+	}
+}
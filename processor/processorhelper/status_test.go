@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestStatusReporting(t *testing.T) {
+	var processErr error
+	var statuses []component.Status
+	set := processortest.NewNopSettings()
+	set.ReportStatus = func(ev *component.StatusEvent) {
+		statuses = append(statuses, ev.Status())
+	}
+
+	tp, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, consumertest.NewNop(), func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		return td, processErr
+	}, WithStatusReporting())
+	require.NoError(t, err)
+	mp, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, consumertest.NewNop(), func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		return md, processErr
+	}, WithStatusReporting())
+	require.NoError(t, err)
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+		return ld, processErr
+	}, WithStatusReporting())
+	require.NoError(t, err)
+
+	consume := func() {
+		_ = tp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+		_ = mp.ConsumeMetrics(context.Background(), pmetric.NewMetrics())
+		_ = lp.ConsumeLogs(context.Background(), plog.NewLogs())
+	}
+
+	consume()
+	assert.Empty(t, statuses)
+
+	processErr = consumererror.NewPermanent(errors.New("invalid data"))
+	consume()
+	assert.Empty(t, statuses)
+
+	processErr = errors.New("lookup failed")
+	consume()
+	consume()
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusRecoverableError, component.StatusRecoverableError}, statuses)
+
+	processErr = ErrSkipProcessingData
+	consume()
+	assert.Equal(t, []component.Status{
+		component.StatusRecoverableError, component.StatusRecoverableError, component.StatusRecoverableError,
+		component.StatusOK, component.StatusOK, component.StatusOK,
+	}, statuses)
+}
+
+func TestStatusReportingDisabled(t *testing.T) {
+	set := processortest.NewNopSettings()
+	set.ReportStatus = func(*component.StatusEvent) {
+		t.Fatal("unexpected status report")
+	}
+	tp, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, consumertest.NewNop(), func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+		return ptrace.NewTraces(), errors.New("lookup failed")
+	})
+	require.NoError(t, err)
+	require.Error(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+}
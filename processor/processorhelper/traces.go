@@ -41,12 +41,14 @@ func NewTracesProcessor(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	sr := newStatusReporter(set.TelemetrySettings, bs.reportStatus)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		td, err = tracesFunc(ctx, td)
 		span.AddEvent("End processing.", eventOptions)
+		reportProcessed(sr, err)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -21,6 +22,11 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// Health defines how the statuses reported by the components are rolled up into the
+	// health of the pipelines and of the collector.
+	// Experimental: *NOTE* this field is subject to change or removal in the future.
+	Health health.Config `mapstructure:"health"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,6 +34,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.Health.Validate(); err != nil {
+		return fmt.Errorf("service::health config validation failed: %w", err)
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
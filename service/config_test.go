@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -71,6 +72,15 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "invalid-health-recovery-duration",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Health.RecoveryDuration = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::health config validation failed: %w`, errors.New(`recovery_duration must not be negative`)),
+		},
 	}
 
 	for _, test := range testCases {
@@ -26,6 +26,7 @@ type Extensions struct {
 	telemetry    servicetelemetry.TelemetrySettings
 	extMap       map[component.ID]extension.Extension
 	instanceIDs  map[component.ID]*component.InstanceID
+	stability    map[component.ID]component.StabilityLevel
 	extensionIDs []component.ID // start order (and reverse stop order)
 }
 
@@ -138,6 +139,11 @@ func (bes *Extensions) GetExtensions() map[component.ID]component.Component {
 func (bes *Extensions) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	extensionName := r.URL.Query().Get(zExtensionName)
 
+	if zpages.IsJSONRequest(r) {
+		zpages.WriteJSON(w, bes.extensionsData(), bes.telemetry.Logger)
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Extensions"})
 	data := zpages.SummaryExtensionsTableData{}
@@ -161,6 +167,18 @@ func (bes *Extensions) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
+func (bes *Extensions) extensionsData() zpages.ExtensionsData {
+	data := zpages.ExtensionsData{Extensions: make([]zpages.ComponentData, 0, len(bes.extensionIDs))}
+	for _, id := range bes.extensionIDs {
+		ev, _ := bes.telemetry.Status.Status(bes.instanceIDs[id])
+		data.Extensions = append(data.Extensions, zpages.NewComponentData(id, component.KindExtension, bes.stability[id], ev))
+	}
+	sort.Slice(data.Extensions, func(i, j int) bool {
+		return data.Extensions[i].ID < data.Extensions[j].ID
+	})
+	return data
+}
+
 // Settings holds configuration for building Extensions.
 type Settings struct {
 	Telemetry servicetelemetry.TelemetrySettings
@@ -176,6 +194,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 		telemetry:    set.Telemetry,
 		extMap:       make(map[component.ID]extension.Extension),
 		instanceIDs:  make(map[component.ID]*component.InstanceID),
+		stability:    make(map[component.ID]component.StabilityLevel),
 		extensionIDs: make([]component.ID, 0, len(cfg)),
 	}
 	for _, extID := range cfg {
@@ -202,6 +221,9 @@ func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 
 		exts.extMap[extID] = ext
 		exts.instanceIDs[extID] = instanceID
+		if f, ok := set.Extensions.Factory(extID.Type()).(extension.Factory); ok {
+			exts.stability[extID] = f.ExtensionStability()
+		}
 	}
 	order, err := computeOrder(exts)
 	if err != nil {
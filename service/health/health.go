@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health rolls the status events reported by the component instances up into
+// the health of every pipeline and of the collector as a whole.
+// Experimental: *NOTE* this package is subject to change or removal in the future.
+package health // import "go.opentelemetry.io/collector/service/health"
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines which component statuses make the collector unhealthy.
+// Fatal errors always make the collector unhealthy.
+type Config struct {
+	// IncludePermanentErrors makes the components in a permanent error state unhealthy.
+	// (default = true)
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+
+	// IncludeRecoverableErrors makes the components in a recoverable error state unhealthy,
+	// once they failed to recover for longer than RecoveryDuration.
+	// (default = false)
+	IncludeRecoverableErrors bool `mapstructure:"include_recoverable_errors"`
+
+	// RecoveryDuration is the time a component is given to recover from a recoverable error
+	// before it is considered unhealthy.
+	// (default = 5m)
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+}
+
+// NewDefaultConfig returns the default Config.
+func NewDefaultConfig() Config {
+	return Config{
+		IncludePermanentErrors:   true,
+		IncludeRecoverableErrors: false,
+		RecoveryDuration:         5 * time.Minute,
+	}
+}
+
+// Validate checks whether the current configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RecoveryDuration < 0 {
+		return errors.New("recovery_duration must not be negative")
+	}
+	return nil
+}
+
+// Health is the health of a component instance, of a pipeline or of the collector.
+type Health struct {
+	// Healthy is false if the status, or the status of one of the components, is unhealthy.
+	Healthy bool `json:"healthy"`
+	// Status is the aggregated status, see component.AggregateStatus.
+	Status string `json:"status"`
+	// Error is the error of the latest event matching the status, if any.
+	Error string `json:"error,omitempty"`
+	// StatusTime is the time of the latest status change.
+	StatusTime time.Time `json:"status_time"`
+
+	// Pipelines holds the health of every pipeline, for the collector.
+	Pipelines map[string]*Health `json:"pipelines,omitempty"`
+	// Extensions holds the health of every extension, for the collector.
+	Extensions map[string]*Health `json:"extensions,omitempty"`
+	// Components holds the health of every component instance, for a pipeline.
+	Components map[string]*Health `json:"components,omitempty"`
+}
+
+// Aggregator keeps the latest status event of every component instance.
+// It is safe for concurrent use.
+type Aggregator struct {
+	cfg Config
+	now func() time.Time
+
+	mu     sync.RWMutex
+	events map[*component.InstanceID]*component.StatusEvent
+}
+
+// NewAggregator returns an Aggregator applying the rules of the given Config.
+func NewAggregator(cfg Config) *Aggregator {
+	return &Aggregator{
+		cfg:    cfg,
+		now:    time.Now,
+		events: map[*component.InstanceID]*component.StatusEvent{},
+	}
+}
+
+// RecordStatus records the latest status event of a component instance. Instances are
+// identified by the pointer reporting the events, as done by the service.
+func (a *Aggregator) RecordStatus(source *component.InstanceID, event *component.StatusEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events[source] = event
+}
+
+// Health returns the health of the collector, with the health of every pipeline and extension.
+func (a *Aggregator) Health() *Health {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	now := a.now()
+	all := map[*component.InstanceID]*component.StatusEvent{}
+	pipelines := map[component.ID]map[*component.InstanceID]*component.StatusEvent{}
+	extensions := map[string]*Health{}
+	for source, ev := range a.events {
+		all[source] = ev
+		if source.Kind == component.KindExtension {
+			extensions[source.ID.String()] = a.instanceHealth(ev, now)
+			continue
+		}
+		for pipelineID := range source.PipelineIDs {
+			if pipelines[pipelineID] == nil {
+				pipelines[pipelineID] = map[*component.InstanceID]*component.StatusEvent{}
+			}
+			pipelines[pipelineID][source] = ev
+		}
+	}
+
+	collector := a.aggregateHealth(all, now)
+	if len(extensions) > 0 {
+		collector.Extensions = extensions
+	}
+	if len(pipelines) > 0 {
+		collector.Pipelines = make(map[string]*Health, len(pipelines))
+		for pipelineID, events := range pipelines {
+			pipeline := a.aggregateHealth(events, now)
+			pipeline.Components = make(map[string]*Health, len(events))
+			for source, ev := range events {
+				pipeline.Components[componentKey(source)] = a.instanceHealth(ev, now)
+			}
+			collector.Pipelines[pipelineID.String()] = pipeline
+		}
+	}
+	return collector
+}
+
+// ServeHTTP writes the health of the collector as JSON. The response status code is
+// 200 when the collector is healthy, and 503 otherwise.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h := a.Health()
+	body, err := json.Marshal(h)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if h.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(body)
+}
+
+// aggregateHealth returns the health of a group of instances, which is only healthy
+// if all the instances are healthy.
+func (a *Aggregator) aggregateHealth(events map[*component.InstanceID]*component.StatusEvent, now time.Time) *Health {
+	if len(events) == 0 {
+		return &Health{Healthy: true, Status: component.StatusNone.String()}
+	}
+	h := newHealth(component.AggregateStatusEvent(events))
+	h.Healthy = true
+	for _, ev := range events {
+		h.Healthy = h.Healthy && a.isHealthy(ev, now)
+	}
+	return h
+}
+
+func (a *Aggregator) instanceHealth(ev *component.StatusEvent, now time.Time) *Health {
+	h := newHealth(ev)
+	h.Healthy = a.isHealthy(ev, now)
+	return h
+}
+
+func (a *Aggregator) isHealthy(ev *component.StatusEvent, now time.Time) bool {
+	switch ev.Status() {
+	case component.StatusFatalError:
+		return false
+	case component.StatusPermanentError:
+		return !a.cfg.IncludePermanentErrors
+	case component.StatusRecoverableError:
+		return !a.cfg.IncludeRecoverableErrors || now.Sub(ev.Timestamp()) <= a.cfg.RecoveryDuration
+	default:
+		return true
+	}
+}
+
+func newHealth(ev *component.StatusEvent) *Health {
+	h := &Health{
+		Status:     ev.Status().String(),
+		StatusTime: ev.Timestamp(),
+	}
+	if ev.Err() != nil {
+		h.Error = ev.Err().Error()
+	}
+	return h
+}
+
+// componentKey returns the key of a component instance in a pipeline, e.g. "receiver:otlp".
+func componentKey(source *component.InstanceID) string {
+	return strings.ToLower(source.Kind.String()) + ":" + source.ID.String()
+}
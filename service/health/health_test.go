@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	tracesID  = component.MustNewID("traces")
+	metricsID = component.MustNewID("metrics")
+
+	receiverID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindReceiver,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}, metricsID: {}},
+	}
+	tracesExporterID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}},
+	}
+	metricsExporterID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{metricsID: {}},
+	}
+	extensionID = &component.InstanceID{
+		ID:   component.MustNewID("zpages"),
+		Kind: component.KindExtension,
+	}
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.RecoveryDuration = -time.Second
+	require.Error(t, cfg.Validate())
+}
+
+func TestAggregatorEmpty(t *testing.T) {
+	h := NewAggregator(NewDefaultConfig()).Health()
+	assert.True(t, h.Healthy)
+	assert.Equal(t, component.StatusNone.String(), h.Status)
+	assert.Nil(t, h.Pipelines)
+	assert.Nil(t, h.Extensions)
+}
+
+func TestAggregatorHealth(t *testing.T) {
+	errExport := errors.New("export failed")
+	tests := []struct {
+		name           string
+		cfg            Config
+		elapsed        time.Duration
+		exporterEvent  *component.StatusEvent
+		healthy        bool
+		tracesHealthy  bool
+		expectedStatus component.Status
+	}{
+		{
+			name:           "all ok",
+			cfg:            NewDefaultConfig(),
+			exporterEvent:  component.NewStatusEvent(component.StatusOK),
+			healthy:        true,
+			tracesHealthy:  true,
+			expectedStatus: component.StatusOK,
+		},
+		{
+			name:           "recoverable error ignored",
+			cfg:            NewDefaultConfig(),
+			elapsed:        time.Hour,
+			exporterEvent:  component.NewRecoverableErrorEvent(errExport),
+			healthy:        true,
+			tracesHealthy:  true,
+			expectedStatus: component.StatusRecoverableError,
+		},
+		{
+			name:           "recoverable error within recovery duration",
+			cfg:            Config{IncludeRecoverableErrors: true, RecoveryDuration: time.Minute},
+			elapsed:        time.Second,
+			exporterEvent:  component.NewRecoverableErrorEvent(errExport),
+			healthy:        true,
+			tracesHealthy:  true,
+			expectedStatus: component.StatusRecoverableError,
+		},
+		{
+			name:           "recoverable error after recovery duration",
+			cfg:            Config{IncludeRecoverableErrors: true, RecoveryDuration: time.Minute},
+			elapsed:        2 * time.Minute,
+			exporterEvent:  component.NewRecoverableErrorEvent(errExport),
+			healthy:        false,
+			tracesHealthy:  false,
+			expectedStatus: component.StatusRecoverableError,
+		},
+		{
+			name:           "permanent error",
+			cfg:            NewDefaultConfig(),
+			exporterEvent:  component.NewPermanentErrorEvent(errExport),
+			healthy:        false,
+			tracesHealthy:  false,
+			expectedStatus: component.StatusPermanentError,
+		},
+		{
+			name:           "permanent error ignored",
+			cfg:            Config{},
+			exporterEvent:  component.NewPermanentErrorEvent(errExport),
+			healthy:        true,
+			tracesHealthy:  true,
+			expectedStatus: component.StatusPermanentError,
+		},
+		{
+			name:           "fatal error",
+			cfg:            Config{},
+			exporterEvent:  component.NewFatalErrorEvent(errExport),
+			healthy:        false,
+			tracesHealthy:  false,
+			expectedStatus: component.StatusFatalError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregator(tt.cfg)
+			agg.now = func() time.Time { return time.Now().Add(tt.elapsed) }
+			agg.RecordStatus(receiverID, component.NewStatusEvent(component.StatusOK))
+			agg.RecordStatus(metricsExporterID, component.NewStatusEvent(component.StatusOK))
+			agg.RecordStatus(extensionID, component.NewStatusEvent(component.StatusOK))
+			agg.RecordStatus(tracesExporterID, tt.exporterEvent)
+
+			h := agg.Health()
+			assert.Equal(t, tt.healthy, h.Healthy)
+			assert.Equal(t, tt.expectedStatus.String(), h.Status)
+			require.Len(t, h.Extensions, 1)
+			assert.True(t, h.Extensions["zpages"].Healthy)
+
+			require.Len(t, h.Pipelines, 2)
+			traces := h.Pipelines["traces"]
+			assert.Equal(t, tt.tracesHealthy, traces.Healthy)
+			assert.Equal(t, tt.expectedStatus.String(), traces.Status)
+			require.Len(t, traces.Components, 2)
+			assert.True(t, traces.Components["receiver:otlp"].Healthy)
+			assert.Equal(t, tt.tracesHealthy, traces.Components["exporter:otlp"].Healthy)
+			if component.StatusIsError(tt.expectedStatus) {
+				assert.Equal(t, errExport.Error(), traces.Error)
+				assert.Equal(t, errExport.Error(), traces.Components["exporter:otlp"].Error)
+			}
+
+			metrics := h.Pipelines["metrics"]
+			assert.True(t, metrics.Healthy)
+			assert.Equal(t, component.StatusOK.String(), metrics.Status)
+		})
+	}
+}
+
+func TestAggregatorServeHTTP(t *testing.T) {
+	agg := NewAggregator(NewDefaultConfig())
+	agg.RecordStatus(tracesExporterID, component.NewStatusEvent(component.StatusOK))
+
+	rec := httptest.NewRecorder()
+	agg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	h := &Health{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), h))
+	assert.True(t, h.Healthy)
+	assert.Equal(t, "StatusOK", h.Pipelines["traces"].Components["exporter:otlp"].Status)
+
+	agg.RecordStatus(tracesExporterID, component.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+	rec = httptest.NewRecorder()
+	agg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	h = &Health{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), h))
+	assert.False(t, h.Healthy)
+	assert.Equal(t, "invalid endpoint", h.Error)
+}
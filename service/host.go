@@ -4,6 +4,8 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"go.uber.org/zap"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
@@ -11,7 +13,9 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 // TODO: remove as part of https://github.com/open-telemetry/opentelemetry-collector/issues/7370 for service 1.0
@@ -31,9 +35,13 @@ type serviceHost struct {
 	extensions        *extension.Builder
 
 	buildInfo component.BuildInfo
+	// logger is the logger of the service, used by the zPages.
+	logger *zap.Logger
 
 	pipelines         *graph.Graph
 	serviceExtensions *extensions.Extensions
+	health            *health.Aggregator
+	logLevels         *loglevel.Controller
 }
 
 func (host *serviceHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -67,6 +75,7 @@ func (host *serviceHost) GetExporters() map[component.DataType]map[component.ID]
 }
 
 func (host *serviceHost) notifyComponentStatusChange(source *component.InstanceID, event *component.StatusEvent) {
+	host.health.RecordStatus(source, event)
 	host.serviceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == component.StatusFatalError {
 		host.asyncErrorChannel <- event.Err()
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 const (
@@ -23,6 +24,7 @@ const (
 
 func ReceiverLogger(logger *zap.Logger, id component.ID, dt component.DataType) *zap.Logger {
 	return logger.With(
+		loglevel.ComponentField(component.KindReceiver, id),
 		zap.String(zapKindKey, strings.ToLower(component.KindReceiver.String())),
 		zap.String(zapNameKey, id.String()),
 		zap.String(zapDataTypeKey, dt.String()))
@@ -30,6 +32,7 @@ func ReceiverLogger(logger *zap.Logger, id component.ID, dt component.DataType)
 
 func ProcessorLogger(logger *zap.Logger, id component.ID, pipelineID component.ID) *zap.Logger {
 	return logger.With(
+		loglevel.ComponentField(component.KindProcessor, id),
 		zap.String(zapKindKey, strings.ToLower(component.KindProcessor.String())),
 		zap.String(zapNameKey, id.String()),
 		zap.String(zapPipelineKey, pipelineID.String()))
@@ -37,6 +40,7 @@ func ProcessorLogger(logger *zap.Logger, id component.ID, pipelineID component.I
 
 func ExporterLogger(logger *zap.Logger, id component.ID, dt component.DataType) *zap.Logger {
 	return logger.With(
+		loglevel.ComponentField(component.KindExporter, id),
 		zap.String(zapKindKey, strings.ToLower(component.KindExporter.String())),
 		zap.String(zapDataTypeKey, dt.String()),
 		zap.String(zapNameKey, id.String()))
@@ -44,12 +48,14 @@ func ExporterLogger(logger *zap.Logger, id component.ID, dt component.DataType)
 
 func ExtensionLogger(logger *zap.Logger, id component.ID) *zap.Logger {
 	return logger.With(
+		loglevel.ComponentField(component.KindExtension, id),
 		zap.String(zapKindKey, strings.ToLower(component.KindExtension.String())),
 		zap.String(zapNameKey, id.String()))
 }
 
 func ConnectorLogger(logger *zap.Logger, id component.ID, expDT, rcvDT component.DataType) *zap.Logger {
 	return logger.With(
+		loglevel.ComponentField(component.KindConnector, id),
 		zap.String(zapKindKey, strings.ToLower(component.KindConnector.String())),
 		zap.String(zapNameKey, id.String()),
 		zap.String(zapExporterInPipeline, expDT.String()),
@@ -48,6 +48,10 @@ type Settings struct {
 
 	// PipelineConfigs is a map of component.ID to PipelineConfig.
 	PipelineConfigs pipelines.Config
+
+	// EnableTaps allows to tap the data flowing through the pipelines with the tapz zPage.
+	// The consumers of the pipelines are not wrapped when it is false.
+	EnableTaps bool
 }
 
 type Graph struct {
@@ -60,6 +64,12 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*component.InstanceID
 
+	// Keep track of the stability level per node
+	stability map[int64]component.StabilityLevel
+
+	// Keep track of the points where the data can be tapped, nil if tapping is disabled
+	taps *tapRegistry
+
 	telemetry servicetelemetry.TelemetrySettings
 }
 
@@ -70,6 +80,11 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[component.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*component.InstanceID),
+		stability:      make(map[int64]component.StabilityLevel),
+		telemetry:      set.Telemetry,
+	}
+	if set.EnableTaps {
+		pipelines.taps = newTapRegistry()
 	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
@@ -305,13 +320,25 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 		switch n := node.(type) {
 		case *receiverNode:
 			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			if f, ok := set.ReceiverBuilder.Factory(n.componentID.Type()).(receiver.Factory); ok {
+				g.stability[n.ID()] = receiverStability(f, n.pipelineType)
+			}
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
 			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			if f, ok := set.ProcessorBuilder.Factory(n.componentID.Type()).(processor.Factory); ok {
+				g.stability[n.ID()] = processorStability(f, n.pipelineID.Type())
+			}
 		case *exporterNode:
 			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ExporterBuilder)
+			if f, ok := set.ExporterBuilder.Factory(n.componentID.Type()).(exporter.Factory); ok {
+				g.stability[n.ID()] = exporterStability(f, n.pipelineType)
+			}
 		case *connectorNode:
 			err = n.buildComponent(ctx, telemetrySettings, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+			if f, ok := set.ConnectorBuilder.Factory(n.componentID.Type()).(connector.Factory); ok {
+				g.stability[n.ID()] = connectorStability(f, n.exprPipelineType, n.rcvrPipelineType)
+			}
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{
 				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
@@ -367,10 +394,15 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
+	from := g.componentGraph.Node(nodeID)
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		next := nextNodes.Node().(consumerNode).getConsumer()
+		if point, ok := edgeTapPoint(from, nextNodes.Node()); ok && g.taps != nil {
+			next = g.taps.wrap(point, next)
+		}
+		nexts = append(nexts, next)
 	}
 	return nexts
 }
@@ -569,3 +601,39 @@ func connectorStability(f connector.Factory, expType, recType component.Type) co
 	}
 	return component.StabilityLevelUndefined
 }
+
+func receiverStability(f receiver.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesReceiverStability()
+	case component.DataTypeMetrics:
+		return f.MetricsReceiverStability()
+	case component.DataTypeLogs:
+		return f.LogsReceiverStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func processorStability(f processor.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesProcessorStability()
+	case component.DataTypeMetrics:
+		return f.MetricsProcessorStability()
+	case component.DataTypeLogs:
+		return f.LogsProcessorStability()
+	}
+	return component.StabilityLevelUndefined
+}
+
+func exporterStability(f exporter.Factory, dataType component.DataType) component.StabilityLevel {
+	switch dataType {
+	case component.DataTypeTraces:
+		return f.TracesExporterStability()
+	case component.DataTypeMetrics:
+		return f.MetricsExporterStability()
+	case component.DataTypeLogs:
+		return f.LogsExporterStability()
+	}
+	return component.StabilityLevelUndefined
+}
@@ -23,6 +23,9 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -762,6 +765,8 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					},
 				),
 				PipelineConfigs: test.pipelineConfigs,
+				// The connectors must route the data through the wrapped consumers.
+				EnableTaps: true,
 			}
 
 			pg, err := Build(context.Background(), set)
@@ -955,7 +960,7 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					expected.MarkReadOnly() // multiple read-only exporters should get read-only pdata
 				}
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, expected, tracesExporter.Traces[0])
+					assert.EqualValues(t, expected, unsharedTraces(tracesExporter.Traces[0]))
 				}
 			}
 			for _, e := range allExporters[component.DataTypeMetrics] {
@@ -966,7 +971,7 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					expected.MarkReadOnly() // multiple read-only exporters should get read-only pdata
 				}
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, expected, metricsExporter.Metrics[0])
+					assert.EqualValues(t, expected, unsharedMetrics(metricsExporter.Metrics[0]))
 				}
 			}
 			for _, e := range allExporters[component.DataTypeLogs] {
@@ -977,7 +982,7 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					expected.MarkReadOnly() // multiple read-only exporters should get read-only pdata
 				}
 				for i := 0; i < test.expectedPerExporter; i++ {
-					assert.EqualValues(t, expected, logsExporter.Logs[0])
+					assert.EqualValues(t, expected, unsharedLogs(logsExporter.Logs[0]))
 				}
 			}
 		})
@@ -1088,6 +1093,8 @@ func TestConnectorRouter(t *testing.T) {
 				Exporters: []component.ID{expLeftID},
 			},
 		},
+		// The router must find the pipelines of the wrapped consumers.
+		EnableTaps: true,
 	}
 
 	pg, err := Build(ctx, set)
@@ -2550,3 +2557,81 @@ func (e errComponent) Start(context.Context, component.Host) error {
 func (e errComponent) Shutdown(context.Context) error {
 	return errors.New("my error")
 }
+
+func TestGraphPipelinesData(t *testing.T) {
+	nopID := component.MustNewID("nop")
+	tracesID := component.MustNewID("traces")
+	set := Settings{
+		Telemetry:        servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo:        component.NewDefaultBuildInfo(),
+		ReceiverBuilder:  receivertest.NewNopBuilder(),
+		ProcessorBuilder: processortest.NewNopBuilder(),
+		ExporterBuilder:  exportertest.NewNopBuilder(),
+		ConnectorBuilder: connectortest.NewNopBuilder(),
+		PipelineConfigs: pipelines.Config{
+			tracesID: {
+				Receivers:  []component.ID{nopID},
+				Processors: []component.ID{nopID},
+				Exporters:  []component.ID{nopID},
+			},
+		},
+	}
+	set.Telemetry.Status.Ready()
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	data := pg.pipelinesData()
+	require.Len(t, data.Pipelines, 1)
+	pipe := data.Pipelines[0]
+	assert.Equal(t, "traces", pipe.ID)
+	assert.Equal(t, "traces", pipe.InputType)
+	require.Len(t, pipe.Receivers, 1)
+	assert.Equal(t, "nop", pipe.Receivers[0].ID)
+	assert.Equal(t, "receiver", pipe.Receivers[0].Kind)
+	assert.Equal(t, "stable", pipe.Receivers[0].Stability)
+	assert.Empty(t, pipe.Receivers[0].Status)
+	require.Len(t, pipe.Processors, 1)
+	assert.Equal(t, "processor", pipe.Processors[0].Kind)
+	require.Len(t, pipe.Exporters, 1)
+	assert.Equal(t, "exporter", pipe.Exporters[0].Kind)
+
+	require.NoError(t, pg.StartAll(context.Background(), componenttest.NewNopHost(), set.Telemetry.Status))
+	data = pg.pipelinesData()
+	assert.Equal(t, component.StatusOK.String(), data.Pipelines[0].Receivers[0].Status)
+	assert.Equal(t, component.StatusOK.String(), data.Pipelines[0].Exporters[0].Status)
+	require.NoError(t, pg.ShutdownAll(context.Background(), set.Telemetry.Status))
+}
+
+// unsharedTraces returns a copy of the traces which does not share any element with other data,
+// so that the traces received by a component can be compared with the expected ones.
+func unsharedTraces(td ptrace.Traces) ptrace.Traces {
+	tracesCopy := ptrace.NewTraces()
+	td.CopyTo(tracesCopy)
+	if td.IsReadOnly() {
+		tracesCopy.MarkReadOnly()
+	}
+	return tracesCopy
+}
+
+// unsharedMetrics returns a copy of the metrics which does not share any element with other data,
+// so that the metrics received by a component can be compared with the expected ones.
+func unsharedMetrics(md pmetric.Metrics) pmetric.Metrics {
+	metricsCopy := pmetric.NewMetrics()
+	md.CopyTo(metricsCopy)
+	if md.IsReadOnly() {
+		metricsCopy.MarkReadOnly()
+	}
+	return metricsCopy
+}
+
+// unsharedLogs returns a copy of the logs which does not share any element with other data,
+// so that the logs received by a component can be compared with the expected ones.
+func unsharedLogs(ld plog.Logs) plog.Logs {
+	logsCopy := plog.NewLogs()
+	ld.CopyTo(logsCopy)
+	if ld.IsReadOnly() {
+		logsCopy.MarkReadOnly()
+	}
+	return logsCopy
+}
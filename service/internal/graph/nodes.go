@@ -226,6 +226,19 @@ func (n *connectorNode) getConsumer() baseConsumer {
 	return n.baseConsumer
 }
 
+// receiverPipelineID returns the ID of the pipeline in which the connector emits through next.
+func receiverPipelineID(next baseConsumer) component.ID {
+	switch tc := next.(type) {
+	case *tracesTapConsumer:
+		return tc.point.pipelineID
+	case *metricsTapConsumer:
+		return tc.point.pipelineID
+	case *logsTapConsumer:
+		return tc.point.pipelineID
+	}
+	return next.(*capabilitiesNode).pipelineID
+}
+
 func (n *connectorNode) buildComponent(
 	ctx context.Context,
 	tel component.TelemetrySettings,
@@ -241,7 +254,7 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Traces, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Traces)
+			consumers[receiverPipelineID(next)] = next.(consumer.Traces)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewTracesRouter(consumers)
@@ -277,7 +290,7 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Metrics, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Metrics)
+			consumers[receiverPipelineID(next)] = next.(consumer.Metrics)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewMetricsRouter(consumers)
@@ -312,7 +325,7 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Logs, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Logs)
+			consumers[receiverPipelineID(next)] = next.(consumer.Logs)
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewLogsRouter(consumers)
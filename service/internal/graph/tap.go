@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tapBufferSize is the number of sampled items buffered per tap session.
+// Items are dropped when the session is not read fast enough, so that a slow client never blocks the pipeline.
+const tapBufferSize = 16
+
+var (
+	tracesJSONMarshaler  = &ptrace.JSONMarshaler{}
+	metricsJSONMarshaler = &pmetric.JSONMarshaler{}
+	logsJSONMarshaler    = &plog.JSONMarshaler{}
+)
+
+// tapPoint identifies where the data flowing through the graph can be tapped:
+//   - the data emitted by a receiver (or a connector) into a pipeline,
+//   - the data consumed by a processor of a pipeline,
+//   - the data consumed by an exporter (or a connector) of a pipeline.
+type tapPoint struct {
+	kind        component.Kind
+	componentID component.ID
+	pipelineID  component.ID
+}
+
+// tapSession receives a sampled copy, marshaled as OTLP JSON, of the data flowing through a tapPoint.
+type tapSession struct {
+	point         tapPoint
+	samplingRatio float64
+	items         chan []byte
+}
+
+func (s *tapSession) sampled() bool {
+	return s.samplingRatio >= 1 || rand.Float64() < s.samplingRatio
+}
+
+// offer sends the item to the session without blocking, the item is dropped if the buffer is full.
+func (s *tapSession) offer(item []byte) {
+	select {
+	case s.items <- item:
+	default:
+	}
+}
+
+// tapRegistry keeps track of the tap points of the graph and of the sessions attached to them.
+type tapRegistry struct {
+	// active is the number of attached sessions, used to avoid any overhead when nothing is tapped.
+	active atomic.Int32
+
+	mu       sync.RWMutex
+	points   map[tapPoint]struct{}
+	sessions map[tapPoint]map[*tapSession]struct{}
+}
+
+func newTapRegistry() *tapRegistry {
+	return &tapRegistry{
+		points:   make(map[tapPoint]struct{}),
+		sessions: make(map[tapPoint]map[*tapSession]struct{}),
+	}
+}
+
+// attach starts a new session on the given point.
+// The returned bool is false if the point does not exist in the graph.
+func (r *tapRegistry) attach(point tapPoint, samplingRatio float64) (*tapSession, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.points[point]; !ok {
+		return nil, false
+	}
+	s := &tapSession{
+		point:         point,
+		samplingRatio: samplingRatio,
+		items:         make(chan []byte, tapBufferSize),
+	}
+	if r.sessions[point] == nil {
+		r.sessions[point] = make(map[*tapSession]struct{})
+	}
+	r.sessions[point][s] = struct{}{}
+	r.active.Add(1)
+	return s, true
+}
+
+// detach stops the given session, no more items are sent to it afterwards.
+func (r *tapRegistry) detach(s *tapSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sessions[s.point][s]; !ok {
+		return
+	}
+	delete(r.sessions[s.point], s)
+	if len(r.sessions[s.point]) == 0 {
+		delete(r.sessions, s.point)
+	}
+	r.active.Add(-1)
+}
+
+// publish offers the data to the sessions attached to the point.
+// The data is marshaled at most once, and only if at least one session sampled it.
+func (r *tapRegistry) publish(point tapPoint, marshal func() ([]byte, error)) {
+	if r.active.Load() == 0 {
+		return
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var item []byte
+	for s := range r.sessions[point] {
+		if !s.sampled() {
+			continue
+		}
+		if item == nil {
+			var err error
+			if item, err = marshal(); err != nil {
+				return
+			}
+		}
+		s.offer(item)
+	}
+}
+
+// wrap returns a consumer publishing the data to the sessions attached to the point before passing it to next.
+// The returned consumer only implements the consumer interface of the data type of the pipeline of the point.
+func (r *tapRegistry) wrap(point tapPoint, next baseConsumer) baseConsumer {
+	r.mu.Lock()
+	r.points[point] = struct{}{}
+	r.mu.Unlock()
+	switch point.pipelineID.Type() {
+	case component.DataTypeTraces:
+		return &tracesTapConsumer{Traces: next.(consumer.Traces), point: point, taps: r}
+	case component.DataTypeMetrics:
+		return &metricsTapConsumer{Metrics: next.(consumer.Metrics), point: point, taps: r}
+	case component.DataTypeLogs:
+		return &logsTapConsumer{Logs: next.(consumer.Logs), point: point, taps: r}
+	}
+	return next
+}
+
+type tracesTapConsumer struct {
+	consumer.Traces
+	point tapPoint
+	taps  *tapRegistry
+}
+
+func (c *tracesTapConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c.taps.publish(c.point, func() ([]byte, error) { return tracesJSONMarshaler.MarshalTraces(td) })
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsTapConsumer struct {
+	consumer.Metrics
+	point tapPoint
+	taps  *tapRegistry
+}
+
+func (c *metricsTapConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.taps.publish(c.point, func() ([]byte, error) { return metricsJSONMarshaler.MarshalMetrics(md) })
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsTapConsumer struct {
+	consumer.Logs
+	point tapPoint
+	taps  *tapRegistry
+}
+
+func (c *logsTapConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.taps.publish(c.point, func() ([]byte, error) { return logsJSONMarshaler.MarshalLogs(ld) })
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// edgeTapPoint returns the tap point of the edge going from one node to the other, if any.
+func edgeTapPoint(from, to graph.Node) (tapPoint, bool) {
+	switch t := to.(type) {
+	case *processorNode:
+		return tapPoint{kind: component.KindProcessor, componentID: t.componentID, pipelineID: t.pipelineID}, true
+	case *exporterNode:
+		if f, ok := from.(*fanOutNode); ok {
+			return tapPoint{kind: component.KindExporter, componentID: t.componentID, pipelineID: f.pipelineID}, true
+		}
+	case *connectorNode:
+		if f, ok := from.(*fanOutNode); ok {
+			return tapPoint{kind: component.KindConnector, componentID: t.componentID, pipelineID: f.pipelineID}, true
+		}
+	case *capabilitiesNode:
+		switch f := from.(type) {
+		case *receiverNode:
+			return tapPoint{kind: component.KindReceiver, componentID: f.componentID, pipelineID: t.pipelineID}, true
+		case *connectorNode:
+			return tapPoint{kind: component.KindConnector, componentID: f.componentID, pipelineID: t.pipelineID}, true
+		}
+	}
+	return tapPoint{}, false
+}
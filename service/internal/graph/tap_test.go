@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func newTapTestGraph(t *testing.T, enableTaps bool) *Graph {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("exampleprocessor")
+	expID := component.MustNewID("exampleexporter")
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{procID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+		EnableTaps: enableTaps,
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	return pg
+}
+
+func TestHandleTapZPages(t *testing.T) {
+	for _, query := range []string{
+		"componentkindz=receiver&componentnamez=examplereceiver",
+		"componentkindz=processor&componentnamez=exampleprocessor",
+		"componentkindz=exporter&componentnamez=exampleexporter",
+	} {
+		t.Run(query, func(t *testing.T) {
+			pg := newTapTestGraph(t, true)
+			srv := httptest.NewServer(http.HandlerFunc(pg.HandleTapZPages))
+			defer srv.Close()
+
+			respCh := make(chan *http.Response, 1)
+			go func() {
+				resp, err := http.Get(srv.URL + "?pipelinenamez=traces&maxitemsz=2&durationz=10s&" + query)
+				assert.NoError(t, err)
+				respCh <- resp
+			}()
+			require.Eventually(t, func() bool { return pg.taps.active.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+			rcvr := pg.getReceivers()[component.DataTypeTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+			for i := 0; i < 3; i++ {
+				require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			}
+
+			resp := <-respCh
+			require.NotNil(t, resp)
+			defer func() { assert.NoError(t, resp.Body.Close()) }()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+			var lines int
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(scanner.Bytes())
+				require.NoError(t, err)
+				assert.Equal(t, 1, td.SpanCount())
+				lines++
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, 2, lines)
+			assert.Eventually(t, func() bool { return pg.taps.active.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestHandleTapZPagesErrors(t *testing.T) {
+	pg := newTapTestGraph(t, true)
+	for _, tt := range []struct {
+		query  string
+		status int
+	}{
+		{query: "componentkindz=extension&componentnamez=exampleprocessor&pipelinenamez=traces", status: http.StatusBadRequest},
+		{query: "componentkindz=processor&componentnamez=&pipelinenamez=traces", status: http.StatusBadRequest},
+		{query: "componentkindz=processor&componentnamez=exampleprocessor&pipelinenamez=traces&samplingratioz=2", status: http.StatusBadRequest},
+		{query: "componentkindz=processor&componentnamez=exampleprocessor&pipelinenamez=traces&durationz=1h", status: http.StatusBadRequest},
+		{query: "componentkindz=processor&componentnamez=exampleprocessor&pipelinenamez=traces&maxitemsz=0", status: http.StatusBadRequest},
+		{query: "componentkindz=processor&componentnamez=exampleprocessor&pipelinenamez=metrics", status: http.StatusNotFound},
+		{query: "componentkindz=exporter&componentnamez=exampleprocessor&pipelinenamez=traces", status: http.StatusNotFound},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			pg.HandleTapZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/tapz?"+tt.query, nil))
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Zero(t, pg.taps.active.Load())
+		})
+	}
+}
+
+func TestHandleTapZPagesDisabled(t *testing.T) {
+	pg := newTapTestGraph(t, false)
+	rr := httptest.NewRecorder()
+	pg.HandleTapZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/tapz?componentkindz=processor&componentnamez=exampleprocessor&pipelinenamez=traces", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// The consumers of the pipelines are not wrapped.
+	pipe := pg.pipelines[component.MustNewID("traces")]
+	nexts := pg.nextConsumers(pipe.capabilitiesNode.ID())
+	require.Len(t, nexts, 1)
+	assert.Same(t, pipe.processors[0].getConsumer(), nexts[0])
+}
+
+func TestTapRegistryWrapDataType(t *testing.T) {
+	taps := newTapRegistry()
+	for _, pipelineID := range []component.ID{component.MustNewID("traces"), component.MustNewID("metrics"), component.MustNewID("logs")} {
+		wrapped := taps.wrap(tapPoint{kind: component.KindProcessor, componentID: component.MustNewID("nop"), pipelineID: pipelineID}, consumertest.NewNop())
+		_, isTraces := wrapped.(consumer.Traces)
+		_, isMetrics := wrapped.(consumer.Metrics)
+		_, isLogs := wrapped.(consumer.Logs)
+		assert.Equal(t, pipelineID.Type() == component.DataTypeTraces, isTraces)
+		assert.Equal(t, pipelineID.Type() == component.DataTypeMetrics, isMetrics)
+		assert.Equal(t, pipelineID.Type() == component.DataTypeLogs, isLogs)
+	}
+}
+
+func TestTapRegistryDropsWhenFull(t *testing.T) {
+	taps := newTapRegistry()
+	point := tapPoint{kind: component.KindProcessor, componentID: component.MustNewID("nop"), pipelineID: component.MustNewID("traces")}
+	taps.wrap(point, consumertest.NewNop())
+
+	session, ok := taps.attach(point, 1)
+	require.True(t, ok)
+	for i := 0; i < 2*tapBufferSize; i++ {
+		taps.publish(point, func() ([]byte, error) { return []byte("{}"), nil })
+	}
+	assert.Len(t, session.items, tapBufferSize)
+
+	taps.detach(session)
+	taps.detach(session)
+	assert.Zero(t, taps.active.Load())
+	taps.publish(point, func() ([]byte, error) {
+		assert.Fail(t, "data must not be marshaled without session")
+		return nil, nil
+	})
+}
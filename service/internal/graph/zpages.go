@@ -4,9 +4,17 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -16,6 +24,14 @@ const (
 	zPipelineName  = "pipelinenamez"
 	zComponentName = "componentnamez"
 	zComponentKind = "componentkindz"
+	zDuration      = "durationz"
+	zMaxItems      = "maxitemsz"
+	zSamplingRatio = "samplingratioz"
+
+	defaultTapDuration = 30 * time.Second
+	maxTapDuration     = 5 * time.Minute
+	defaultTapMaxItems = 100
+	maxTapMaxItems     = 1000
 )
 
 func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
@@ -24,6 +40,11 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	componentName := qValues.Get(zComponentName)
 	componentKind := qValues.Get(zComponentKind)
 
+	if zpages.IsJSONRequest(r) {
+		zpages.WriteJSON(w, g.pipelinesData(), g.telemetry.Logger)
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "builtPipelines"})
 
@@ -79,3 +100,148 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+func (g *Graph) pipelinesData() zpages.PipelinesData {
+	data := zpages.PipelinesData{Pipelines: make([]zpages.PipelineData, 0, len(g.pipelines))}
+	for pipelineID, p := range g.pipelines {
+		pipeData := zpages.PipelineData{
+			ID:          pipelineID.String(),
+			InputType:   pipelineID.Type().String(),
+			MutatesData: p.capabilitiesNode.getConsumer().Capabilities().MutatesData,
+			Receivers:   make([]zpages.ComponentData, 0, len(p.receivers)),
+			Processors:  make([]zpages.ComponentData, 0, len(p.processors)),
+			Exporters:   make([]zpages.ComponentData, 0, len(p.exporters)),
+		}
+		for _, n := range p.receivers {
+			pipeData.Receivers = append(pipeData.Receivers, g.componentData(n))
+		}
+		for _, n := range p.processors {
+			pipeData.Processors = append(pipeData.Processors, g.componentData(n))
+		}
+		for _, n := range p.exporters {
+			pipeData.Exporters = append(pipeData.Exporters, g.componentData(n))
+		}
+		// Processors keep the pipeline order, receivers and exporters are sorted to produce a stable output.
+		sortComponentsData(pipeData.Receivers)
+		sortComponentsData(pipeData.Exporters)
+		data.Pipelines = append(data.Pipelines, pipeData)
+	}
+	sort.Slice(data.Pipelines, func(i, j int) bool {
+		return data.Pipelines[i].ID < data.Pipelines[j].ID
+	})
+	return data
+}
+
+func (g *Graph) componentData(node graph.Node) zpages.ComponentData {
+	instanceID := g.instanceIDs[node.ID()]
+	var ev *component.StatusEvent
+	if g.telemetry.Status != nil {
+		ev, _ = g.telemetry.Status.Status(instanceID)
+	}
+	return zpages.NewComponentData(instanceID.ID, instanceID.Kind, g.stability[node.ID()], ev)
+}
+
+func sortComponentsData(data []zpages.ComponentData) {
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].ID == data[j].ID {
+			return data[i].Kind < data[j].Kind
+		}
+		return data[i].ID < data[j].ID
+	})
+}
+
+// HandleTapZPages streams, as newline-delimited OTLP JSON, a sampled copy of the data flowing through a component
+// of a pipeline. The stream ends when the duration elapsed, when the maximum number of items was sent or when the
+// client disconnects, whichever happens first.
+func (g *Graph) HandleTapZPages(w http.ResponseWriter, r *http.Request) {
+	if g.taps == nil {
+		http.Error(w, "tapping the pipelines requires the zpages extension", http.StatusNotFound)
+		return
+	}
+	point, samplingRatio, duration, maxItems, err := parseTapRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	session, ok := g.taps.attach(point, samplingRatio)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no %s %q in pipeline %q", strings.ToLower(point.kind.String()), point.componentID, point.pipelineID), http.StatusNotFound)
+		return
+	}
+	defer g.taps.detach(session)
+
+	ctx, cancel := context.WithTimeout(r.Context(), duration)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	for sent := 0; sent < maxItems; sent++ {
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-session.items:
+			// The item is shared with the other sessions, write the delimiter separately instead of appending it.
+			if _, err = w.Write(item); err != nil {
+				return
+			}
+			if _, err = w.Write([]byte{'\n'}); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func parseTapRequest(r *http.Request) (point tapPoint, samplingRatio float64, duration time.Duration, maxItems int, err error) {
+	qValues := r.URL.Query()
+	switch qValues.Get(zComponentKind) {
+	case "receiver":
+		point.kind = component.KindReceiver
+	case "processor":
+		point.kind = component.KindProcessor
+	case "exporter":
+		point.kind = component.KindExporter
+	case "connector":
+		point.kind = component.KindConnector
+	default:
+		err = fmt.Errorf("%s must be one of receiver, processor, exporter or connector", zComponentKind)
+		return
+	}
+	if err = point.componentID.UnmarshalText([]byte(qValues.Get(zComponentName))); err != nil {
+		err = fmt.Errorf("invalid %s: %w", zComponentName, err)
+		return
+	}
+	if err = point.pipelineID.UnmarshalText([]byte(qValues.Get(zPipelineName))); err != nil {
+		err = fmt.Errorf("invalid %s: %w", zPipelineName, err)
+		return
+	}
+
+	samplingRatio = 1
+	if v := qValues.Get(zSamplingRatio); v != "" {
+		if samplingRatio, err = strconv.ParseFloat(v, 64); err != nil || samplingRatio <= 0 || samplingRatio > 1 {
+			err = fmt.Errorf("%s must be a number in (0, 1]", zSamplingRatio)
+			return
+		}
+	}
+	duration = defaultTapDuration
+	if v := qValues.Get(zDuration); v != "" {
+		if duration, err = time.ParseDuration(v); err != nil || duration <= 0 || duration > maxTapDuration {
+			err = fmt.Errorf("%s must be a positive duration not greater than %v", zDuration, maxTapDuration)
+			return
+		}
+	}
+	maxItems = defaultTapMaxItems
+	if v := qValues.Get(zMaxItems); v != "" {
+		if maxItems, err = strconv.Atoi(v); err != nil || maxItems <= 0 || maxItems > maxTapMaxItems {
+			err = fmt.Errorf("%s must be a positive integer not greater than %d", zMaxItems, maxTapMaxItems)
+			return
+		}
+	}
+	return point, samplingRatio, duration, maxItems, nil
+}
@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package loglevel allows to change the level of the collector's own logs at runtime,
+// globally or for the loggers of a specific component.
+package loglevel // import "go.opentelemetry.io/collector/service/internal/loglevel"
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// componentFieldKey is the key of the field identifying the component of a logger.
+const componentFieldKey = "otelcol.loglevel.component"
+
+// Component identifies the loggers of a component.
+type Component struct {
+	Kind component.Kind
+	ID   component.ID
+}
+
+// ComponentField returns a field identifying the loggers of the component, which is not encoded in the logs.
+// It must be added when the logger of the component is created, so that the levels of the component apply to it.
+func ComponentField(kind component.Kind, id component.ID) zap.Field {
+	return zap.Field{Key: componentFieldKey, Type: zapcore.SkipType, Interface: Component{Kind: kind, ID: id}}
+}
+
+// Level is the level overriding the global level for the loggers of a Component.
+type Level struct {
+	Component
+	Level zapcore.Level
+	// ExpiresAt is the time the override is removed.
+	ExpiresAt time.Time
+}
+
+// PatternLevel is the configured level of the loggers of the components selected by a Pattern.
+type PatternLevel struct {
+	Pattern Pattern
+	Level   zapcore.Level
+}
+
+// State is the current state of the levels.
+type State struct {
+	Level zapcore.Level
+	// ExpiresAt is the time the level reverts to the configured one, zero if the level is the configured one.
+	ExpiresAt time.Time
+	// Configured contains the configured levels of the components, sorted by pattern.
+	Configured []PatternLevel
+	// Components contains the levels of the components changed at runtime, sorted by kind and ID.
+	Components []Level
+}
+
+// Controller controls the level of the loggers created from a core wrapped by the Controller.
+// The level of the loggers of a component is, by order of precedence, the level changed at runtime for the component,
+// the configured level of the most specific pattern selecting the component, or the global level.
+// The levels changed at runtime revert to the configured ones once their TTL expires.
+type Controller struct {
+	global atomic.Int32
+	// overrides is replaced on every change so that the loggers can read it without locking.
+	overrides atomic.Pointer[map[Component]zapcore.Level]
+
+	mu         sync.Mutex
+	configured zapcore.Level
+	patterns   []PatternLevel
+	expiresAt  time.Time
+	revert     *time.Timer
+	components map[Component]*override
+}
+
+type override struct {
+	level     zapcore.Level
+	expiresAt time.Time
+	revert    *time.Timer
+}
+
+// NewController returns a Controller with the info level and no component override.
+func NewController() *Controller {
+	c := &Controller{
+		configured: zapcore.InfoLevel,
+		components: make(map[Component]*override),
+	}
+	c.global.Store(int32(zapcore.InfoLevel))
+	c.overrides.Store(&map[Component]zapcore.Level{})
+	return c
+}
+
+// Configure sets the configured global level, cancelling the runtime change, if any,
+// and the configured levels of the components, keyed by Pattern.
+// The levels of the components apply to the loggers created afterwards.
+func (c *Controller) Configure(level zapcore.Level, components map[string]zapcore.Level) error {
+	patterns := make([]PatternLevel, 0, len(components))
+	for raw, l := range components {
+		p, err := ParsePattern(raw)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, PatternLevel{Pattern: p, Level: l})
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].Pattern.raw < patterns[j].Pattern.raw
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.configured = level
+	c.patterns = patterns
+	c.resetGlobal()
+	return nil
+}
+
+// configuredLevel returns the level of the most specific pattern selecting the component, if any.
+func (c *Controller) configuredLevel(comp Component) (zapcore.Level, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	best := -1
+	var level zapcore.Level
+	for _, pl := range c.patterns {
+		if s := pl.Pattern.specificity(); s > best && pl.Pattern.matches(comp) {
+			best, level = s, pl.Level
+		}
+	}
+	return level, best >= 0
+}
+
+// SetLevel changes the global level, until the TTL expires.
+func (c *Controller) SetLevel(level zapcore.Level, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.revert != nil {
+		c.revert.Stop()
+	}
+	c.global.Store(int32(level))
+	c.expiresAt = time.Now().Add(ttl)
+	var t *time.Timer
+	t = time.AfterFunc(ttl, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// The timer may have fired concurrently to a later change.
+		if c.revert == t {
+			c.resetGlobal()
+		}
+	})
+	c.revert = t
+}
+
+// ResetLevel reverts the global level to the configured one.
+func (c *Controller) ResetLevel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetGlobal()
+}
+
+// Note: a lock must be acquired before calling this method.
+func (c *Controller) resetGlobal() {
+	if c.revert != nil {
+		c.revert.Stop()
+		c.revert = nil
+	}
+	c.expiresAt = time.Time{}
+	c.global.Store(int32(c.configured))
+}
+
+// SetComponentLevel changes the level of the loggers of a component, until the TTL expires.
+func (c *Controller) SetComponentLevel(comp Component, level zapcore.Level, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o, ok := c.components[comp]
+	if !ok {
+		o = &override{}
+		c.components[comp] = o
+	}
+	if o.revert != nil {
+		o.revert.Stop()
+	}
+	o.level = level
+	o.expiresAt = time.Now().Add(ttl)
+	var t *time.Timer
+	t = time.AfterFunc(ttl, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// The timer may have fired concurrently to a later change.
+		if cur, ok := c.components[comp]; ok && cur.revert == t {
+			c.resetComponent(comp)
+		}
+	})
+	o.revert = t
+	c.publish()
+}
+
+// ResetComponentLevel removes the override of the level of the loggers of a component.
+func (c *Controller) ResetComponentLevel(comp Component) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetComponent(comp)
+}
+
+// Note: a lock must be acquired before calling this method.
+func (c *Controller) resetComponent(comp Component) {
+	o, ok := c.components[comp]
+	if !ok {
+		return
+	}
+	if o.revert != nil {
+		o.revert.Stop()
+	}
+	delete(c.components, comp)
+	c.publish()
+}
+
+// Note: a lock must be acquired before calling this method.
+func (c *Controller) publish() {
+	overrides := make(map[Component]zapcore.Level, len(c.components))
+	for comp, o := range c.components {
+		overrides[comp] = o.level
+	}
+	c.overrides.Store(&overrides)
+}
+
+// State returns the current state of the levels.
+func (c *Controller) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := State{
+		Level:      zapcore.Level(c.global.Load()),
+		ExpiresAt:  c.expiresAt,
+		Configured: c.patterns,
+		Components: make([]Level, 0, len(c.components)),
+	}
+	for comp, o := range c.components {
+		s.Components = append(s.Components, Level{Component: comp, Level: o.level, ExpiresAt: o.expiresAt})
+	}
+	sort.Slice(s.Components, func(i, j int) bool {
+		if s.Components[i].Kind != s.Components[j].Kind {
+			return s.Components[i].Kind < s.Components[j].Kind
+		}
+		return s.Components[i].ID.String() < s.Components[j].ID.String()
+	})
+	return s
+}
+
+func (c *Controller) level(core *levelCore) zapcore.Level {
+	if core.comp != nil {
+		if level, ok := (*c.overrides.Load())[*core.comp]; ok {
+			return level
+		}
+	}
+	if core.configured != nil {
+		return *core.configured
+	}
+	return zapcore.Level(c.global.Load())
+}
+
+// WrapCore wraps a core to filter the entries according to the levels of the Controller.
+// The wrapped core must enable all the levels, the filtering being done by the Controller.
+func (c *Controller) WrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, ctrl: c}
+}
+
+// levelCore filters the entries of a core according to the level of the component of its logger, if any.
+type levelCore struct {
+	zapcore.Core
+	ctrl *Controller
+
+	comp *Component
+	// configured is the configured level of the component, resolved when the logger of the component is created.
+	configured *zapcore.Level
+}
+
+var _ zapcore.LevelEnabler = (*levelCore)(nil)
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.ctrl.level(c)
+}
+
+// Level returns the minimum enabled level, which allows zap to report it with zapcore.LevelOf.
+func (c *levelCore) Level() zapcore.Level {
+	return c.ctrl.level(c)
+}
+
+// With sets the identity of the component from the field added by ComponentField. The identity is only set once,
+// the fields added by the loggers derived from the one of the component do not change it.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &levelCore{Core: c.Core.With(fields), ctrl: c.ctrl, comp: c.comp, configured: c.configured}
+	if clone.comp != nil {
+		return clone
+	}
+	for _, f := range fields {
+		comp, ok := f.Interface.(Component)
+		if f.Type != zapcore.SkipType || f.Key != componentFieldKey || !ok {
+			continue
+		}
+		clone.comp = &comp
+		if level, ok := c.ctrl.configuredLevel(comp); ok {
+			clone.configured = &level
+		}
+		break
+	}
+	return clone
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// ParseKind returns the component.Kind of its lowercase name, as used in the loggers and the zPages.
+func ParseKind(kind string) (component.Kind, bool) {
+	for _, k := range []component.Kind{
+		component.KindReceiver,
+		component.KindProcessor,
+		component.KindExporter,
+		component.KindExtension,
+		component.KindConnector,
+	} {
+		if kind == strings.ToLower(k.String()) {
+			return k, true
+		}
+	}
+	return 0, false
+}
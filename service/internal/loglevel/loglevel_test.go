@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func newObservedLogger(c *Controller) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(c.WrapCore(core)), logs
+}
+
+func TestControllerGlobalLevel(t *testing.T) {
+	c := NewController()
+	require.NoError(t, c.Configure(zapcore.WarnLevel, nil))
+	logger, logs := newObservedLogger(c)
+
+	logger.Info("filtered")
+	logger.Warn("logged")
+	assert.Equal(t, 1, logs.Len())
+	assert.Equal(t, zapcore.WarnLevel, zapcore.LevelOf(logger.Core()))
+
+	c.SetLevel(zapcore.DebugLevel, time.Hour)
+	logger.Debug("logged")
+	assert.Equal(t, 2, logs.Len())
+	state := c.State()
+	assert.Equal(t, zapcore.DebugLevel, state.Level)
+	assert.False(t, state.ExpiresAt.IsZero())
+
+	c.ResetLevel()
+	logger.Info("filtered")
+	assert.Equal(t, 2, logs.Len())
+	state = c.State()
+	assert.Equal(t, zapcore.WarnLevel, state.Level)
+	assert.True(t, state.ExpiresAt.IsZero())
+}
+
+func TestControllerLevelExpires(t *testing.T) {
+	c := NewController()
+	c.SetLevel(zapcore.ErrorLevel, 10*time.Millisecond)
+	assert.Equal(t, zapcore.ErrorLevel, c.State().Level)
+	assert.Eventually(t, func() bool { return c.State().Level == zapcore.InfoLevel }, 5*time.Second, 5*time.Millisecond)
+
+	comp := Component{Kind: component.KindReceiver, ID: component.MustNewID("otlp")}
+	c.SetComponentLevel(comp, zapcore.DebugLevel, 10*time.Millisecond)
+	assert.Len(t, c.State().Components, 1)
+	assert.Eventually(t, func() bool { return len(c.State().Components) == 0 }, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestControllerComponentLevel(t *testing.T) {
+	c := NewController()
+	logger, logs := newObservedLogger(c)
+	comp := Component{Kind: component.KindReceiver, ID: component.MustNewIDWithName("otlp", "2")}
+	rcvLogger := logger.With(ComponentField(comp.Kind, comp.ID), zap.String("kind", "receiver"))
+	expLogger := logger.With(ComponentField(component.KindExporter, comp.ID))
+
+	c.SetComponentLevel(comp, zapcore.DebugLevel, time.Hour)
+	rcvLogger.Debug("logged")
+	// The fields added by the component do not change its identity.
+	rcvLogger.With(zap.String("kind", "exporter"), zap.String("name", "other")).Debug("logged")
+	rcvLogger.With(ComponentField(component.KindExporter, comp.ID)).Debug("logged")
+	expLogger.Debug("filtered")
+	logger.Debug("filtered")
+	require.Equal(t, 3, logs.Len())
+	// The identity of the component is not encoded.
+	assert.Equal(t, map[string]any{"kind": "receiver"}, logs.All()[0].ContextMap())
+
+	state := c.State()
+	require.Len(t, state.Components, 1)
+	assert.Equal(t, comp, state.Components[0].Component)
+	assert.Equal(t, zapcore.DebugLevel, state.Components[0].Level)
+
+	c.SetLevel(zapcore.ErrorLevel, time.Hour)
+	rcvLogger.Debug("logged")
+	expLogger.Warn("filtered")
+	assert.Equal(t, 4, logs.Len())
+
+	c.ResetComponentLevel(comp)
+	rcvLogger.Warn("filtered")
+	assert.Equal(t, 4, logs.Len())
+	assert.Empty(t, c.State().Components)
+}
+
+func TestParseKind(t *testing.T) {
+	kind, ok := ParseKind("connector")
+	assert.True(t, ok)
+	assert.Equal(t, component.KindConnector, kind)
+
+	_, ok = ParseKind("Receiver")
+	assert.False(t, ok)
+}
+
+func TestControllerConfiguredComponentLevel(t *testing.T) {
+	c := NewController()
+	require.NoError(t, c.Configure(zapcore.InfoLevel, map[string]zapcore.Level{
+		"exporter:*":    zapcore.WarnLevel,
+		"exporter:otlp": zapcore.DebugLevel,
+		"otlp/*":        zapcore.ErrorLevel,
+	}))
+	logger, logs := newObservedLogger(c)
+	otlpLogger := logger.With(ComponentField(component.KindExporter, component.MustNewID("otlp")))
+	otlp2Logger := logger.With(ComponentField(component.KindExporter, component.MustNewIDWithName("otlp", "2")))
+	debugLogger := logger.With(ComponentField(component.KindExporter, component.MustNewID("debug")))
+	rcvLogger := logger.With(ComponentField(component.KindReceiver, component.MustNewID("nop")))
+
+	assert.Equal(t, zapcore.DebugLevel, zapcore.LevelOf(otlpLogger.Core()))
+	assert.Equal(t, zapcore.ErrorLevel, zapcore.LevelOf(otlp2Logger.Core()))
+	assert.Equal(t, zapcore.WarnLevel, zapcore.LevelOf(debugLogger.Core()))
+	assert.Equal(t, zapcore.InfoLevel, zapcore.LevelOf(rcvLogger.Core()))
+
+	otlpLogger.Debug("logged")
+	debugLogger.Info("filtered")
+	rcvLogger.Info("logged")
+	assert.Equal(t, 2, logs.Len())
+
+	// The levels changed at runtime take precedence over the configured ones.
+	comp := Component{Kind: component.KindExporter, ID: component.MustNewID("otlp")}
+	c.SetComponentLevel(comp, zapcore.ErrorLevel, time.Hour)
+	assert.Equal(t, zapcore.ErrorLevel, zapcore.LevelOf(otlpLogger.Core()))
+	c.ResetComponentLevel(comp)
+	assert.Equal(t, zapcore.DebugLevel, zapcore.LevelOf(otlpLogger.Core()))
+
+	// The configured levels of the components do not depend on the global level.
+	c.SetLevel(zapcore.ErrorLevel, time.Hour)
+	assert.Equal(t, zapcore.DebugLevel, zapcore.LevelOf(otlpLogger.Core()))
+	assert.Equal(t, zapcore.ErrorLevel, zapcore.LevelOf(rcvLogger.Core()))
+	c.ResetLevel()
+
+	state := c.State()
+	require.Len(t, state.Configured, 3)
+	assert.Equal(t, "exporter:*", state.Configured[0].Pattern.String())
+	assert.Equal(t, zapcore.WarnLevel, state.Configured[0].Level)
+}
+
+func TestControllerConfigureInvalidPattern(t *testing.T) {
+	c := NewController()
+	assert.Error(t, c.Configure(zapcore.InfoLevel, map[string]zapcore.Level{"pipeline:traces": zapcore.DebugLevel}))
+}
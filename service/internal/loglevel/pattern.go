@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel // import "go.opentelemetry.io/collector/service/internal/loglevel"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const wildcard = "*"
+
+// Pattern selects the loggers of components, it has the form `[<kind>:]<type>[/<name>]`:
+//   - `otlp/2` selects the components with the ID `otlp/2`, whatever their kind,
+//   - `exporter:otlp` selects the exporter with the ID `otlp`,
+//   - `exporter:otlp/*` selects the exporters of type `otlp`, whatever their name,
+//   - `exporter:*` selects all the exporters,
+//   - `*` selects all the components.
+type Pattern struct {
+	raw string
+	// kind is zero when the pattern matches any kind.
+	kind component.Kind
+	// typ is empty when the pattern matches any ID.
+	typ string
+	// name is nil when the pattern matches any name.
+	name *string
+}
+
+// ParsePattern parses a Pattern.
+func ParsePattern(s string) (Pattern, error) {
+	p := Pattern{raw: s}
+	id := s
+	if kind, rest, ok := strings.Cut(s, ":"); ok {
+		if p.kind, ok = ParseKind(kind); !ok {
+			return Pattern{}, fmt.Errorf("invalid pattern %q: unknown kind %q", s, kind)
+		}
+		id = rest
+	}
+	if id == wildcard {
+		return p, nil
+	}
+	typ, name, hasName := strings.Cut(id, "/")
+	if _, err := component.NewType(typ); err != nil {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: %w", s, err)
+	}
+	p.typ = typ
+	switch {
+	case !hasName:
+		p.name = new(string)
+	case name == wildcard:
+	default:
+		// Validates the name.
+		var cid component.ID
+		if err := cid.UnmarshalText([]byte(id)); err != nil {
+			return Pattern{}, fmt.Errorf("invalid pattern %q: %w", s, err)
+		}
+		p.name = &name
+	}
+	return p, nil
+}
+
+// String returns the Pattern as it was parsed.
+func (p Pattern) String() string {
+	return p.raw
+}
+
+// matches returns whether the Pattern selects the Component.
+func (p Pattern) matches(comp Component) bool {
+	if p.kind != 0 && p.kind != comp.Kind {
+		return false
+	}
+	if p.typ == "" {
+		return true
+	}
+	if p.typ != comp.ID.Type().String() {
+		return false
+	}
+	return p.name == nil || *p.name == comp.ID.Name()
+}
+
+// specificity ranks the patterns matching a same Component, the most specific one applies:
+// a pattern selecting an ID is more specific than one selecting a type, which is more specific than a wildcard,
+// and for the same ID selection, a pattern selecting the kind is more specific than one that does not.
+func (p Pattern) specificity() int {
+	s := 0
+	if p.typ != "" {
+		s += 2
+		if p.name != nil {
+			s += 2
+		}
+	}
+	if p.kind != 0 {
+		s++
+	}
+	return s
+}
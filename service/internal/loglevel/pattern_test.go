@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestParsePattern(t *testing.T) {
+	otlpExporter := Component{Kind: component.KindExporter, ID: component.MustNewID("otlp")}
+	otlp2Exporter := Component{Kind: component.KindExporter, ID: component.MustNewIDWithName("otlp", "2")}
+	otlpReceiver := Component{Kind: component.KindReceiver, ID: component.MustNewID("otlp")}
+	debugExporter := Component{Kind: component.KindExporter, ID: component.MustNewID("debug")}
+
+	tests := []struct {
+		pattern     string
+		matches     []Component
+		mismatches  []Component
+		specificity int
+	}{
+		{pattern: "*", matches: []Component{otlpExporter, otlp2Exporter, otlpReceiver, debugExporter}, specificity: 0},
+		{pattern: "exporter:*", matches: []Component{otlpExporter, otlp2Exporter, debugExporter}, mismatches: []Component{otlpReceiver}, specificity: 1},
+		{pattern: "otlp/*", matches: []Component{otlpExporter, otlp2Exporter, otlpReceiver}, mismatches: []Component{debugExporter}, specificity: 2},
+		{pattern: "exporter:otlp/*", matches: []Component{otlpExporter, otlp2Exporter}, mismatches: []Component{otlpReceiver, debugExporter}, specificity: 3},
+		{pattern: "otlp", matches: []Component{otlpExporter, otlpReceiver}, mismatches: []Component{otlp2Exporter, debugExporter}, specificity: 4},
+		{pattern: "exporter:otlp/2", matches: []Component{otlp2Exporter}, mismatches: []Component{otlpExporter, otlpReceiver, debugExporter}, specificity: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p, err := ParsePattern(tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.pattern, p.String())
+			assert.Equal(t, tt.specificity, p.specificity())
+			for _, comp := range tt.matches {
+				assert.True(t, p.matches(comp), comp)
+			}
+			for _, comp := range tt.mismatches {
+				assert.False(t, p.matches(comp), comp)
+			}
+		})
+	}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"pipeline:otlp",
+		"exporter:",
+		"1otlp",
+		"otlp/",
+		"*/2",
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := ParsePattern(pattern)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -100,6 +100,8 @@ type Reporter interface {
 	Ready()
 	ReportStatus(id *component.InstanceID, ev *component.StatusEvent)
 	ReportOKIfStarting(id *component.InstanceID)
+	// Status returns the latest status event of the given InstanceID, if any status was reported.
+	Status(id *component.InstanceID) (*component.StatusEvent, bool)
 }
 
 type reporter struct {
@@ -157,6 +159,16 @@ func (r *reporter) ReportOKIfStarting(id *component.InstanceID) {
 	}
 }
 
+func (r *reporter) Status(id *component.InstanceID) (*component.StatusEvent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fsm, ok := r.fsmMap[id]
+	if !ok {
+		return nil, false
+	}
+	return fsm.current, true
+}
+
 // Note: a lock must be acquired before calling this method.
 func (r *reporter) componentFSM(id *component.InstanceID) *fsm {
 	fsm, ok := r.fsmMap[id]
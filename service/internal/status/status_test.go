@@ -8,6 +8,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
@@ -277,6 +278,22 @@ func TestReporterReady(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReporterStatus(t *testing.T) {
+	rep := NewReporter(func(*component.InstanceID, *component.StatusEvent) {}, func(error) {})
+	rep.Ready()
+	id := &component.InstanceID{}
+
+	_, ok := rep.Status(id)
+	require.False(t, ok)
+
+	rep.ReportStatus(id, component.NewStatusEvent(component.StatusStarting))
+	rep.ReportStatus(id, component.NewRecoverableErrorEvent(assert.AnError))
+	ev, ok := rep.Status(id)
+	require.True(t, ok)
+	assert.Equal(t, component.StatusRecoverableError, ev.Status())
+	assert.Equal(t, assert.AnError, ev.Err())
+}
+
 func TestReportComponentOKIfStarting(t *testing.T) {
 	for _, tc := range []struct {
 		name             string
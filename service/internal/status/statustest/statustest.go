@@ -19,3 +19,7 @@ func (r *nopStatusReporter) Ready() {}
 func (r *nopStatusReporter) ReportStatus(*component.InstanceID, *component.StatusEvent) {}
 
 func (r *nopStatusReporter) ReportOKIfStarting(*component.InstanceID) {}
+
+func (r *nopStatusReporter) Status(*component.InstanceID) (*component.StatusEvent, bool) {
+	return nil, false
+}
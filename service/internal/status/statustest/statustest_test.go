@@ -5,9 +5,13 @@ package statustest // import "go.opentelemetry.io/collector/service/internal/sta
 
 import "testing"
 
-func TestNopStatusReporter(*testing.T) {
+func TestNopStatusReporter(t *testing.T) {
 	nop := NewNopStatusReporter()
 	nop.Ready()
 	nop.ReportOKIfStarting(nil)
 	nop.ReportStatus(nil, nil)
+	_, ok := nop.Status(nil)
+	if ok {
+		t.Fatal("nop status reporter must not return a status")
+	}
 }
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zpages // import "go.opentelemetry.io/collector/service/internal/zpages"
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// formatParam is the URL parameter selecting the format of a zPage.
+	formatParam = "format"
+	formatJSON  = "json"
+)
+
+// IsJSONRequest returns whether the zPage must be written as JSON, i.e. when the request has the
+// "format=json" URL parameter.
+func IsJSONRequest(r *http.Request) bool {
+	return r.URL.Query().Get(formatParam) == formatJSON
+}
+
+// WriteJSON writes the given data as an indented JSON document, the errors are logged with the given logger.
+func WriteJSON(w http.ResponseWriter, data any, logger *zap.Logger) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		logger.Warn("Failed to write zPage as JSON", zap.Error(err))
+	}
+}
+
+// ServiceData contains the JSON representation of the service zPage.
+type ServiceData struct {
+	BuildInfo   BuildInfoData     `json:"build_info"`
+	RuntimeInfo map[string]string `json:"runtime_info"`
+}
+
+// BuildInfoData contains the JSON representation of component.BuildInfo.
+type BuildInfoData struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// PipelinesData contains the JSON representation of the pipelines zPage.
+type PipelinesData struct {
+	Pipelines []PipelineData `json:"pipelines"`
+}
+
+// PipelineData contains the JSON representation of a pipeline.
+type PipelineData struct {
+	ID          string          `json:"id"`
+	InputType   string          `json:"input_type"`
+	MutatesData bool            `json:"mutates_data"`
+	Receivers   []ComponentData `json:"receivers"`
+	Processors  []ComponentData `json:"processors"`
+	Exporters   []ComponentData `json:"exporters"`
+}
+
+// ExtensionsData contains the JSON representation of the extensions zPage.
+type ExtensionsData struct {
+	Extensions []ComponentData `json:"extensions"`
+}
+
+// ComponentData contains the JSON representation of a component instance.
+type ComponentData struct {
+	ID         string     `json:"id"`
+	Kind       string     `json:"kind"`
+	Stability  string     `json:"stability,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	StatusTime *time.Time `json:"status_time,omitempty"`
+}
+
+// NewComponentData returns the ComponentData of a component instance.
+// The status event is nil if the component did not report any status.
+func NewComponentData(id component.ID, kind component.Kind, stability component.StabilityLevel, ev *component.StatusEvent) ComponentData {
+	data := ComponentData{
+		ID:   id.String(),
+		Kind: strings.ToLower(kind.String()),
+	}
+	if stability != component.StabilityLevelUndefined {
+		data.Stability = strings.ToLower(stability.String())
+	}
+	if ev != nil {
+		ts := ev.Timestamp()
+		data.Status = ev.Status().String()
+		data.StatusTime = &ts
+		if ev.Err() != nil {
+			data.Error = ev.Err().Error()
+		}
+	}
+	return data
+}
+
+// FeatureGatesData contains the JSON representation of the feature gates zPage.
+type FeatureGatesData struct {
+	FeatureGates []FeatureGateData `json:"feature_gates"`
+}
+
+// FeatureGateData contains the JSON representation of a feature gate.
+type FeatureGateData struct {
+	ID           string `json:"id"`
+	Enabled      bool   `json:"enabled"`
+	Description  string `json:"description"`
+	Stage        string `json:"stage"`
+	FromVersion  string `json:"from_version,omitempty"`
+	ToVersion    string `json:"to_version,omitempty"`
+	ReferenceURL string `json:"reference_url,omitempty"`
+}
+
+// LogLevelsData contains the JSON representation of the log levels zPage.
+type LogLevelsData struct {
+	Level      string                  `json:"level"`
+	ExpiresAt  *time.Time              `json:"expires_at,omitempty"`
+	Configured []PatternLogLevelData   `json:"configured"`
+	Components []ComponentLogLevelData `json:"components"`
+}
+
+// PatternLogLevelData contains the JSON representation of the configured log level of the components selected by a pattern.
+type PatternLogLevelData struct {
+	Pattern string `json:"pattern"`
+	Level   string `json:"level"`
+}
+
+// ComponentLogLevelData contains the JSON representation of the log level of a component.
+type ComponentLogLevelData struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	Level     string     `json:"level"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package zpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestIsJSONRequest(t *testing.T) {
+	assert.True(t, IsJSONRequest(httptest.NewRequest(http.MethodGet, "/debug/servicez?format=json", nil)))
+	assert.False(t, IsJSONRequest(httptest.NewRequest(http.MethodGet, "/debug/servicez", nil)))
+	assert.False(t, IsJSONRequest(httptest.NewRequest(http.MethodGet, "/debug/servicez?format=html", nil)))
+}
+
+func TestWriteJSON(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	rr := httptest.NewRecorder()
+	WriteJSON(rr, ExtensionsData{Extensions: []ComponentData{{ID: "nop", Kind: "extension"}}}, zap.New(core))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"extensions":[{"id":"nop","kind":"extension"}]}`, rr.Body.String())
+	assert.Zero(t, logs.Len())
+
+	WriteJSON(httptest.NewRecorder(), make(chan int), zap.New(core))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Failed to write zPage as JSON", logs.All()[0].Message)
+}
+
+func TestNewComponentData(t *testing.T) {
+	id := component.MustNewIDWithName("nop", "1")
+
+	data := NewComponentData(id, component.KindReceiver, component.StabilityLevelUndefined, nil)
+	assert.Equal(t, ComponentData{ID: "nop/1", Kind: "receiver"}, data)
+
+	ev := component.NewRecoverableErrorEvent(errors.New("transient"))
+	data = NewComponentData(id, component.KindExporter, component.StabilityLevelBeta, ev)
+	assert.Equal(t, "nop/1", data.ID)
+	assert.Equal(t, "exporter", data.Kind)
+	assert.Equal(t, "beta", data.Stability)
+	assert.Equal(t, component.StatusRecoverableError.String(), data.Status)
+	assert.Equal(t, "transient", data.Error)
+	if assert.NotNil(t, data.StatusTime) {
+		assert.Equal(t, ev.Timestamp(), *data.StatusTime)
+	}
+}
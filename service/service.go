@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"runtime"
 
+	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/multierr"
@@ -29,7 +30,9 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/internal/resource"
 	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
@@ -71,12 +74,13 @@ type Settings struct {
 type Service struct {
 	buildInfo         component.BuildInfo
 	telemetrySettings servicetelemetry.TelemetrySettings
+	loggerProvider    log.LoggerProvider
 	host              *serviceHost
 	collectorConf     *confmap.Conf
 }
 
 // New creates a new Service, its telemetry, and Components.
-func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
+func New(ctx context.Context, set Settings, cfg Config) (_ *Service, err error) {
 	disableHighCard := obsreportconfig.DisableHighCardinalityMetricsfeatureGate.IsEnabled()
 	extendedConfig := obsreportconfig.UseOtelWithSDKConfigurationForInternalTelemetryFeatureGate.IsEnabled()
 	srv := &Service{
@@ -89,6 +93,8 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			extensions:        set.Extensions,
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			health:            health.NewAggregator(cfg.Health),
+			logLevels:         loglevel.NewController(),
 		},
 		collectorConf: set.CollectorConf,
 	}
@@ -101,17 +107,27 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	telset := telemetry.Settings{
 		BuildInfo:  set.BuildInfo,
 		ZapOptions: set.LoggingOptions,
+		LogLevels:  srv.host.logLevels,
 	}
 
-	logger, err := telFactory.CreateLogger(ctx, telset, &cfg.Telemetry)
+	logger, lp, err := telFactory.CreateLogger(ctx, telset, &cfg.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
+	srv.loggerProvider = lp
+	srv.host.logger = logger
+	// The telemetry providers created so far, starting with the logger provider, are shut down on any error.
+	defer func() {
+		if err != nil {
+			err = multierr.Append(err, srv.shutdownTelemetry(ctx))
+		}
+	}()
 
 	tracerProvider, err := telFactory.CreateTracerProvider(ctx, telset, &cfg.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
 	}
+	srv.telemetrySettings.TracerProvider = tracerProvider
 
 	logger.Info("Setting up own telemetry...")
 
@@ -147,13 +163,11 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	}
 
 	if err = srv.initGraph(ctx, set, cfg); err != nil {
-		err = multierr.Append(err, srv.shutdownTelemetry(ctx))
 		return nil, err
 	}
 
 	// process the configuration and initialize the pipeline
 	if err = srv.initExtensions(ctx, cfg.Extensions); err != nil {
-		err = multierr.Append(err, srv.shutdownTelemetry(ctx))
 		return nil, err
 	}
 
@@ -227,7 +241,7 @@ func (srv *Service) Start(ctx context.Context) error {
 }
 
 func (srv *Service) shutdownTelemetry(ctx context.Context) error {
-	// The metric.MeterProvider and trace.TracerProvider interfaces do not have a Shutdown method.
+	// The metric.MeterProvider, trace.TracerProvider and log.LoggerProvider interfaces do not have a Shutdown method.
 	// To shutdown the providers we try to cast to this interface, which matches the type signature used in the SDK.
 	type shutdownable interface {
 		Shutdown(context.Context) error
@@ -245,6 +259,12 @@ func (srv *Service) shutdownTelemetry(ctx context.Context) error {
 			err = multierr.Append(err, fmt.Errorf("failed to shutdown tracer provider: %w", shutdownErr))
 		}
 	}
+
+	if prov, ok := srv.loggerProvider.(shutdownable); ok {
+		if shutdownErr := prov.Shutdown(ctx); shutdownErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to shutdown logger provider: %w", shutdownErr))
+		}
+	}
 	return err
 }
 
@@ -304,6 +324,7 @@ func (srv *Service) initGraph(ctx context.Context, set Settings, cfg Config) err
 		ExporterBuilder:  set.Exporters,
 		ConnectorBuilder: set.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
+		EnableTaps:       hasZPagesExtension(cfg.Extensions),
 	}); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
@@ -6,17 +6,21 @@ package service
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -252,6 +256,37 @@ func TestServiceTelemetryCleanupOnError(t *testing.T) {
 	assert.NoError(t, srv.Shutdown(context.Background()))
 }
 
+// TestServiceLoggerProviderShutdownOnError tests that the logger provider is shut down, flushing the logs
+// it batched, when New fails after the logger is created.
+func TestServiceLoggerProviderShutdownOnError(t *testing.T) {
+	var received atomic.Int64
+	otlpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer otlpSrv.Close()
+
+	cfg := newNopConfig()
+	cfg.Telemetry.Logs.Processors = []config.LogRecordProcessor{
+		{
+			Batch: &config.BatchLogRecordProcessor{
+				Exporter: config.LogRecordExporter{
+					OTLP: &config.OTLP{
+						Protocol: "http/protobuf",
+						Endpoint: otlpSrv.URL,
+					},
+				},
+			},
+		},
+	}
+	// The meter provider cannot be created with an address without port.
+	cfg.Telemetry.Metrics.Address = "localhost"
+
+	_, err := New(context.Background(), newNopSettings(), cfg)
+	require.Error(t, err)
+	assert.Positive(t, received.Load())
+}
+
 func TestServiceTelemetry(t *testing.T) {
 	for _, tc := range ownMetricsTestCases() {
 		t.Run(fmt.Sprintf("ipv4_%s", tc.name), func(t *testing.T) {
@@ -517,6 +552,7 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/healthz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -532,6 +568,30 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 	for _, path := range paths {
 		testZPagePathFn(t, path)
 	}
+
+	jsonPaths := []string{
+		"/debug/pipelinez",
+		"/debug/servicez",
+		"/debug/extensionz",
+		"/debug/featurez",
+	}
+
+	testJSONZPagePathFn := func(t *testing.T, path string) {
+		client := &http.Client{}
+		resp, err := client.Get("http://" + zpagesAddr + path + "?format=json")
+		if !assert.NoError(t, err, "error retrieving zpage at %q", path) {
+			return
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "unsuccessful zpage %q GET", path)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var data map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&data), "invalid json for zpage %q", path)
+	}
+
+	for _, path := range jsonPaths {
+		testJSONZPagePathFn(t, path)
+	}
 }
 
 func newNopSettings() Settings {
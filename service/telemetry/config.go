@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -34,6 +35,17 @@ type LogsConfig struct {
 	// (default = "INFO")
 	Level zapcore.Level `mapstructure:"level"`
 
+	// ComponentLevels overrides Level for the loggers of the components selected by the keys,
+	// which have the form `[<kind>:]<type>[/<name>]`, the type or the name can be `*`. Example:
+	//
+	// 		component_levels:
+	//	   		exporter:otlp/2: debug
+	//	   		receiver:*: warn
+	//
+	// When several keys select a component, the most specific one applies.
+	// By default, all the components log with Level.
+	ComponentLevels map[string]zapcore.Level `mapstructure:"component_levels"`
+
 	// Development puts the logger in development mode, which changes the
 	// behavior of DPanicLevel and takes stacktraces more liberally.
 	// (default = false)
@@ -92,6 +104,11 @@ type LogsConfig struct {
 	//
 	// By default, there is no initial field.
 	InitialFields map[string]any `mapstructure:"initial_fields"`
+
+	// Processors allow configuration of log record processors to emit the collector's
+	// own logs to any number of supported backends, in addition to the output paths.
+	// Experimental: *NOTE* this field is subject to change or removal in the future.
+	Processors []config.LogRecordProcessor `mapstructure:"processors"`
 }
 
 // LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the
@@ -120,7 +137,7 @@ type MetricsConfig struct {
 	Level configtelemetry.Level `mapstructure:"level"`
 
 	// Address is the [address]:port that metrics exposition should be bound to.
-	Address string `mapstructure:"address"`
+	Address string `mapstructure:"address" deprecated:"Deprecated in favor of service::telemetry::metrics::readers."`
 
 	// Readers allow configuration of metric readers to emit metrics to
 	// any number of supported backends.
@@ -146,5 +163,11 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
 	}
 
+	for pattern := range c.Logs.ComponentLevels {
+		if _, err := loglevel.ParsePattern(pattern); err != nil {
+			return fmt.Errorf("invalid logs component level: %w", err)
+		}
+	}
+
 	return nil
 }
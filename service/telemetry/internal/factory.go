@@ -6,6 +6,8 @@ package internal // import "go.opentelemetry.io/collector/service/telemetry/inte
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/log"
+	lognoop "go.opentelemetry.io/otel/log/noop"
 	"go.opentelemetry.io/otel/metric"
 	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
@@ -13,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 // Settings holds configuration for building Telemetry.
@@ -20,6 +23,8 @@ type Settings struct {
 	BuildInfo         component.BuildInfo
 	AsyncErrorChannel chan error
 	ZapOptions        []zap.Option
+	// LogLevels, if set, controls the level of the logger, allowing to change it at runtime.
+	LogLevels *loglevel.Controller
 }
 
 // Factory is factory interface for telemetry.
@@ -30,8 +35,9 @@ type Factory interface {
 	// TODO: Should we just inherit from component.Factory?
 	CreateDefaultConfig() component.Config
 
-	// CreateLogger creates a logger.
-	CreateLogger(ctx context.Context, set Settings, cfg component.Config) (*zap.Logger, error)
+	// CreateLogger creates a logger, and the LoggerProvider its entries are emitted to, if any.
+	// The LoggerProvider must be shut down to flush the pending log records.
+	CreateLogger(ctx context.Context, set Settings, cfg component.Config) (*zap.Logger, log.LoggerProvider, error)
 
 	// CreateTracerProvider creates a TracerProvider.
 	CreateTracerProvider(ctx context.Context, set Settings, cfg component.Config) (trace.TracerProvider, error)
@@ -73,7 +79,7 @@ func (f *factory) CreateDefaultConfig() component.Config {
 }
 
 // CreateLoggerFunc is the equivalent of Factory.CreateLogger.
-type CreateLoggerFunc func(context.Context, Settings, component.Config) (*zap.Logger, error)
+type CreateLoggerFunc func(context.Context, Settings, component.Config) (*zap.Logger, log.LoggerProvider, error)
 
 // WithLogger overrides the default no-op logger.
 func WithLogger(createLogger CreateLoggerFunc) FactoryOption {
@@ -82,9 +88,9 @@ func WithLogger(createLogger CreateLoggerFunc) FactoryOption {
 	})
 }
 
-func (f *factory) CreateLogger(ctx context.Context, set Settings, cfg component.Config) (*zap.Logger, error) {
+func (f *factory) CreateLogger(ctx context.Context, set Settings, cfg component.Config) (*zap.Logger, log.LoggerProvider, error) {
 	if f.CreateLoggerFunc == nil {
-		return zap.NewNop(), nil
+		return zap.NewNop(), lognoop.NewLoggerProvider(), nil
 	}
 	return f.CreateLoggerFunc(ctx, set, cfg)
 }
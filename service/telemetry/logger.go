@@ -4,14 +4,37 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/log"
+	lognoop "go.opentelemetry.io/otel/log/noop"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
-func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
+// loggerScopeName is the instrumentation scope of the log records emitted for the collector's own logs.
+const loggerScopeName = "go.opentelemetry.io/collector/service"
+
+// newLogger creates the collector's logger. When log record processors are configured,
+// the log entries are also emitted to the returned LoggerProvider.
+func newLogger(ctx context.Context, set Settings, c Config) (*zap.Logger, log.LoggerProvider, error) {
+	cfg := c.Logs
+	levels := set.LogLevels
+	if levels == nil {
+		levels = loglevel.NewController()
+	}
+	if err := levels.Configure(cfg.Level, cfg.ComponentLevels); err != nil {
+		return nil, nil, err
+	}
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(cfg.Level),
+		// All the levels are enabled by the core, the entries are filtered by the level controller wrapping it.
+		Level:             zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development:       cfg.Development,
 		Encoding:          cfg.Encoding,
 		EncoderConfig:     zap.NewProductionEncoderConfig(),
@@ -27,15 +50,59 @@ func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
+	var lp log.LoggerProvider = lognoop.NewLoggerProvider()
+	shutdown := func(context.Context) error { return nil }
+	options := set.ZapOptions
+	if len(cfg.Processors) > 0 {
+		sdk, err := config.NewSDK(
+			config.WithContext(ctx),
+			config.WithOpenTelemetryConfiguration(
+				config.OpenTelemetryConfiguration{
+					Resource: newResource(set, c),
+					LoggerProvider: &config.LoggerProvider{
+						Processors: cfg.Processors,
+					},
+				},
+			),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		lp, shutdown = sdk.LoggerProvider(), sdk.Shutdown
+
+		// The initial fields are added to the core built from the zap.Config before this option
+		// is applied, so they need to be added to the OpenTelemetry core explicitly.
+		otelCore := newOTelCore(lp.Logger(loggerScopeName), zapCfg.Level).With(initialFields(cfg.InitialFields))
+		options = append(options[:len(options):len(options)], zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, otelCore)
+		}))
+	}
+
+	options = append(options[:len(options):len(options)], zap.WrapCore(levels.WrapCore))
+
 	logger, err := zapCfg.Build(options...)
 	if err != nil {
-		return nil, err
+		return nil, nil, errors.Join(err, shutdown(ctx))
 	}
 	if cfg.Sampling != nil && cfg.Sampling.Enabled {
 		logger = newSampledLogger(logger, cfg.Sampling)
 	}
 
-	return logger, nil
+	return logger, lp, nil
+}
+
+func initialFields(fields map[string]any) []zap.Field {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, k := range keys {
+		zapFields = append(zapFields, zap.Any(k, fields[k]))
+	}
+	return zapFields
 }
 
 func newSampledLogger(logger *zap.Logger, sc *LogsSamplingConfig) *zap.Logger {
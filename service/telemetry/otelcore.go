@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// Attribute keys following the OpenTelemetry semantic conventions.
+const (
+	attributeCodeFilepath = "code.filepath"
+	attributeCodeFunction = "code.function"
+	attributeCodeLineno   = "code.lineno"
+	attributeStacktrace   = "exception.stacktrace"
+	attributeLoggerName   = "logger"
+)
+
+// otelCore is a zapcore.Core that emits the log entries as OpenTelemetry log records.
+type otelCore struct {
+	zapcore.LevelEnabler
+	logger log.Logger
+	attrs  []log.KeyValue
+}
+
+var _ zapcore.Core = (*otelCore)(nil)
+
+func newOTelCore(logger log.Logger, enabler zapcore.LevelEnabler) *otelCore {
+	return &otelCore{LevelEnabler: enabler, logger: logger}
+}
+
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.attrs = append(c.attrs[:len(c.attrs):len(c.attrs)], convertFields(fields)...)
+	return &clone
+}
+
+func (c *otelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *otelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	r := log.Record{}
+	r.SetTimestamp(ent.Time)
+	r.SetObservedTimestamp(time.Now())
+	r.SetBody(log.StringValue(ent.Message))
+	r.SetSeverity(convertLevel(ent.Level))
+	r.SetSeverityText(ent.Level.String())
+
+	r.AddAttributes(c.attrs...)
+	r.AddAttributes(convertFields(fields)...)
+	if ent.LoggerName != "" {
+		r.AddAttributes(log.String(attributeLoggerName, ent.LoggerName))
+	}
+	if ent.Caller.Defined {
+		r.AddAttributes(
+			log.String(attributeCodeFilepath, ent.Caller.File),
+			log.Int(attributeCodeLineno, ent.Caller.Line),
+		)
+		if ent.Caller.Function != "" {
+			r.AddAttributes(log.String(attributeCodeFunction, ent.Caller.Function))
+		}
+	}
+	if ent.Stack != "" {
+		r.AddAttributes(log.String(attributeStacktrace, ent.Stack))
+	}
+
+	c.logger.Emit(context.Background(), r)
+	return nil
+}
+
+// Sync is a no-op, the records are flushed when the LoggerProvider is shut down.
+func (c *otelCore) Sync() error {
+	return nil
+}
+
+func convertLevel(level zapcore.Level) log.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return log.SeverityDebug
+	case zapcore.InfoLevel:
+		return log.SeverityInfo
+	case zapcore.WarnLevel:
+		return log.SeverityWarn
+	case zapcore.ErrorLevel:
+		return log.SeverityError
+	case zapcore.DPanicLevel:
+		return log.SeverityFatal1
+	case zapcore.PanicLevel:
+		return log.SeverityFatal2
+	case zapcore.FatalLevel:
+		return log.SeverityFatal3
+	default:
+		return log.SeverityUndefined
+	}
+}
+
+// convertFields encodes the zap fields, and converts the result to log attributes sorted by key.
+func convertFields(fields []zapcore.Field) []log.KeyValue {
+	if len(fields) == 0 {
+		return nil
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return convertMap(enc.Fields)
+}
+
+func convertMap(m map[string]any) []log.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]log.KeyValue, 0, len(m))
+	for _, k := range keys {
+		kvs = append(kvs, log.KeyValue{Key: k, Value: convertValue(m[k])})
+	}
+	return kvs
+}
+
+// convertValue converts a value stored by a zapcore.MapObjectEncoder to a log.Value.
+func convertValue(v any) log.Value {
+	switch val := v.(type) {
+	case nil:
+		return log.Value{}
+	case string:
+		return log.StringValue(val)
+	case bool:
+		return log.BoolValue(val)
+	case []byte:
+		return log.BytesValue(val)
+	case int:
+		return log.IntValue(val)
+	case int8:
+		return log.Int64Value(int64(val))
+	case int16:
+		return log.Int64Value(int64(val))
+	case int32:
+		return log.Int64Value(int64(val))
+	case int64:
+		return log.Int64Value(val)
+	case uint8:
+		return log.Int64Value(int64(val))
+	case uint16:
+		return log.Int64Value(int64(val))
+	case uint32:
+		return log.Int64Value(int64(val))
+	case uint:
+		return convertUint(uint64(val))
+	case uint64:
+		return convertUint(val)
+	case uintptr:
+		return convertUint(uint64(val))
+	case float32:
+		return log.Float64Value(float64(val))
+	case float64:
+		return log.Float64Value(val)
+	case time.Time:
+		return log.StringValue(val.Format(time.RFC3339Nano))
+	case time.Duration:
+		return log.StringValue(val.String())
+	case []any:
+		vals := make([]log.Value, 0, len(val))
+		for _, item := range val {
+			vals = append(vals, convertValue(item))
+		}
+		return log.SliceValue(vals...)
+	case map[string]any:
+		return log.MapValue(convertMap(val)...)
+	default:
+		return log.StringValue(fmt.Sprint(val))
+	}
+}
+
+func convertUint(v uint64) log.Value {
+	if v > math.MaxInt64 {
+		return log.StringValue(fmt.Sprint(v))
+	}
+	return log.Int64Value(int64(v))
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestOTelCore(t *testing.T) {
+	rec := logtest.NewRecorder()
+	core := newOTelCore(rec.Logger(loggerScopeName), zapcore.InfoLevel)
+	logger := zap.New(core, zap.AddCaller()).Named("test").With(zap.String("component", "otlp"))
+
+	logger.Debug("not enabled")
+	logger.Warn("something happened",
+		zap.Int("count", 3),
+		zap.Bool("retry", true),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("delay", time.Second),
+		zap.Error(errors.New("failure")),
+		zap.Strings("items", []string{"a", "b"}),
+		zap.Uint64("huge", 1<<63),
+	)
+
+	result := rec.Result()
+	require.Len(t, result, 1)
+	assert.Equal(t, loggerScopeName, result[0].Name)
+	require.Len(t, result[0].Records, 1)
+
+	r := result[0].Records[0]
+	assert.Equal(t, log.StringValue("something happened"), r.Body())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "warn", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+
+	attrs := map[string]log.Value{}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	assert.Equal(t, log.StringValue("otlp"), attrs["component"])
+	assert.Equal(t, log.Int64Value(3), attrs["count"])
+	assert.Equal(t, log.BoolValue(true), attrs["retry"])
+	assert.Equal(t, log.Float64Value(0.5), attrs["ratio"])
+	assert.Equal(t, log.StringValue("1s"), attrs["delay"])
+	assert.Equal(t, log.StringValue("failure"), attrs["error"])
+	assert.Equal(t, log.SliceValue(log.StringValue("a"), log.StringValue("b")), attrs["items"])
+	assert.Equal(t, log.StringValue("9223372036854775808"), attrs["huge"])
+	assert.Equal(t, log.StringValue("test"), attrs[attributeLoggerName])
+	assert.Equal(t, log.KindString, attrs[attributeCodeFilepath].Kind())
+	assert.Equal(t, log.KindInt64, attrs[attributeCodeLineno].Kind())
+}
+
+func TestConvertLevel(t *testing.T) {
+	tests := []struct {
+		level    zapcore.Level
+		expected log.Severity
+	}{
+		{level: zapcore.DebugLevel, expected: log.SeverityDebug},
+		{level: zapcore.InfoLevel, expected: log.SeverityInfo},
+		{level: zapcore.WarnLevel, expected: log.SeverityWarn},
+		{level: zapcore.ErrorLevel, expected: log.SeverityError},
+		{level: zapcore.DPanicLevel, expected: log.SeverityFatal1},
+		{level: zapcore.PanicLevel, expected: log.SeverityFatal2},
+		{level: zapcore.FatalLevel, expected: log.SeverityFatal3},
+		{level: zapcore.InvalidLevel, expected: log.SeverityUndefined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, convertLevel(tt.level))
+		})
+	}
+}
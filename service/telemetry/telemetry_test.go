@@ -5,14 +5,23 @@ package telemetry
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 func TestTelemetryConfiguration(t *testing.T) {
@@ -54,7 +63,7 @@ func TestTelemetryConfiguration(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			f := NewFactory()
 			set := Settings{ZapOptions: []zap.Option{}}
-			logger, err := f.CreateLogger(context.Background(), set, tt.cfg)
+			logger, _, err := f.CreateLogger(context.Background(), set, tt.cfg)
 			if tt.success {
 				assert.NoError(t, err)
 				assert.NotNil(t, logger)
@@ -113,9 +122,109 @@ func TestSampledLogger(t *testing.T) {
 			f := NewFactory()
 			ctx := context.Background()
 			set := Settings{ZapOptions: []zap.Option{}}
-			logger, err := f.CreateLogger(ctx, set, tt.cfg)
+			logger, _, err := f.CreateLogger(ctx, set, tt.cfg)
 			assert.NoError(t, err)
 			assert.NotNil(t, logger)
 		})
 	}
 }
+
+func TestLoggerWithProcessors(t *testing.T) {
+	var received atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, body)
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.OutputPaths = []string{}
+	cfg.Logs.Processors = []config.LogRecordProcessor{
+		{
+			Simple: &config.SimpleLogRecordProcessor{
+				Exporter: config.LogRecordExporter{
+					OTLP: &config.OTLP{
+						Protocol: "http/protobuf",
+						Endpoint: srv.URL,
+					},
+				},
+			},
+		},
+	}
+
+	logger, lp, err := NewFactory().CreateLogger(context.Background(), Settings{}, cfg)
+	require.NoError(t, err)
+	logger.Info("exported over OTLP")
+	assert.Equal(t, int64(1), received.Load())
+
+	prov, ok := lp.(interface{ Shutdown(context.Context) error })
+	require.True(t, ok)
+	require.NoError(t, prov.Shutdown(context.Background()))
+}
+
+func TestLoggerWithInvalidProcessors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Processors = []config.LogRecordProcessor{
+		{
+			Simple: &config.SimpleLogRecordProcessor{
+				Exporter: config.LogRecordExporter{
+					OTLP: &config.OTLP{Protocol: "invalid"},
+				},
+			},
+		},
+	}
+
+	_, _, err := NewFactory().CreateLogger(context.Background(), Settings{}, cfg)
+	assert.ErrorContains(t, err, `unsupported protocol "invalid"`)
+}
+
+func TestLoggerLevelChangedAtRuntime(t *testing.T) {
+	var entries atomic.Int64
+	hook := func(zapcore.Entry) error {
+		entries.Add(1)
+		return nil
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Level = zapcore.WarnLevel
+	cfg.Logs.OutputPaths = []string{}
+	cfg.Logs.Sampling = nil
+	levels := loglevel.NewController()
+
+	logger, _, err := NewFactory().CreateLogger(context.Background(), Settings{ZapOptions: []zap.Option{zap.Hooks(hook)}, LogLevels: levels}, cfg)
+	require.NoError(t, err)
+	logger.Info("filtered")
+	assert.Equal(t, int64(0), entries.Load())
+
+	levels.SetLevel(zapcore.DebugLevel, time.Hour)
+	logger.Debug("logged")
+	assert.Equal(t, int64(1), entries.Load())
+
+	levels.ResetLevel()
+	logger.Info("filtered")
+	assert.Equal(t, int64(1), entries.Load())
+}
+
+func TestLoggerWithComponentLevels(t *testing.T) {
+	var entries atomic.Int64
+	hook := func(zapcore.Entry) error {
+		entries.Add(1)
+		return nil
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Level = zapcore.WarnLevel
+	cfg.Logs.ComponentLevels = map[string]zapcore.Level{"exporter:otlp": zapcore.DebugLevel}
+	cfg.Logs.OutputPaths = []string{}
+	cfg.Logs.Sampling = nil
+
+	logger, _, err := NewFactory().CreateLogger(context.Background(), Settings{ZapOptions: []zap.Option{zap.Hooks(hook)}}, cfg)
+	require.NoError(t, err)
+	components.ExporterLogger(logger, component.MustNewID("otlp"), component.DataTypeTraces).Debug("logged")
+	components.ExporterLogger(logger, component.MustNewID("debug"), component.DataTypeTraces).Info("filtered")
+	components.ReceiverLogger(logger, component.MustNewID("otlp"), component.DataTypeTraces).Info("filtered")
+	logger.Info("filtered")
+	assert.Equal(t, int64(1), entries.Load())
+}
@@ -27,30 +27,11 @@ var (
 
 // New creates a new Telemetry from Config.
 func newTracerProvider(ctx context.Context, set Settings, cfg Config) (trace.TracerProvider, error) {
-	attrs := map[string]interface{}{
-		string(semconv.ServiceNameKey): set.BuildInfo.Version,
-	}
-	for k, v := range cfg.Resource {
-		if v != nil {
-			attrs[k] = *v
-		}
-
-		// the new value is nil, delete the existing key
-		if _, ok := attrs[k]; ok && v == nil {
-			delete(attrs, k)
-		}
-	}
-	sch := semconv.SchemaURL
-	res := config.Resource{
-		SchemaUrl:  &sch,
-		Attributes: attrs,
-	}
-
 	sdk, err := config.NewSDK(
 		config.WithContext(ctx),
 		config.WithOpenTelemetryConfiguration(
 			config.OpenTelemetryConfiguration{
-				Resource: &res,
+				Resource: newResource(set, cfg),
 				TracerProvider: &config.TracerProvider{
 					Processors: cfg.Traces.Processors,
 					// TODO: once https://github.com/open-telemetry/opentelemetry-configuration/issues/83 is resolved,
@@ -90,6 +71,28 @@ func newTracerProvider(ctx context.Context, set Settings, cfg Config) (trace.Tra
 	return sdk.TracerProvider(), nil
 }
 
+// newResource returns the resource of the telemetry emitted through the OpenTelemetry SDK.
+func newResource(set Settings, cfg Config) *config.Resource {
+	attrs := map[string]interface{}{
+		string(semconv.ServiceNameKey): set.BuildInfo.Version,
+	}
+	for k, v := range cfg.Resource {
+		if v != nil {
+			attrs[k] = *v
+		}
+
+		// the new value is nil, delete the existing key
+		if _, ok := attrs[k]; ok && v == nil {
+			delete(attrs, k)
+		}
+	}
+	sch := semconv.SchemaURL
+	return &config.Resource{
+		SchemaUrl:  &sch,
+		Attributes: attrs,
+	}
+}
+
 func textMapPropagatorFromConfig(props []string) (propagation.TextMapPropagator, error) {
 	var textMapPropagators []propagation.TextMapPropagator
 	for _, prop := range props {
@@ -4,13 +4,20 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"fmt"
 	"net/http"
 	"path"
 	"runtime"
+	"strings"
 	"time"
 
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -20,11 +27,26 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zHealthPath    = "healthz"
+	zTapPath       = "tapz"
+	zLogLevelPath  = "loglevelz"
+
+	// URL Params
+	zComponentName = "componentnamez"
+	zComponentKind = "componentkindz"
+	zLevel         = "levelz"
+	zTTL           = "ttlz"
+
+	defaultLogLevelTTL = 10 * time.Minute
+	maxLogLevelTTL     = 24 * time.Hour
 )
 
 var (
 	// InfoVar is a singleton instance of the Info struct.
 	runtimeInfoVar [][2]string
+
+	// zPagesExtensionType is the type of the extension registering the zPages of the host.
+	zPagesExtensionType = component.MustNewType("zpages")
 )
 
 func init() {
@@ -41,10 +63,35 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.serviceExtensions.HandleZPages)
-	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), host.handleFeaturezRequest)
+	mux.Handle(path.Join(pathPrefix, zHealthPath), host.health)
+	mux.HandleFunc(path.Join(pathPrefix, zTapPath), host.pipelines.HandleTapZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zLogLevelPath), host.handleLogLevelzRequest)
+}
+
+// hasZPagesExtension returns whether the extensions of the service include a zpages extension, which the data
+// flowing through the pipelines is only tapped for.
+func hasZPagesExtension(cfg extensions.Config) bool {
+	for _, id := range cfg {
+		if id.Type() == zPagesExtensionType {
+			return true
+		}
+	}
+	return false
 }
 
-func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
+func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
+	if zpages.IsJSONRequest(r) {
+		zpages.WriteJSON(w, zpages.ServiceData{
+			BuildInfo: zpages.BuildInfoData{
+				Command:     host.buildInfo.Command,
+				Description: host.buildInfo.Description,
+				Version:     host.buildInfo.Version,
+			},
+			RuntimeInfo: getRuntimeInfo(),
+		}, host.logger)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Service " + host.buildInfo.Command})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.buildInfo)})
@@ -67,7 +114,11 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
+func (host *serviceHost) handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	if zpages.IsJSONRequest(r) {
+		zpages.WriteJSON(w, getFeatureGatesData(), host.logger)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
 	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
@@ -90,6 +141,30 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 	return data
 }
 
+func getFeatureGatesData() zpages.FeatureGatesData {
+	data := zpages.FeatureGatesData{FeatureGates: []zpages.FeatureGateData{}}
+	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
+		data.FeatureGates = append(data.FeatureGates, zpages.FeatureGateData{
+			ID:           gate.ID(),
+			Enabled:      gate.IsEnabled(),
+			Description:  gate.Description(),
+			Stage:        gate.Stage().String(),
+			FromVersion:  gate.FromVersion(),
+			ToVersion:    gate.ToVersion(),
+			ReferenceURL: gate.ReferenceURL(),
+		})
+	})
+	return data
+}
+
+func getRuntimeInfo() map[string]string {
+	info := make(map[string]string, len(runtimeInfoVar))
+	for _, kv := range runtimeInfoVar {
+		info[kv[0]] = kv[1]
+	}
+	return info
+}
+
 func getBuildInfoProperties(buildInfo component.BuildInfo) [][2]string {
 	return [][2]string{
 		{"Command", buildInfo.Command},
@@ -97,3 +172,102 @@ func getBuildInfoProperties(buildInfo component.BuildInfo) [][2]string {
 		{"Version", buildInfo.Version},
 	}
 }
+
+// handleLogLevelzRequest returns the log levels as JSON. The PUT method changes the global level, or the level of
+// a component if one is selected, until a TTL expires. The DELETE method reverts it immediately.
+// Changing the levels requires the request to be authenticated by the server exposing the zPages.
+func (host *serviceHost) handleLogLevelzRequest(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodDelete:
+		if client.FromContext(r.Context()).Auth == nil {
+			http.Error(w, "changing the log levels requires an authenticated request", http.StatusUnauthorized)
+			return
+		}
+		if err := host.changeLogLevel(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodDelete}, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	zpages.WriteJSON(w, getLogLevelsData(host.logLevels.State()), host.logger)
+}
+
+func (host *serviceHost) changeLogLevel(r *http.Request) error {
+	qValues := r.URL.Query()
+	var comp *loglevel.Component
+	if kind, name := qValues.Get(zComponentKind), qValues.Get(zComponentName); kind != "" || name != "" {
+		comp = &loglevel.Component{}
+		var ok bool
+		if comp.Kind, ok = loglevel.ParseKind(kind); !ok {
+			return fmt.Errorf("%s must be one of receiver, processor, exporter, extension or connector", zComponentKind)
+		}
+		if err := comp.ID.UnmarshalText([]byte(name)); err != nil {
+			return fmt.Errorf("invalid %s: %w", zComponentName, err)
+		}
+	}
+
+	if r.Method == http.MethodDelete {
+		if comp != nil {
+			host.logLevels.ResetComponentLevel(*comp)
+		} else {
+			host.logLevels.ResetLevel()
+		}
+		return nil
+	}
+
+	if qValues.Get(zLevel) == "" {
+		return fmt.Errorf("%s is required", zLevel)
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(qValues.Get(zLevel))); err != nil {
+		return fmt.Errorf("invalid %s: %w", zLevel, err)
+	}
+	ttl := defaultLogLevelTTL
+	if v := qValues.Get(zTTL); v != "" {
+		var err error
+		if ttl, err = time.ParseDuration(v); err != nil || ttl <= 0 || ttl > maxLogLevelTTL {
+			return fmt.Errorf("%s must be a positive duration not greater than %v", zTTL, maxLogLevelTTL)
+		}
+	}
+	if comp != nil {
+		host.logLevels.SetComponentLevel(*comp, level, ttl)
+	} else {
+		host.logLevels.SetLevel(level, ttl)
+	}
+	return nil
+}
+
+func getLogLevelsData(state loglevel.State) zpages.LogLevelsData {
+	data := zpages.LogLevelsData{
+		Level:      state.Level.String(),
+		ExpiresAt:  expiresAt(state.ExpiresAt),
+		Configured: make([]zpages.PatternLogLevelData, 0, len(state.Configured)),
+		Components: make([]zpages.ComponentLogLevelData, 0, len(state.Components)),
+	}
+	for _, pl := range state.Configured {
+		data.Configured = append(data.Configured, zpages.PatternLogLevelData{
+			Pattern: pl.Pattern.String(),
+			Level:   pl.Level.String(),
+		})
+	}
+	for _, l := range state.Components {
+		data.Components = append(data.Components, zpages.ComponentLogLevelData{
+			ID:        l.ID.String(),
+			Kind:      strings.ToLower(l.Kind.String()),
+			Level:     l.Level.String(),
+			ExpiresAt: expiresAt(l.ExpiresAt),
+		})
+	}
+	return data
+}
+
+func expiresAt(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+type testAuthData struct{}
+
+func (testAuthData) GetAttribute(string) any { return nil }
+
+func (testAuthData) GetAttributeNames() []string { return nil }
+
+func logLevelzRequest(t *testing.T, host *serviceHost, method, query string, authenticated bool) (int, zpages.LogLevelsData) {
+	req := httptest.NewRequest(method, "/debug/loglevelz?"+query, nil)
+	if authenticated {
+		req = req.WithContext(client.NewContext(context.Background(), client.Info{Auth: testAuthData{}}))
+	}
+	rr := httptest.NewRecorder()
+	host.handleLogLevelzRequest(rr, req)
+	var data zpages.LogLevelsData
+	if rr.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &data))
+	}
+	return rr.Code, data
+}
+
+func TestLogLevelzRequest(t *testing.T) {
+	host := &serviceHost{logLevels: loglevel.NewController(), logger: zap.NewNop()}
+	require.NoError(t, host.logLevels.Configure(zapcore.WarnLevel, map[string]zapcore.Level{"receiver:otlp": zapcore.DebugLevel}))
+	defer host.logLevels.ResetLevel()
+
+	code, data := logLevelzRequest(t, host, http.MethodGet, "", false)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "warn", data.Level)
+	assert.Nil(t, data.ExpiresAt)
+	assert.Equal(t, []zpages.PatternLogLevelData{{Pattern: "receiver:otlp", Level: "debug"}}, data.Configured)
+	assert.Empty(t, data.Components)
+
+	code, _ = logLevelzRequest(t, host, http.MethodPut, "levelz=debug", false)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, zapcore.WarnLevel, host.logLevels.State().Level)
+
+	code, data = logLevelzRequest(t, host, http.MethodPut, "levelz=debug&ttlz=1m", true)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "debug", data.Level)
+	assert.NotNil(t, data.ExpiresAt)
+
+	code, data = logLevelzRequest(t, host, http.MethodPut, "levelz=error&componentkindz=exporter&componentnamez=otlp/2", true)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, data.Components, 1)
+	assert.Equal(t, zpages.ComponentLogLevelData{ID: "otlp/2", Kind: "exporter", Level: "error", ExpiresAt: data.Components[0].ExpiresAt}, data.Components[0])
+	assert.NotNil(t, data.Components[0].ExpiresAt)
+
+	code, data = logLevelzRequest(t, host, http.MethodDelete, "componentkindz=exporter&componentnamez=otlp/2", true)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, data.Components)
+
+	code, data = logLevelzRequest(t, host, http.MethodDelete, "", true)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "warn", data.Level)
+}
+
+func TestLogLevelzRequestErrors(t *testing.T) {
+	host := &serviceHost{logLevels: loglevel.NewController(), logger: zap.NewNop()}
+	for _, tt := range []struct {
+		method string
+		query  string
+		code   int
+	}{
+		{method: http.MethodPost, query: "levelz=debug", code: http.StatusMethodNotAllowed},
+		{method: http.MethodPut, query: "", code: http.StatusBadRequest},
+		{method: http.MethodPut, query: "levelz=verbose", code: http.StatusBadRequest},
+		{method: http.MethodPut, query: "levelz=debug&ttlz=48h", code: http.StatusBadRequest},
+		{method: http.MethodPut, query: "levelz=debug&ttlz=-1m", code: http.StatusBadRequest},
+		{method: http.MethodPut, query: "levelz=debug&componentkindz=pipeline&componentnamez=otlp", code: http.StatusBadRequest},
+		{method: http.MethodPut, query: "levelz=debug&componentkindz=receiver", code: http.StatusBadRequest},
+	} {
+		t.Run(tt.method+" "+tt.query, func(t *testing.T) {
+			code, _ := logLevelzRequest(t, host, tt.method, tt.query, true)
+			assert.Equal(t, tt.code, code)
+			state := host.logLevels.State()
+			assert.Equal(t, zapcore.InfoLevel, state.Level)
+			assert.Empty(t, state.Components)
+		})
+	}
+}
+
+func TestHasZPagesExtension(t *testing.T) {
+	assert.False(t, hasZPagesExtension(nil))
+	assert.False(t, hasZPagesExtension(extensions.Config{component.MustNewID("nop")}))
+	assert.True(t, hasZPagesExtension(extensions.Config{component.MustNewID("nop"), component.MustNewIDWithName("zpages", "debug")}))
+}